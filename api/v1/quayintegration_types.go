@@ -22,6 +22,7 @@ import (
 	"strings"
 	"time"
 
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -33,10 +34,20 @@ type QuayIntegrationSpec struct {
 	// +kubebuilder:validation:Required
 	ClusterID string `json:"clusterID"`
 
-	// CredentialsSecret refers to the Secret containing credentials to communicate with the Quay registry.
+	// CredentialsSecret refers to the Secret containing credentials to communicate with the Quay registry. Required unless the credentials are read from another CredentialsSource.
 	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Credentials secret",xDescriptors={"urn:alm:descriptor:io.kubernetes:Secret"}
-	// +kubebuilder:validation:Required
-	CredentialsSecret *SecretRef `json:"credentialsSecret"`
+	// +kubebuilder:validation:Optional
+	CredentialsSecret *SecretRef `json:"credentialsSecret,omitempty"`
+
+	// CredentialsSource selects where the credentials to communicate with the Quay registry are read from. Defaults to the CredentialsSecret.
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Credentials Source"
+	// +kubebuilder:validation:Optional
+	CredentialsSource *CredentialsSource `json:"credentialsSource,omitempty"`
+
+	// Authentication configures how tokens for the Quay API are obtained using the CredentialsSecret. Defaults to a static token.
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Authentication"
+	// +kubebuilder:validation:Optional
+	Authentication *Authentication `json:"authentication,omitempty"`
 
 	// OrganizationPrefix is the prefix assigned to organizations.
 	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Organization Prefix",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:text"}
@@ -67,6 +78,297 @@ type QuayIntegrationSpec struct {
 	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="List of namespaces to include"
 	// +kubebuilder:validation:Optional
 	AllowlistNamespaces []string `json:"allowlistNamespaces,omitempty"`
+
+	// SharedOrganizations are existing Quay organizations that namespaces may select as their target using the target-organization annotation instead of an organization generated for each namespace.
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Shared Organizations"
+	// +kubebuilder:validation:Optional
+	SharedOrganizations []SharedOrganization `json:"sharedOrganizations,omitempty"`
+
+	// RepositoryPathTemplate is a Go template rendering the path of the Quay repository of an ImageStream, which may be nested such as app/component. The fields Namespace, Name, Labels and Annotations of the ImageStream are available. Defaults to the name of the ImageStream.
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Repository Path Template"
+	// +kubebuilder:validation:Optional
+	RepositoryPathTemplate string `json:"repositoryPathTemplate,omitempty"`
+
+	// OrganizationDeletionPolicy determines whether the Quay organization of a namespace is deleted when the namespace is deleted or the QuayIntegration is deleted. Delete deletes the organization and Retain leaves it in place. Namespaces excluded by the allowlist or denylist follow the OffboardingPolicy instead.
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Organization Deletion Policy",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:select:Delete","urn:alm:descriptor:com.tectonic.ui:select:Retain"}
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Enum=Delete;Retain
+	// +kubebuilder:default=Delete
+	OrganizationDeletionPolicy string `json:"organizationDeletionPolicy,omitempty"`
+
+	// OffboardingPolicy determines whether the Quay organization of a namespace is deleted when the namespace is excluded by the allowlist or denylist while it still exists. Delete deletes the organization and Retain leaves it in place, deleting only its robot accounts. Defaults to Retain.
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Offboarding Policy",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:select:Delete","urn:alm:descriptor:com.tectonic.ui:select:Retain"}
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Enum=Delete;Retain
+	// +kubebuilder:default=Retain
+	OffboardingPolicy string `json:"offboardingPolicy,omitempty"`
+
+	// RepositoryDefaults are the settings applied to repositories created for ImageStreams.
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Repository Defaults"
+	// +kubebuilder:validation:Optional
+	RepositoryDefaults *RepositoryDefaults `json:"repositoryDefaults,omitempty"`
+
+	// TagRetention is the tag retention policy applied to managed organizations and repositories.
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Tag Retention"
+	// +kubebuilder:validation:Optional
+	TagRetention *TagRetentionPolicy `json:"tagRetention,omitempty"`
+
+	// OrganizationQuota is the storage quota applied to managed organizations.
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Organization Quota"
+	// +kubebuilder:validation:Optional
+	OrganizationQuota *OrganizationQuota `json:"organizationQuota,omitempty"`
+
+	// OrganizationContact is the contact metadata, such as the email address, applied to managed organizations.
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Organization Contact"
+	// +kubebuilder:validation:Optional
+	OrganizationContact *OrganizationContact `json:"organizationContact,omitempty"`
+
+	// TeamSync synchronizes the users bound to the admin, edit and view roles of a namespace to Quay teams within its organization.
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Team Sync"
+	// +kubebuilder:validation:Optional
+	TeamSync *TeamSync `json:"teamSync,omitempty"`
+
+	// SecurityScan enables reporting the Quay security scan results of images pushed by Builds.
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Security Scan"
+	// +kubebuilder:validation:Optional
+	SecurityScan *SecurityScan `json:"securityScan,omitempty"`
+
+	// InboundSync imports images pushed directly to Quay repositories into the ImageStreams of managed namespaces.
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Inbound Sync"
+	// +kubebuilder:validation:Optional
+	InboundSync *InboundSync `json:"inboundSync,omitempty"`
+
+	// RobotCredentials configures how the credentials of the robot accounts associated to service accounts are delivered to namespaces. Defaults to a Secret written by the operator.
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Robot Credentials"
+	// +kubebuilder:validation:Optional
+	RobotCredentials *RobotCredentials `json:"robotCredentials,omitempty"`
+
+	// PullSecrets configures the dockerconfigjson Secrets written for service accounts by the Secret and PushSecret robot credentials modes.
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Pull Secrets"
+	// +kubebuilder:validation:Optional
+	PullSecrets *PullSecrets `json:"pullSecrets,omitempty"`
+}
+
+// SharedOrganization represents an existing Quay organization shared by several namespaces
+type SharedOrganization struct {
+
+	// Name is the name of the Quay organization.
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Name"
+	// +kubebuilder:validation:Required
+	Name string `json:"name"`
+
+	// NamespaceSelector selects the namespaces permitted to target the organization.
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Namespace Selector"
+	// +kubebuilder:validation:Required
+	NamespaceSelector *metav1.LabelSelector `json:"namespaceSelector"`
+
+	// AllowUnprefixedRepositories permits namespaces to select the Unprefixed repository naming scheme, naming repositories after their ImageStreams only. Repositories of different namespaces may then collide.
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Allow Unprefixed Repositories"
+	// +kubebuilder:validation:Optional
+	AllowUnprefixedRepositories bool `json:"allowUnprefixedRepositories,omitempty"`
+}
+
+// OrganizationContact represents the contact metadata of managed organizations
+type OrganizationContact struct {
+
+	// EmailTemplate is a Go template rendering the email address of an organization, such as {{.Namespace}}@quay.example.com. The fields Namespace, Organization and ClusterID are available. No email address is set when empty.
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Email Template"
+	// +kubebuilder:validation:Optional
+	EmailTemplate string `json:"emailTemplate,omitempty"`
+
+	// InvoiceEmailTemplate is a Go template rendering the address invoices of an organization are sent to, with the same fields as EmailTemplate. Invoices are not sent by email when empty.
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Invoice Email Template"
+	// +kubebuilder:validation:Optional
+	InvoiceEmailTemplate string `json:"invoiceEmailTemplate,omitempty"`
+}
+
+// PullSecrets represents the contents of the dockerconfigjson Secrets written for service accounts
+type PullSecrets struct {
+
+	// AdditionalRegistries are additional registry hostnames, such as mirrors or alternate routes of the Quay registry, the robot account credentials are written for.
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Additional Registries"
+	// +kubebuilder:validation:Optional
+	AdditionalRegistries []string `json:"additionalRegistries,omitempty"`
+
+	// Merge merges the registries of the other dockerconfigjson Secrets linked to a service account into the Secret written by the operator, so that a single Secret holds the credentials of every registry.
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Merge",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:booleanSwitch"}
+	// +kubebuilder:validation:Optional
+	Merge bool `json:"merge,omitempty"`
+}
+
+// RobotCredentials represents how the credentials of robot accounts are delivered to namespaces
+type RobotCredentials struct {
+
+	// Mode is how robot credentials are delivered. Secret writes a dockerconfigjson Secret to the namespace. PushSecret additionally pushes the Secret to an external secret store using a PushSecret. ExternalSecret reads the credentials from an external secret store using an ExternalSecret instead of writing them. Reference writes nothing and links a Secret provided by other means.
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Mode",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:select:Secret","urn:alm:descriptor:com.tectonic.ui:select:PushSecret","urn:alm:descriptor:com.tectonic.ui:select:ExternalSecret","urn:alm:descriptor:com.tectonic.ui:select:Reference"}
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Enum=Secret;PushSecret;ExternalSecret;Reference
+	// +kubebuilder:default=Secret
+	Mode string `json:"mode,omitempty"`
+
+	// SecretStoreRef refers to the External Secrets Operator store used by the PushSecret and ExternalSecret modes.
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Secret Store"
+	// +kubebuilder:validation:Optional
+	SecretStoreRef *SecretStoreRef `json:"secretStoreRef,omitempty"`
+
+	// RemoteKeyTemplate is a Go template of the key within the external secret store holding the credentials of a robot account. The Namespace, ServiceAccount, Organization and Robot fields are available. Defaults to quay-bridge-operator/{{.Namespace}}/{{.ServiceAccount}}.
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Remote Key Template",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:text"}
+	// +kubebuilder:validation:Optional
+	RemoteKeyTemplate string `json:"remoteKeyTemplate,omitempty"`
+
+	// RefreshInterval is how often the External Secrets Operator synchronizes the credentials. Defaults to 1h.
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Refresh Interval",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:text"}
+	// +kubebuilder:validation:Optional
+	RefreshInterval *metav1.Duration `json:"refreshInterval,omitempty"`
+}
+
+// SecretStoreRef represents a reference to an External Secrets Operator store
+type SecretStoreRef struct {
+
+	// Name is the name of the store
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Name",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:text"}
+	// +kubebuilder:validation:Required
+	Name string `json:"name"`
+
+	// Kind is the kind of the store. Defaults to ClusterSecretStore.
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Kind",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:select:SecretStore","urn:alm:descriptor:com.tectonic.ui:select:ClusterSecretStore"}
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Enum=SecretStore;ClusterSecretStore
+	// +kubebuilder:default=ClusterSecretStore
+	Kind string `json:"kind,omitempty"`
+}
+
+// InboundSync represents how images pushed directly to Quay are imported into ImageStreams
+type InboundSync struct {
+
+	// Enabled enables inbound synchronization for all managed namespaces. Namespaces can opt in or out using an annotation.
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Enabled",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:booleanSwitch"}
+	// +kubebuilder:validation:Optional
+	Enabled bool `json:"enabled,omitempty"`
+
+	// PollInterval is how often Quay repositories are checked for new tags. Defaults to 5m.
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Poll Interval",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:text"}
+	// +kubebuilder:validation:Optional
+	PollInterval *metav1.Duration `json:"pollInterval,omitempty"`
+
+	// TagFilter is a regular expression matching the tags to import. Defaults to all tags.
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Tag Filter",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:text"}
+	// +kubebuilder:validation:Optional
+	TagFilter string `json:"tagFilter,omitempty"`
+
+	// CreateImageStreams creates an ImageStream for repositories of the namespace that do not have one. Defaults to importing into existing ImageStreams only.
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Create ImageStreams",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:booleanSwitch"}
+	// +kubebuilder:validation:Optional
+	CreateImageStreams bool `json:"createImageStreams,omitempty"`
+}
+
+// SecurityScan represents how Quay security scan results are reported and enforced
+type SecurityScan struct {
+
+	// PollInterval is how often Quay is checked for the results of a pending security scan. Defaults to 1m.
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Poll Interval",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:text"}
+	// +kubebuilder:validation:Optional
+	PollInterval *metav1.Duration `json:"pollInterval,omitempty"`
+
+	// DenySeverity denies Pods within managed namespaces which use images with vulnerabilities of this severity or higher. Images referenced by tag are resolved to the digest imported into the ImageStream tag they refer to.
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Deny Severity",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:select:Low","urn:alm:descriptor:com.tectonic.ui:select:Medium","urn:alm:descriptor:com.tectonic.ui:select:High","urn:alm:descriptor:com.tectonic.ui:select:Critical"}
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Enum=Low;Medium;High;Critical
+	DenySeverity string `json:"denySeverity,omitempty"`
+}
+
+// TeamSync represents how OpenShift users are mapped to Quay users
+type TeamSync struct {
+
+	// UsernameTemplate is a template for the Quay username of an OpenShift user, such as {{.Username | trimSuffix "@example.com" | lower}}. The OpenShift username is available as {{.Username}} and the lower, upper, replace, trimPrefix and trimSuffix functions are available. Defaults to the OpenShift username.
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Username Template",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:text"}
+	// +kubebuilder:validation:Optional
+	UsernameTemplate string `json:"usernameTemplate,omitempty"`
+
+	// UsernameMappings are explicit mappings of OpenShift users to Quay users, taking precedence over the UsernameTemplate.
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Username Mappings"
+	// +kubebuilder:validation:Optional
+	UsernameMappings []UsernameMapping `json:"usernameMappings,omitempty"`
+}
+
+// UsernameMapping represents the Quay user of an OpenShift user
+type UsernameMapping struct {
+
+	// OpenShiftUsername is the name of the OpenShift user.
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="OpenShift Username",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:text"}
+	// +kubebuilder:validation:Required
+	OpenShiftUsername string `json:"openShiftUsername"`
+
+	// QuayUsername is the name of the Quay user.
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Quay Username",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:text"}
+	// +kubebuilder:validation:Required
+	QuayUsername string `json:"quayUsername"`
+}
+
+// OrganizationQuota represents the storage quota of a Quay organization
+type OrganizationQuota struct {
+
+	// Limit is the storage available to each organization, such as 10Gi.
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Limit",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:text"}
+	// +kubebuilder:validation:Optional
+	Limit *resource.Quantity `json:"limit,omitempty"`
+
+	// WarningThresholdPercent is the percentage of the limit at which a soft limit warning is raised.
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Warning Threshold Percent",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:number"}
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=100
+	WarningThresholdPercent int `json:"warningThresholdPercent,omitempty"`
+
+	// RejectThresholdPercent is the percentage of the limit at which pushes are rejected. Defaults to 100.
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Reject Threshold Percent",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:number"}
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=100
+	RejectThresholdPercent int `json:"rejectThresholdPercent,omitempty"`
+}
+
+// TagRetentionPolicy represents how long tags are retained in Quay
+type TagRetentionPolicy struct {
+
+	// KeepLastTags is the number of most recent tags retained in each repository. Only one of KeepLastTags or MaxTagAge may be set.
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Keep Last Tags",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:number"}
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=1
+	KeepLastTags int `json:"keepLastTags,omitempty"`
+
+	// MaxTagAge is the age after which tags are pruned from each repository, such as 30d or 4w. Only one of KeepLastTags or MaxTagAge may be set.
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Maximum Tag Age",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:text"}
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Pattern=`^[0-9]+[smhdw]$`
+	MaxTagAge string `json:"maxTagAge,omitempty"`
+
+	// UntaggedExpiration is how long untagged images remain recoverable before they are garbage collected, such as 2w. Quay only supports it per organization, so it applies to every repository of the organization of a namespace.
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Untagged Expiration",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:text"}
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Pattern=`^[0-9]+[smhdw]$`
+	UntaggedExpiration string `json:"untaggedExpiration,omitempty"`
+}
+
+// RepositoryDefaults represents the default settings of Quay repositories
+type RepositoryDefaults struct {
+
+	// Visibility is the visibility of the repository.
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Visibility",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:select:private","urn:alm:descriptor:com.tectonic.ui:select:public"}
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Enum=private;public
+	Visibility string `json:"visibility,omitempty"`
+
+	// Description is a template for the description of the repository. The namespace and name of the ImageStream are available as {{.Namespace}} and {{.Name}}.
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Description",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:text"}
+	// +kubebuilder:validation:Optional
+	Description string `json:"description,omitempty"`
+
+	// Kind is the kind of repository to create.
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Kind",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:select:image","urn:alm:descriptor:com.tectonic.ui:select:application"}
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Enum=image;application
+	Kind string `json:"kind,omitempty"`
 }
 
 // QuayIntegrationStatus defines the observed state of QuayIntegration
@@ -83,6 +385,59 @@ type QuayIntegrationStatus struct {
 	// +kubebuilder:validation:Optional
 	// +operator-sdk:csv:customresourcedefinitions:type=status,displayName="Last Updated Time",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:text"}
 	LastUpdate string `json:"lastUpdate,omitempty"`
+
+	// Teardown reports the progress of removing the artifacts of the operator from managed namespaces once the QuayIntegration is deleted
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=status,displayName="Teardown"
+	Teardown *TeardownStatus `json:"teardown,omitempty"`
+
+	// ClusterClaim reports the claim of the ClusterID held in Quay
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=status,displayName="Cluster Claim"
+	ClusterClaim *ClusterClaimStatus `json:"clusterClaim,omitempty"`
+}
+
+// ClusterClaimStatus represents the claim of a ClusterID by a cluster, recorded in Quay
+type ClusterClaimStatus struct {
+
+	// ClusterID is the ClusterID that was claimed
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=status,displayName="Cluster ID",xDescriptors={"urn:alm:descriptor:text"}
+	ClusterID string `json:"clusterID,omitempty"`
+
+	// ClusterUID is the unique identifier of this cluster
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=status,displayName="Cluster UID",xDescriptors={"urn:alm:descriptor:text"}
+	ClusterUID string `json:"clusterUID,omitempty"`
+
+	// HolderUID is the unique identifier of the cluster holding the claim
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=status,displayName="Holder UID",xDescriptors={"urn:alm:descriptor:text"}
+	HolderUID string `json:"holderUID,omitempty"`
+
+	// RenewTime is when the claim was last renewed by its holder
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=status,displayName="Renew Time",xDescriptors={"urn:alm:descriptor:text"}
+	RenewTime *metav1.Time `json:"renewTime,omitempty"`
+}
+
+// TeardownStatus represents the progress of removing the artifacts of the operator from managed namespaces
+type TeardownStatus struct {
+
+	// TotalNamespaces is the number of managed namespaces when the teardown started
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=status,displayName="Total Namespaces",xDescriptors={"urn:alm:descriptor:text"}
+	TotalNamespaces int `json:"totalNamespaces,omitempty"`
+
+	// RemainingNamespaces is the number of managed namespaces that have not been torn down
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=status,displayName="Remaining Namespaces",xDescriptors={"urn:alm:descriptor:text"}
+	RemainingNamespaces int `json:"remainingNamespaces,omitempty"`
+
+	// FailedNamespaces are the namespaces that failed to be torn down during the last attempt
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=status,displayName="Failed Namespaces"
+	FailedNamespaces []string `json:"failedNamespaces,omitempty"`
 }
 
 //+kubebuilder:object:root=true
@@ -107,6 +462,92 @@ type QuayIntegrationList struct {
 	Items           []QuayIntegration `json:"items"`
 }
 
+// CredentialsSource represents where the credentials to communicate with the Quay registry are read from
+type CredentialsSource struct {
+
+	// Type is the kind of credentials source. Secret reads the CredentialsSecret, File reads a directory mounted into the operator and Vault reads a Vault KV secret.
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Type",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:select:Secret","urn:alm:descriptor:com.tectonic.ui:select:File","urn:alm:descriptor:com.tectonic.ui:select:Vault"}
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Enum=Secret;File;Vault
+	// +kubebuilder:default=Secret
+	Type string `json:"type,omitempty"`
+
+	// File configures the File credentials source.
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="File"
+	// +kubebuilder:validation:Optional
+	File *FileCredentialsSource `json:"file,omitempty"`
+
+	// Vault configures the Vault credentials source.
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Vault"
+	// +kubebuilder:validation:Optional
+	Vault *VaultCredentialsSource `json:"vault,omitempty"`
+}
+
+// FileCredentialsSource represents credentials read from files mounted into the operator
+type FileCredentialsSource struct {
+
+	// Path is a directory within the operator container containing a file for each credential key, such as a CSI secrets store volume. Changes are reloaded automatically.
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Path",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:text"}
+	// +kubebuilder:validation:Required
+	Path string `json:"path"`
+}
+
+// VaultCredentialsSource represents credentials read from a HashiCorp Vault KV secrets engine
+type VaultCredentialsSource struct {
+
+	// Address is the URL of the Vault server.
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Address",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:text"}
+	// +kubebuilder:validation:Required
+	Address string `json:"address"`
+
+	// Mount is the mount path of the KV secrets engine. Defaults to secret.
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Mount",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:text"}
+	// +kubebuilder:validation:Optional
+	Mount string `json:"mount,omitempty"`
+
+	// Path is the path of the secret within the KV secrets engine.
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Path",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:text"}
+	// +kubebuilder:validation:Required
+	Path string `json:"path"`
+
+	// KVVersion is the version of the KV secrets engine. Defaults to 2.
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="KV Version",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:number"}
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Enum=1;2
+	KVVersion int `json:"kvVersion,omitempty"`
+
+	// Role is the Vault role used to log in with the Kubernetes auth method. When unset, the VAULT_TOKEN environment variable of the operator is used.
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Role",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:text"}
+	// +kubebuilder:validation:Optional
+	Role string `json:"role,omitempty"`
+
+	// AuthPath is the mount path of the Kubernetes auth method. Defaults to kubernetes.
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Auth Path",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:text"}
+	// +kubebuilder:validation:Optional
+	AuthPath string `json:"authPath,omitempty"`
+}
+
+// Authentication represents how credentials are exchanged for tokens to communicate with the Quay API
+type Authentication struct {
+
+	// Method is how tokens are obtained. Token uses the token stored in the CredentialsSecret. ClientCredentials exchanges the clientID and clientSecret keys, and Password exchanges the username and password keys, of the CredentialsSecret for short-lived tokens at the TokenURL.
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Method",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:select:Token","urn:alm:descriptor:com.tectonic.ui:select:ClientCredentials","urn:alm:descriptor:com.tectonic.ui:select:Password"}
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Enum=Token;ClientCredentials;Password
+	// +kubebuilder:default=Token
+	Method string `json:"method,omitempty"`
+
+	// TokenURL is the OAuth token endpoint used by the ClientCredentials and Password methods.
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Token URL",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:text"}
+	// +kubebuilder:validation:Optional
+	TokenURL string `json:"tokenURL,omitempty"`
+
+	// Scopes are the OAuth scopes requested by the ClientCredentials and Password methods.
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Scopes"
+	// +kubebuilder:validation:Optional
+	Scopes []string `json:"scopes,omitempty"`
+}
+
 // SecretRef represents a reference to an item within a Secret
 type SecretRef struct {
 
@@ -150,6 +591,11 @@ func (qi *QuayIntegration) GenerateQuayOrganizationNameFromNamespace(namespace s
 	return fmt.Sprintf("%s_%s", strings.ToLower(qi.Spec.ClusterID), namespace)
 }
 
+// GenerateClusterClaimOrganizationName returns the name of the Quay organization recording the claim of the ClusterID. Dots cannot appear in namespace names, so the organization never collides with the organization of a namespace.
+func (qi *QuayIntegration) GenerateClusterClaimOrganizationName() string {
+	return fmt.Sprintf("%s.quaybridge", strings.ToLower(qi.Spec.ClusterID))
+}
+
 // IsAllowedNamespace returns whether a namespace is allowed to be managed.
 func (qi *QuayIntegration) IsAllowedNamespace(namespace string) bool {
 	for _, denylistNamespace := range qi.Spec.DenylistNamespaces {
@@ -171,6 +617,11 @@ func (qi *QuayIntegration) IsAllowedNamespace(namespace string) bool {
 	return len(qi.Spec.AllowlistNamespaces) == 0
 }
 
+// IsCredentialsSecret returns whether an object is the credentials Secret referenced by the QuayIntegration.
+func (qi *QuayIntegration) IsCredentialsSecret(object metav1.Object) bool {
+	return qi.Spec.CredentialsSecret != nil && qi.Spec.CredentialsSecret.Namespace == object.GetNamespace() && qi.Spec.CredentialsSecret.Name == object.GetName()
+}
+
 func (qi *QuayIntegration) GetRegistryHostname() (string, error) {
 	quayURL, err := url.Parse(qi.Spec.QuayHostname)
 
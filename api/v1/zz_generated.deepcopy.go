@@ -26,6 +26,160 @@ import (
 	runtime "k8s.io/apimachinery/pkg/runtime"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Authentication) DeepCopyInto(out *Authentication) {
+	*out = *in
+	if in.Scopes != nil {
+		in, out := &in.Scopes, &out.Scopes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Authentication.
+func (in *Authentication) DeepCopy() *Authentication {
+	if in == nil {
+		return nil
+	}
+	out := new(Authentication)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterClaimStatus) DeepCopyInto(out *ClusterClaimStatus) {
+	*out = *in
+	if in.RenewTime != nil {
+		in, out := &in.RenewTime, &out.RenewTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterClaimStatus.
+func (in *ClusterClaimStatus) DeepCopy() *ClusterClaimStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterClaimStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CredentialsSource) DeepCopyInto(out *CredentialsSource) {
+	*out = *in
+	if in.File != nil {
+		in, out := &in.File, &out.File
+		*out = new(FileCredentialsSource)
+		**out = **in
+	}
+	if in.Vault != nil {
+		in, out := &in.Vault, &out.Vault
+		*out = new(VaultCredentialsSource)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CredentialsSource.
+func (in *CredentialsSource) DeepCopy() *CredentialsSource {
+	if in == nil {
+		return nil
+	}
+	out := new(CredentialsSource)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FileCredentialsSource) DeepCopyInto(out *FileCredentialsSource) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FileCredentialsSource.
+func (in *FileCredentialsSource) DeepCopy() *FileCredentialsSource {
+	if in == nil {
+		return nil
+	}
+	out := new(FileCredentialsSource)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *InboundSync) DeepCopyInto(out *InboundSync) {
+	*out = *in
+	if in.PollInterval != nil {
+		in, out := &in.PollInterval, &out.PollInterval
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InboundSync.
+func (in *InboundSync) DeepCopy() *InboundSync {
+	if in == nil {
+		return nil
+	}
+	out := new(InboundSync)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OrganizationContact) DeepCopyInto(out *OrganizationContact) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OrganizationContact.
+func (in *OrganizationContact) DeepCopy() *OrganizationContact {
+	if in == nil {
+		return nil
+	}
+	out := new(OrganizationContact)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OrganizationQuota) DeepCopyInto(out *OrganizationQuota) {
+	*out = *in
+	if in.Limit != nil {
+		in, out := &in.Limit, &out.Limit
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OrganizationQuota.
+func (in *OrganizationQuota) DeepCopy() *OrganizationQuota {
+	if in == nil {
+		return nil
+	}
+	out := new(OrganizationQuota)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PullSecrets) DeepCopyInto(out *PullSecrets) {
+	*out = *in
+	if in.AdditionalRegistries != nil {
+		in, out := &in.AdditionalRegistries, &out.AdditionalRegistries
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PullSecrets.
+func (in *PullSecrets) DeepCopy() *PullSecrets {
+	if in == nil {
+		return nil
+	}
+	out := new(PullSecrets)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *QuayIntegration) DeepCopyInto(out *QuayIntegration) {
 	*out = *in
@@ -93,6 +247,16 @@ func (in *QuayIntegrationSpec) DeepCopyInto(out *QuayIntegrationSpec) {
 		*out = new(SecretRef)
 		**out = **in
 	}
+	if in.CredentialsSource != nil {
+		in, out := &in.CredentialsSource, &out.CredentialsSource
+		*out = new(CredentialsSource)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Authentication != nil {
+		in, out := &in.Authentication, &out.Authentication
+		*out = new(Authentication)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.DenylistNamespaces != nil {
 		in, out := &in.DenylistNamespaces, &out.DenylistNamespaces
 		*out = make([]string, len(*in))
@@ -103,6 +267,58 @@ func (in *QuayIntegrationSpec) DeepCopyInto(out *QuayIntegrationSpec) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.SharedOrganizations != nil {
+		in, out := &in.SharedOrganizations, &out.SharedOrganizations
+		*out = make([]SharedOrganization, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.RepositoryDefaults != nil {
+		in, out := &in.RepositoryDefaults, &out.RepositoryDefaults
+		*out = new(RepositoryDefaults)
+		**out = **in
+	}
+	if in.TagRetention != nil {
+		in, out := &in.TagRetention, &out.TagRetention
+		*out = new(TagRetentionPolicy)
+		**out = **in
+	}
+	if in.OrganizationQuota != nil {
+		in, out := &in.OrganizationQuota, &out.OrganizationQuota
+		*out = new(OrganizationQuota)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.OrganizationContact != nil {
+		in, out := &in.OrganizationContact, &out.OrganizationContact
+		*out = new(OrganizationContact)
+		**out = **in
+	}
+	if in.TeamSync != nil {
+		in, out := &in.TeamSync, &out.TeamSync
+		*out = new(TeamSync)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.SecurityScan != nil {
+		in, out := &in.SecurityScan, &out.SecurityScan
+		*out = new(SecurityScan)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.InboundSync != nil {
+		in, out := &in.InboundSync, &out.InboundSync
+		*out = new(InboundSync)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.RobotCredentials != nil {
+		in, out := &in.RobotCredentials, &out.RobotCredentials
+		*out = new(RobotCredentials)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PullSecrets != nil {
+		in, out := &in.PullSecrets, &out.PullSecrets
+		*out = new(PullSecrets)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new QuayIntegrationSpec.
@@ -125,6 +341,16 @@ func (in *QuayIntegrationStatus) DeepCopyInto(out *QuayIntegrationStatus) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.Teardown != nil {
+		in, out := &in.Teardown, &out.Teardown
+		*out = new(TeardownStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ClusterClaim != nil {
+		in, out := &in.ClusterClaim, &out.ClusterClaim
+		*out = new(ClusterClaimStatus)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new QuayIntegrationStatus.
@@ -137,6 +363,46 @@ func (in *QuayIntegrationStatus) DeepCopy() *QuayIntegrationStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RepositoryDefaults) DeepCopyInto(out *RepositoryDefaults) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RepositoryDefaults.
+func (in *RepositoryDefaults) DeepCopy() *RepositoryDefaults {
+	if in == nil {
+		return nil
+	}
+	out := new(RepositoryDefaults)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RobotCredentials) DeepCopyInto(out *RobotCredentials) {
+	*out = *in
+	if in.SecretStoreRef != nil {
+		in, out := &in.SecretStoreRef, &out.SecretStoreRef
+		*out = new(SecretStoreRef)
+		**out = **in
+	}
+	if in.RefreshInterval != nil {
+		in, out := &in.RefreshInterval, &out.RefreshInterval
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RobotCredentials.
+func (in *RobotCredentials) DeepCopy() *RobotCredentials {
+	if in == nil {
+		return nil
+	}
+	out := new(RobotCredentials)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SecretRef) DeepCopyInto(out *SecretRef) {
 	*out = *in
@@ -151,3 +417,143 @@ func (in *SecretRef) DeepCopy() *SecretRef {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SecretStoreRef) DeepCopyInto(out *SecretStoreRef) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SecretStoreRef.
+func (in *SecretStoreRef) DeepCopy() *SecretStoreRef {
+	if in == nil {
+		return nil
+	}
+	out := new(SecretStoreRef)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SecurityScan) DeepCopyInto(out *SecurityScan) {
+	*out = *in
+	if in.PollInterval != nil {
+		in, out := &in.PollInterval, &out.PollInterval
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SecurityScan.
+func (in *SecurityScan) DeepCopy() *SecurityScan {
+	if in == nil {
+		return nil
+	}
+	out := new(SecurityScan)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SharedOrganization) DeepCopyInto(out *SharedOrganization) {
+	*out = *in
+	if in.NamespaceSelector != nil {
+		in, out := &in.NamespaceSelector, &out.NamespaceSelector
+		*out = new(metav1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SharedOrganization.
+func (in *SharedOrganization) DeepCopy() *SharedOrganization {
+	if in == nil {
+		return nil
+	}
+	out := new(SharedOrganization)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TagRetentionPolicy) DeepCopyInto(out *TagRetentionPolicy) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TagRetentionPolicy.
+func (in *TagRetentionPolicy) DeepCopy() *TagRetentionPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(TagRetentionPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TeamSync) DeepCopyInto(out *TeamSync) {
+	*out = *in
+	if in.UsernameMappings != nil {
+		in, out := &in.UsernameMappings, &out.UsernameMappings
+		*out = make([]UsernameMapping, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TeamSync.
+func (in *TeamSync) DeepCopy() *TeamSync {
+	if in == nil {
+		return nil
+	}
+	out := new(TeamSync)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TeardownStatus) DeepCopyInto(out *TeardownStatus) {
+	*out = *in
+	if in.FailedNamespaces != nil {
+		in, out := &in.FailedNamespaces, &out.FailedNamespaces
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TeardownStatus.
+func (in *TeardownStatus) DeepCopy() *TeardownStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(TeardownStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *UsernameMapping) DeepCopyInto(out *UsernameMapping) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UsernameMapping.
+func (in *UsernameMapping) DeepCopy() *UsernameMapping {
+	if in == nil {
+		return nil
+	}
+	out := new(UsernameMapping)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VaultCredentialsSource) DeepCopyInto(out *VaultCredentialsSource) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VaultCredentialsSource.
+func (in *VaultCredentialsSource) DeepCopy() *VaultCredentialsSource {
+	if in == nil {
+		return nil
+	}
+	out := new(VaultCredentialsSource)
+	in.DeepCopyInto(out)
+	return out
+}
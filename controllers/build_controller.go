@@ -18,7 +18,10 @@ package controllers
 
 import (
 	"context"
-	"strings"
+	"fmt"
+	"net/url"
+	"strconv"
+	"time"
 
 	"github.com/go-logr/logr"
 	buildv1 "github.com/openshift/api/build/v1"
@@ -28,12 +31,16 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
+	qclient "github.com/quay/quay-bridge-operator/pkg/client/quay"
 	"github.com/quay/quay-bridge-operator/pkg/constants"
 	"github.com/quay/quay-bridge-operator/pkg/core"
 	"github.com/quay/quay-bridge-operator/pkg/logging"
+	qotypes "github.com/quay/quay-bridge-operator/pkg/types"
+	"github.com/quay/quay-bridge-operator/pkg/utils"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/types"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/event"
@@ -72,34 +79,50 @@ func (r *BuildIntegrationReconciler) Reconcile(ctx context.Context, req ctrl.Req
 		return reconcile.Result{}, nil
 	}
 
-	buildImageStreamComponents := strings.Split(buildImageStreamTagAnnotation, "/")
+	if _, imported := instance.GetAnnotations()[constants.BuildDestinationImageStreamTagImportedAnnotation]; imported || instance.Status.Phase != buildv1.BuildPhaseComplete {
+		return reconcile.Result{}, nil
+	}
 
-	// Validate Annotation
-	if len(buildImageStreamComponents) != 2 {
-		return r.CoreComponents.ManageError(&core.QuayIntegrationCoreError{
-			Object:       instance,
-			Message:      "Unexpected number of ImageStream Annotation Components",
-			KeyAndValues: []interface{}{"Namespace", instance.Namespace, "Build", instance.Name, "Annotation", buildImageStreamTagAnnotation, "Expected Size", "2", "Actual Size", len(buildImageStreamComponents)},
-			Reason:       "ProcessingError",
-		})
+	// Abandoned imports are retried once the annotation is removed
+	if _, abandoned := instance.GetAnnotations()[constants.BuildImportAbandonedAnnotation]; abandoned {
+		return reconcile.Result{}, nil
 	}
 
-	buildImageStreamNamespace := buildImageStreamComponents[0]
-	imageNameTagComponents := strings.Split(buildImageStreamComponents[1], ":")
+	// Honor the backoff from a previously failed import
+	if requeueAfter := importRequeueAfter(instance); requeueAfter > 0 {
+		return reconcile.Result{RequeueAfter: requeueAfter}, nil
+	}
+
+	destinations, err := utils.ParseImageStreamTagDestinations(buildImageStreamTagAnnotation)
 
-	if len(imageNameTagComponents) != 2 {
+	// Validate Annotation
+	if err != nil {
 		return r.CoreComponents.ManageError(&core.QuayIntegrationCoreError{
 			Object:       instance,
-			Message:      "Unexpected number of ImageStream Name Components",
-			KeyAndValues: []interface{}{"Namespace", instance.Namespace, "Build", instance.Name, "Annotation", buildImageStreamTagAnnotation, "Actual Size", len(imageNameTagComponents)},
+			Message:      "Unable to parse ImageStream Annotation",
+			KeyAndValues: []interface{}{"Namespace", instance.Namespace, "Build", instance.Name, "Annotation", buildImageStreamTagAnnotation},
 			Reason:       "ProcessingError",
+			Error:        err,
 		})
 	}
 
-	buildImageName := imageNameTagComponents[0]
-	buildImageTag := imageNameTagComponents[1]
+	// All destinations are tags of the ImageStream the Build was pushed to
+	buildImageStreamNamespace := destinations[0].Namespace
+	buildImageName := destinations[0].Name
+
+	for _, destination := range destinations {
+		if destination.Namespace != buildImageStreamNamespace || destination.Name != buildImageName {
+			return r.CoreComponents.ManageError(&core.QuayIntegrationCoreError{
+				Object:       instance,
+				Message:      "ImageStream Annotation references more than one ImageStream",
+				KeyAndValues: []interface{}{"Namespace", instance.Namespace, "Build", instance.Name, "Annotation", buildImageStreamTagAnnotation},
+				Reason:       "ProcessingError",
+				SkipRequeue:  true,
+			})
+		}
+	}
 
-	logging.Log.Info("Importing ImageStream after Build", "ImageStream Namespace", buildImageStreamNamespace, "ImageStream Name", buildImageName, "ImageStream Tag", buildImageTag)
+	logging.Log.Info("Importing ImageStream after Build", "ImageStream Namespace", buildImageStreamNamespace, "ImageStream Name", buildImageName, "ImageStream Tags", buildImageStreamTagAnnotation)
 
 	quayIntegration, result, err := r.CoreComponents.GetQuayIntegration(instance)
 	if err != nil {
@@ -118,6 +141,66 @@ func (r *BuildIntegrationReconciler) Reconcile(ctx context.Context, req ctrl.Req
 		})
 	}
 
+	target, err := r.CoreComponents.GetOrganizationTarget(ctx, &quayIntegration, buildImageStreamNamespace)
+	if err != nil {
+		return r.CoreComponents.ManageError(&core.QuayIntegrationCoreError{
+			Object:       instance,
+			Message:      "Unable to resolve Quay Organization of ImageStream",
+			KeyAndValues: []interface{}{"Namespace", buildImageStreamNamespace, "ImageStream", buildImageName},
+			Reason:       "ConfigurationError",
+			Error:        err,
+		})
+	}
+
+	quayOrganizationName := target.Organization
+
+	quayRepositoryName, err := utils.GetRepositoryName(target, existingImageStream)
+	if err != nil {
+		return r.CoreComponents.ManageError(&core.QuayIntegrationCoreError{
+			Object:       instance,
+			Message:      "Unable to determine Quay Repository of ImageStream",
+			KeyAndValues: []interface{}{"Namespace", buildImageStreamNamespace, "ImageStream", buildImageName},
+			Reason:       "ConfigurationError",
+			Error:        err,
+		})
+	}
+
+	digest := ""
+	if instance.Status.Output.To != nil {
+		digest = instance.Status.Output.To.ImageDigest
+	}
+
+	var quayClient *qclient.Client
+	if digest == "" || len(destinations) > 1 {
+		quayClient, result, err = r.CoreComponents.GetQuayClient(ctx, instance, &quayIntegration)
+		if err != nil || quayClient == nil {
+			return result, err
+		}
+	}
+
+	// Fall back to the Quay tag API when the Build did not report the pushed digest
+	if digest == "" {
+		digest, err = getQuayTagDigest(quayClient, quayOrganizationName, quayRepositoryName, destinations[0].Tag)
+		if err != nil {
+			return r.manageImportFailure(ctx, instance, err)
+		}
+	}
+
+	// Tag the manifest in Quay under each additional tag
+	for _, destination := range destinations[1:] {
+		tagResponse, tagErr := quayClient.CreateOrUpdateTag(quayOrganizationName, quayRepositoryName, destination.Tag, digest)
+		if tagErr.Error != nil {
+			return r.manageImportFailure(ctx, instance, fmt.Errorf("failed to tag %s/%s:%s in Quay: %w", quayOrganizationName, quayRepositoryName, destination.Tag, tagErr.Error))
+		}
+
+		if tagResponse.StatusCode != 200 && tagResponse.StatusCode != 201 {
+			return r.manageImportFailure(ctx, instance, fmt.Errorf("failed to tag %s/%s:%s in Quay: status code %d", quayOrganizationName, quayRepositoryName, destination.Tag, tagResponse.StatusCode))
+		}
+	}
+
+	// Pin the import to the digest so concurrent pushes to the same tag cannot be imported in its place
+	pushedImage := fmt.Sprintf("%s@%s", utils.TrimImageReference(instance.Spec.Output.To.Name), digest)
+
 	isi := &imagev1.ImageStreamImport{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:            buildImageName,
@@ -126,25 +209,26 @@ func (r *BuildIntegrationReconciler) Reconcile(ctx context.Context, req ctrl.Req
 		},
 		Spec: imagev1.ImageStreamImportSpec{
 			Import: true,
-			Images: []imagev1.ImageImportSpec{
-				{
-					From: corev1.ObjectReference{
-						Kind: "DockerImage",
-						Name: instance.Spec.Output.To.Name,
-					},
-					To: &corev1.LocalObjectReference{Name: buildImageTag},
-					ImportPolicy: imagev1.TagImportPolicy{
-						Insecure:  quayIntegration.Spec.InsecureRegistry,
-						Scheduled: quayIntegration.Spec.ScheduledImageStreamImport,
-					},
-					ReferencePolicy: imagev1.TagReferencePolicy{
-						Type: imagev1.SourceTagReferencePolicy,
-					},
-				},
-			},
 		},
 	}
 
+	for _, destination := range destinations {
+		isi.Spec.Images = append(isi.Spec.Images, imagev1.ImageImportSpec{
+			From: corev1.ObjectReference{
+				Kind: "DockerImage",
+				Name: pushedImage,
+			},
+			To: &corev1.LocalObjectReference{Name: destination.Tag},
+			ImportPolicy: imagev1.TagImportPolicy{
+				Insecure:  quayIntegration.Spec.InsecureRegistry,
+				Scheduled: quayIntegration.Spec.ScheduledImageStreamImport,
+			},
+			ReferencePolicy: imagev1.TagReferencePolicy{
+				Type: imagev1.SourceTagReferencePolicy,
+			},
+		})
+	}
+
 	err = r.CoreComponents.ReconcilerBase.GetClient().Create(ctx, isi)
 	if err != nil {
 		return r.CoreComponents.ManageError(&core.QuayIntegrationCoreError{
@@ -156,8 +240,29 @@ func (r *BuildIntegrationReconciler) Reconcile(ctx context.Context, req ctrl.Req
 		})
 	}
 
+	// Verify the result of the import
+	if importErr := getImageStreamImportError(isi); importErr != nil {
+		return r.manageImportFailure(ctx, instance, importErr)
+	}
+
+	// Record the digest on the ImageStream tags
+	err = annotateImageStreamTags(ctx, r.CoreComponents.ReconcilerBase.GetClient(), types.NamespacedName{Namespace: buildImageStreamNamespace, Name: buildImageName}, destinations, map[string]string{constants.ImageStreamTagDigestAnnotation: digest})
+	if err != nil {
+		return r.CoreComponents.ManageError(&core.QuayIntegrationCoreError{
+			Object:       instance,
+			Message:      "Error occurred annotating ImageStream tags",
+			KeyAndValues: []interface{}{"Namespace", buildImageStreamNamespace, "ImageStream", buildImageName},
+			Reason:       "ProcessingError",
+			Error:        err,
+		})
+	}
+
 	// Update the Build
 	instance.GetAnnotations()[constants.BuildDestinationImageStreamTagImportedAnnotation] = "true"
+	instance.GetAnnotations()[constants.BuildImportDigestAnnotation] = digest
+	delete(instance.GetAnnotations(), constants.BuildImportFailureAnnotation)
+	delete(instance.GetAnnotations(), constants.BuildImportAttemptsAnnotation)
+	delete(instance.GetAnnotations(), constants.BuildImportLastAttemptAnnotation)
 	err = r.CoreComponents.ReconcilerBase.GetClient().Update(ctx, instance)
 	if err != nil {
 		return r.CoreComponents.ManageError(&core.QuayIntegrationCoreError{
@@ -169,9 +274,142 @@ func (r *BuildIntegrationReconciler) Reconcile(ctx context.Context, req ctrl.Req
 		})
 	}
 
+	r.CoreComponents.ReconcilerBase.GetRecorder().Event(instance, corev1.EventTypeNormal, "ImportSucceeded", fmt.Sprintf("Imported %s into ImageStreamTags %s", digest, buildImageStreamTagAnnotation))
+
 	return reconcile.Result{}, nil
 }
 
+// annotateImageStreamTags records annotations on each destination tag of the ImageStream
+func annotateImageStreamTags(ctx context.Context, c client.Client, imageStreamName types.NamespacedName, destinations []qotypes.ImageStreamTagDestination, annotations map[string]string) error {
+	imageStream := &imagev1.ImageStream{}
+	err := c.Get(ctx, imageStreamName, imageStream)
+	if err != nil {
+		return err
+	}
+
+	updated := false
+
+	for i := range imageStream.Spec.Tags {
+		for _, destination := range destinations {
+			if imageStream.Spec.Tags[i].Name != destination.Tag {
+				continue
+			}
+
+			for key, value := range annotations {
+				if existingValue, found := imageStream.Spec.Tags[i].Annotations[key]; found && existingValue == value {
+					continue
+				}
+
+				if imageStream.Spec.Tags[i].Annotations == nil {
+					imageStream.Spec.Tags[i].Annotations = map[string]string{}
+				}
+
+				imageStream.Spec.Tags[i].Annotations[key] = value
+				updated = true
+			}
+		}
+	}
+
+	if !updated {
+		return nil
+	}
+
+	return c.Update(ctx, imageStream)
+}
+
+// getQuayTagDigest returns the manifest digest currently referenced by a tag in Quay
+func getQuayTagDigest(quayClient *qclient.Client, quayOrganizationName string, repositoryName string, tag string) (string, error) {
+	tags, tagsResponse, tagsErr := quayClient.GetRepositoryTags(quayOrganizationName, repositoryName, url.Values{"specificTag": {tag}, "onlyActiveTags": {"true"}})
+	if tagsErr.Error != nil {
+		return "", fmt.Errorf("failed to retrieve tag %s/%s:%s from Quay: %w", quayOrganizationName, repositoryName, tag, tagsErr.Error)
+	}
+
+	if tagsResponse.StatusCode != 200 {
+		return "", fmt.Errorf("failed to retrieve tag %s/%s:%s from Quay: status code %d", quayOrganizationName, repositoryName, tag, tagsResponse.StatusCode)
+	}
+
+	for _, quayTag := range tags.Tags {
+		if quayTag.Name == tag && quayTag.ManifestDigest != "" {
+			return quayTag.ManifestDigest, nil
+		}
+	}
+
+	return "", fmt.Errorf("tag %s/%s:%s not found in Quay", quayOrganizationName, repositoryName, tag)
+}
+
+// manageImportFailure records a failed import on the Build and schedules a retry with backoff, abandoning the import after the maximum number of attempts
+func (r *BuildIntegrationReconciler) manageImportFailure(ctx context.Context, instance *buildv1.Build, importErr error) (reconcile.Result, error) {
+	attempts, _ := strconv.Atoi(instance.GetAnnotations()[constants.BuildImportAttemptsAnnotation])
+	attempts++
+
+	abandoned := attempts >= constants.MaxImportAttempts
+
+	instance.GetAnnotations()[constants.BuildImportFailureAnnotation] = importErr.Error()
+	if abandoned {
+		instance.GetAnnotations()[constants.BuildImportAbandonedAnnotation] = "true"
+		delete(instance.GetAnnotations(), constants.BuildImportAttemptsAnnotation)
+		delete(instance.GetAnnotations(), constants.BuildImportLastAttemptAnnotation)
+	} else {
+		instance.GetAnnotations()[constants.BuildImportAttemptsAnnotation] = strconv.Itoa(attempts)
+		instance.GetAnnotations()[constants.BuildImportLastAttemptAnnotation] = time.Now().UTC().Format(time.RFC3339)
+	}
+
+	err := r.CoreComponents.ReconcilerBase.GetClient().Update(ctx, instance)
+	if err != nil {
+		return r.CoreComponents.ManageError(&core.QuayIntegrationCoreError{
+			Object:       instance,
+			Message:      "Error occurred updating Build",
+			KeyAndValues: []interface{}{"Namespace", instance.Namespace, "Build", instance.Name},
+			Reason:       "ProcessingError",
+			Error:        err,
+		})
+	}
+
+	if abandoned {
+		logging.Log.Info("ImageStream import abandoned", "Namespace", instance.Namespace, "Build", instance.Name, "Attempts", attempts, "Error", importErr.Error())
+		r.CoreComponents.ReconcilerBase.GetRecorder().Event(instance, corev1.EventTypeWarning, "ImportAbandoned", fmt.Sprintf("ImageStream import abandoned after %d attempts, remove the %s annotation to retry: %s", attempts, constants.BuildImportAbandonedAnnotation, importErr.Error()))
+
+		return reconcile.Result{}, nil
+	}
+
+	requeueAfter := utils.ImportBackoff(attempts, constants.RequeuePeriod, constants.MaxImportRequeuePeriod)
+
+	logging.Log.Info("ImageStream import failed", "Namespace", instance.Namespace, "Build", instance.Name, "Attempts", attempts, "Retry In", requeueAfter.String(), "Error", importErr.Error())
+	r.CoreComponents.ReconcilerBase.GetRecorder().Event(instance, corev1.EventTypeWarning, "ImportFailed", fmt.Sprintf("ImageStream import failed (attempt %d, retrying in %s): %s", attempts, requeueAfter, importErr.Error()))
+
+	return reconcile.Result{RequeueAfter: requeueAfter}, nil
+}
+
+// importRequeueAfter returns the remaining backoff before a previously failed import can be retried
+func importRequeueAfter(instance *buildv1.Build) time.Duration {
+	attempts, err := strconv.Atoi(instance.GetAnnotations()[constants.BuildImportAttemptsAnnotation])
+	if err != nil || attempts == 0 {
+		return 0
+	}
+
+	lastAttempt, err := time.Parse(time.RFC3339, instance.GetAnnotations()[constants.BuildImportLastAttemptAnnotation])
+	if err != nil {
+		return 0
+	}
+
+	return time.Until(lastAttempt.Add(utils.ImportBackoff(attempts, constants.RequeuePeriod, constants.MaxImportRequeuePeriod)))
+}
+
+// getImageStreamImportError returns an error describing any image within the ImageStreamImport which failed to import
+func getImageStreamImportError(isi *imagev1.ImageStreamImport) error {
+	if len(isi.Status.Images) == 0 {
+		return fmt.Errorf("ImageStreamImport did not report the status of any images")
+	}
+
+	for _, image := range isi.Status.Images {
+		if image.Status.Status != metav1.StatusSuccess {
+			return fmt.Errorf("%s: %s", image.Status.Reason, image.Status.Message)
+		}
+	}
+
+	return nil
+}
+
 // SetupWithManager sets up the controller with the Manager.
 func (r *BuildIntegrationReconciler) SetupWithManager(mgr ctrl.Manager) error {
 
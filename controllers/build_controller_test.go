@@ -0,0 +1,162 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	buildv1 "github.com/openshift/api/build/v1"
+	imagev1 "github.com/openshift/api/image/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	quayv1 "github.com/quay/quay-bridge-operator/api/v1"
+	"github.com/quay/quay-bridge-operator/pkg/constants"
+)
+
+var _ = Describe("Build controller", Ordered, func() {
+
+	const digest = "sha256:4b825dc642cb6eb9a060e54bf8d69288fbee4904"
+
+	ctx := context.Background()
+
+	var quayIntegration *quayv1.QuayIntegration
+	var namespace *corev1.Namespace
+	var quayRepository string
+
+	BeforeAll(func() {
+		quayIntegration = createQuayIntegration(ctx, "builds", "builds")
+
+		namespace = createNamespace(ctx, "builds")
+		waitForOnboarding(ctx, namespace.Name, quayIntegration)
+
+		Expect(k8sClient.Create(ctx, &imagev1.ImageStream{ObjectMeta: metav1.ObjectMeta{Name: "app", Namespace: namespace.Name}})).To(Succeed())
+
+		quayOrganizationName := quayIntegration.GenerateQuayOrganizationNameFromNamespace(namespace.Name)
+
+		Eventually(func() bool {
+			_, found := quayServer.Repository(quayOrganizationName, "app")
+			return found
+		}, timeout, interval).Should(BeTrue())
+
+		registryHostname, err := quayIntegration.GetRegistryHostname()
+		Expect(err).NotTo(HaveOccurred())
+
+		quayRepository = fmt.Sprintf("%s/%s/app", registryHostname, quayOrganizationName)
+	})
+
+	AfterAll(func() {
+		deleteQuayIntegration(ctx, quayIntegration)
+	})
+
+	It("rewrites the output of a Build to the Quay repository on admission", func() {
+		build := &buildv1.Build{
+			ObjectMeta: metav1.ObjectMeta{Name: "app-1", Namespace: namespace.Name},
+			Spec: buildv1.BuildSpec{
+				CommonSpec: buildv1.CommonSpec{
+					Strategy: buildv1.BuildStrategy{Type: buildv1.DockerBuildStrategyType, DockerStrategy: &buildv1.DockerBuildStrategy{}},
+					Output:   buildv1.BuildOutput{To: &corev1.ObjectReference{Kind: "ImageStreamTag", Name: "app:latest"}},
+				},
+			},
+		}
+		Expect(k8sClient.Create(ctx, build)).To(Succeed())
+
+		Expect(build.Spec.Output.To.Kind).To(Equal("DockerImage"))
+		Expect(build.Spec.Output.To.Name).To(Equal(quayRepository + ":latest"))
+		Expect(build.Annotations).To(HaveKeyWithValue(constants.BuildOperatorManagedAnnotation, "true"))
+		Expect(build.Annotations).To(HaveKey(constants.BuildDestinationImageStreamAnnotation))
+	})
+
+	It("imports the pushed image into the ImageStream once the Build completes", func() {
+		build := &buildv1.Build{}
+		Expect(k8sClient.Get(ctx, types.NamespacedName{Namespace: namespace.Name, Name: "app-1"}, build)).To(Succeed())
+
+		// Builds are served without a status subresource in envtest
+		build.Status.Phase = buildv1.BuildPhaseComplete
+		build.Status.Output.To = &buildv1.BuildStatusOutputTo{ImageDigest: digest}
+		Expect(k8sClient.Update(ctx, build)).To(Succeed())
+
+		Eventually(func(g Gomega) {
+			g.Expect(k8sClient.Get(ctx, types.NamespacedName{Namespace: namespace.Name, Name: "app-1"}, build)).To(Succeed())
+			g.Expect(build.Annotations).To(HaveKeyWithValue(constants.BuildDestinationImageStreamTagImportedAnnotation, "true"))
+			g.Expect(build.Annotations).To(HaveKeyWithValue(constants.BuildImportDigestAnnotation, digest))
+		}, timeout, interval).Should(Succeed())
+
+		isi := &imagev1.ImageStreamImport{}
+		Expect(k8sClient.Get(ctx, types.NamespacedName{Namespace: namespace.Name, Name: "app"}, isi)).To(Succeed())
+		Expect(isi.Spec.Import).To(BeTrue())
+		Expect(isi.Spec.Images).To(HaveLen(1))
+		Expect(isi.Spec.Images[0].From.Name).To(Equal(quayRepository + "@" + digest))
+		Expect(isi.Spec.Images[0].To.Name).To(Equal("latest"))
+	})
+
+	It("retries a failed import and clears the retry state once imported", func() {
+		build := createBuild(ctx, namespace.Name, "app-2", "app:retried")
+
+		// Without the pushed digest the tag is looked up in Quay, where it does not exist yet
+		build.Status.Phase = buildv1.BuildPhaseComplete
+		Expect(k8sClient.Update(ctx, build)).To(Succeed())
+
+		Eventually(func(g Gomega) {
+			g.Expect(k8sClient.Get(ctx, types.NamespacedName{Namespace: namespace.Name, Name: build.Name}, build)).To(Succeed())
+			g.Expect(build.Annotations).To(HaveKeyWithValue(constants.BuildImportAttemptsAnnotation, "1"))
+			g.Expect(build.Annotations).To(HaveKey(constants.BuildImportLastAttemptAnnotation))
+		}, timeout, interval).Should(Succeed())
+
+		quayServer.SetTag(quayIntegration.GenerateQuayOrganizationNameFromNamespace(namespace.Name), "app", "retried", digest)
+
+		Eventually(func(g Gomega) {
+			g.Expect(k8sClient.Get(ctx, types.NamespacedName{Namespace: namespace.Name, Name: build.Name}, build)).To(Succeed())
+			g.Expect(build.Annotations).To(HaveKeyWithValue(constants.BuildDestinationImageStreamTagImportedAnnotation, "true"))
+		}, timeout, interval).Should(Succeed())
+
+		Expect(build.Annotations).NotTo(HaveKey(constants.BuildImportFailureAnnotation))
+		Expect(build.Annotations).NotTo(HaveKey(constants.BuildImportAttemptsAnnotation))
+		Expect(build.Annotations).NotTo(HaveKey(constants.BuildImportLastAttemptAnnotation))
+	})
+
+	It("abandons the import after the maximum number of attempts", func() {
+		build := createBuild(ctx, namespace.Name, "app-3", "app:missing")
+
+		build.Annotations[constants.BuildImportAttemptsAnnotation] = strconv.Itoa(constants.MaxImportAttempts - 1)
+		build.Annotations[constants.BuildImportLastAttemptAnnotation] = time.Now().Add(-time.Hour).UTC().Format(time.RFC3339)
+		build.Status.Phase = buildv1.BuildPhaseComplete
+		Expect(k8sClient.Update(ctx, build)).To(Succeed())
+
+		Eventually(func(g Gomega) {
+			g.Expect(k8sClient.Get(ctx, types.NamespacedName{Namespace: namespace.Name, Name: build.Name}, build)).To(Succeed())
+			g.Expect(build.Annotations).To(HaveKeyWithValue(constants.BuildImportAbandonedAnnotation, "true"))
+		}, timeout, interval).Should(Succeed())
+
+		Expect(build.Annotations).To(HaveKey(constants.BuildImportFailureAnnotation))
+		Expect(build.Annotations).NotTo(HaveKey(constants.BuildImportAttemptsAnnotation))
+		Expect(build.Annotations).NotTo(HaveKey(constants.BuildImportLastAttemptAnnotation))
+
+		Eventually(func(g Gomega) {
+			events := &corev1.EventList{}
+			g.Expect(k8sClient.List(ctx, events, client.InNamespace(namespace.Name))).To(Succeed())
+			g.Expect(events.Items).To(ContainElement(And(HaveField("InvolvedObject.Name", build.Name), HaveField("Reason", "ImportAbandoned"))))
+		}, timeout, interval).Should(Succeed())
+	})
+})
+
+// createBuild creates a Build pushing to an ImageStreamTag, which the admission webhook rewrites to the Quay repository
+func createBuild(ctx context.Context, namespace string, name string, imageStreamTag string) *buildv1.Build {
+	build := &buildv1.Build{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+		Spec: buildv1.BuildSpec{
+			CommonSpec: buildv1.CommonSpec{
+				Strategy: buildv1.BuildStrategy{Type: buildv1.DockerBuildStrategyType, DockerStrategy: &buildv1.DockerBuildStrategy{}},
+				Output:   buildv1.BuildOutput{To: &corev1.ObjectReference{Kind: "ImageStreamTag", Name: imageStreamTag}},
+			},
+		},
+	}
+	Expect(k8sClient.Create(ctx, build)).To(Succeed())
+
+	return build
+}
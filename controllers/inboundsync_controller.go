@@ -0,0 +1,406 @@
+/*
+Copyright 2021.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strconv"
+
+	"github.com/go-logr/logr"
+	imagev1 "github.com/openshift/api/image/v1"
+	corev1 "k8s.io/api/core/v1"
+
+	quayv1 "github.com/quay/quay-bridge-operator/api/v1"
+	qclient "github.com/quay/quay-bridge-operator/pkg/client/quay"
+	"github.com/quay/quay-bridge-operator/pkg/constants"
+	"github.com/quay/quay-bridge-operator/pkg/core"
+	"github.com/quay/quay-bridge-operator/pkg/logging"
+	qotypes "github.com/quay/quay-bridge-operator/pkg/types"
+	"github.com/quay/quay-bridge-operator/pkg/utils"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// InboundSyncReconciler imports images pushed directly to Quay into the ImageStreams of managed namespaces
+type InboundSyncReconciler struct {
+	CoreComponents core.CoreComponents
+	Log            logr.Logger
+}
+
+//+kubebuilder:rbac:groups="",resources=namespaces,verbs=get;list;watch;update
+//+kubebuilder:rbac:groups="image.openshift.io",resources=imagestreams;imagestreamimports,verbs=get;list;watch;create;update;patch
+
+func (r *InboundSyncReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	r.Log.Info("Reconciling Inbound Sync", "Name", req.Name)
+
+	instance := &corev1.Namespace{}
+	err := r.CoreComponents.ReconcilerBase.GetClient().Get(ctx, req.NamespacedName, instance)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return reconcile.Result{}, nil
+		}
+
+		// Error reading the object - requeue the request.
+		return reconcile.Result{}, err
+	}
+
+	if instance.GetDeletionTimestamp() != nil {
+		return reconcile.Result{}, nil
+	}
+
+	quayIntegration, result, err := r.CoreComponents.GetQuayIntegration(instance)
+	if err != nil {
+		return result, err
+	}
+
+	if !quayIntegration.IsAllowedNamespace(instance.Name) {
+		return reconcile.Result{}, nil
+	}
+
+	settings, err := utils.GetInboundSyncSettings(&quayIntegration, instance)
+	if err != nil {
+		return r.CoreComponents.ManageError(&core.QuayIntegrationCoreError{
+			Object:       instance,
+			Message:      "Invalid inbound sync configuration",
+			KeyAndValues: []interface{}{"Namespace", instance.Name},
+			Reason:       "ConfigurationError",
+			Error:        err,
+			SkipRequeue:  true,
+		})
+	}
+
+	if !settings.Enabled {
+		return reconcile.Result{}, nil
+	}
+
+	quayClient, result, err := r.CoreComponents.GetQuayClient(ctx, instance, &quayIntegration)
+	if err != nil || quayClient == nil {
+		return result, err
+	}
+
+	quayRegistryHostname, err := quayIntegration.GetRegistryHostname()
+	if err != nil {
+		return r.CoreComponents.ManageError(&core.QuayIntegrationCoreError{
+			Object:  instance,
+			Message: "Unable to determine Quay registry hostname",
+			Reason:  "ConfigurationError",
+			Error:   err,
+		})
+	}
+
+	target, err := utils.GetOrganizationTarget(&quayIntegration, instance)
+	if err != nil {
+		return r.CoreComponents.ManageError(&core.QuayIntegrationCoreError{
+			Object:       instance,
+			Message:      "Invalid target Quay Organization for Namespace",
+			KeyAndValues: []interface{}{"Namespace", instance.Name},
+			Reason:       "ConfigurationError",
+			Error:        err,
+		})
+	}
+
+	quayOrganizationName := target.Organization
+
+	// Shared organizations are never owned
+	if !target.Shared {
+		owned, err := isOrganizationOwned(quayClient, quayOrganizationName, quayIntegration.Spec.ClusterID)
+		if err != nil {
+			return r.CoreComponents.ManageError(&core.QuayIntegrationCoreError{
+				Object:       instance,
+				Message:      "Error occurred retrieving Organization ownership",
+				KeyAndValues: []interface{}{"Quay Organization", quayOrganizationName},
+				Reason:       "ProcessingError",
+				Error:        err,
+			})
+		}
+
+		if !owned {
+			logging.Log.Info("Skipping inbound sync of Quay Organization not managed by the operator", "Quay Organization", quayOrganizationName)
+			return reconcile.Result{RequeueAfter: settings.PollInterval}, nil
+		}
+	}
+
+	repositories, err := getQuayRepositories(quayClient, quayOrganizationName)
+	if err != nil {
+		return r.CoreComponents.ManageError(&core.QuayIntegrationCoreError{
+			Object:       instance,
+			Message:      "Error occurred retrieving Quay repositories",
+			KeyAndValues: []interface{}{"Quay Organization", quayOrganizationName},
+			Reason:       "ProcessingError",
+			Error:        err,
+		})
+	}
+
+	// Repositories are mapped to the existing ImageStreams whose repository path renders to their name
+	imageStreams := &imagev1.ImageStreamList{}
+	err = r.CoreComponents.ReconcilerBase.GetClient().List(ctx, imageStreams, &client.ListOptions{Namespace: instance.Name})
+	if err != nil {
+		return r.CoreComponents.ManageError(&core.QuayIntegrationCoreError{
+			Object:       instance,
+			Message:      "Error occurred retrieving ImageStreams",
+			KeyAndValues: []interface{}{"Namespace", instance.Name},
+			Reason:       "ProcessingError",
+			Error:        err,
+		})
+	}
+
+	imageStreamNames := map[string]bool{}
+	repositoryImageStreams := map[string]*imagev1.ImageStream{}
+	for i := range imageStreams.Items {
+		imageStreamNames[imageStreams.Items[i].Name] = true
+		if repositoryName, err := utils.GetRepositoryName(target, &imageStreams.Items[i]); err == nil {
+			repositoryImageStreams[repositoryName] = &imageStreams.Items[i]
+		}
+	}
+
+	createdImageStreams := false
+
+	for _, repository := range repositories {
+
+		if imageStream, found := repositoryImageStreams[repository.Name]; found {
+			// Only repositories whose ownership was recorded by the NamespaceIntegrationReconciler are imported
+			if imageStream.Annotations[constants.RepositoryOwnerAnnotation] != quayIntegration.Spec.ClusterID {
+				continue
+			}
+
+			if result, err := r.syncImageStream(ctx, instance, quayClient, quayOrganizationName, quayRegistryHostname, &quayIntegration, settings, repository.Name, imageStream); err != nil || result.Requeue {
+				return result, err
+			}
+
+			continue
+		}
+
+		// The ImageStream of a repository can only be derived from its name when no repository path template is used, and unprefixed repositories of shared organizations cannot be attributed to the namespace
+		if !settings.CreateImageStreams || target.RepositoryPathTemplate != "" || (target.Shared && target.RepositoryPrefix == "") {
+			continue
+		}
+
+		imageStreamName, found := target.ImageStreamName(repository.Name)
+		if !found || imageStreamNames[imageStreamName] {
+			continue
+		}
+
+		// Quay repository names permit characters which are not valid ImageStream names
+		if len(validation.IsDNS1123Subdomain(imageStreamName)) > 0 {
+			logging.Log.Info("Skipping Quay repository which is not a valid ImageStream name", "Quay Organization", quayOrganizationName, "Repository", repository.Name)
+			continue
+		}
+
+		imageStream := &imagev1.ImageStream{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      imageStreamName,
+				Namespace: instance.Name,
+			},
+		}
+
+		if err := r.CoreComponents.ReconcilerBase.GetClient().Create(ctx, imageStream); err != nil {
+			return r.CoreComponents.ManageError(&core.QuayIntegrationCoreError{
+				Object:       instance,
+				Message:      "Error occurred creating ImageStream",
+				KeyAndValues: []interface{}{"Namespace", instance.Name, "ImageStream", imageStreamName},
+				Reason:       "ProcessingError",
+				Error:        err,
+			})
+		}
+
+		logging.Log.Info("Created ImageStream for Quay repository", "Namespace", instance.Name, "ImageStream", imageStreamName)
+		createdImageStreams = true
+	}
+
+	// Created ImageStreams are imported once the NamespaceIntegrationReconciler has recorded the ownership of their repositories
+	if createdImageStreams {
+		return reconcile.Result{RequeueAfter: constants.RequeuePeriod}, nil
+	}
+
+	return reconcile.Result{RequeueAfter: settings.PollInterval}, nil
+}
+
+// syncImageStream imports the tags of a Quay repository which have changed since they were last imported into the ImageStream it maps to
+func (r *InboundSyncReconciler) syncImageStream(ctx context.Context, namespace *corev1.Namespace, quayClient *qclient.Client, quayOrganizationName string, quayRegistryHostname string, quayIntegration *quayv1.QuayIntegration, settings qotypes.InboundSyncSettings, repositoryName string, imageStream *imagev1.ImageStream) (reconcile.Result, error) {
+	imageStreamName := imageStream.Name
+
+	quayTags, err := getQuayRepositoryActiveTags(quayClient, quayOrganizationName, repositoryName)
+	if err != nil {
+		return r.CoreComponents.ManageError(&core.QuayIntegrationCoreError{
+			Object:       namespace,
+			Message:      "Error occurred retrieving Quay repository tags",
+			KeyAndValues: []interface{}{"Quay Repository", fmt.Sprintf("%s/%s", quayOrganizationName, repositoryName)},
+			Reason:       "ProcessingError",
+			Error:        err,
+		})
+	}
+
+	imageStreamKey := types.NamespacedName{Namespace: namespace.Name, Name: imageStreamName}
+
+	importedDigests := map[string]string{}
+	for _, tag := range imageStream.Spec.Tags {
+		importedDigests[tag.Name] = tag.Annotations[constants.ImageStreamTagDigestAnnotation]
+	}
+
+	destinationDigests := map[string]string{}
+	var destinations []qotypes.ImageStreamTagDestination
+
+	for _, quayTag := range quayTags {
+		if quayTag.ManifestDigest == "" || (settings.TagFilter != nil && !settings.TagFilter.MatchString(quayTag.Name)) {
+			continue
+		}
+
+		if importedDigests[quayTag.Name] == quayTag.ManifestDigest {
+			continue
+		}
+
+		destinationDigests[quayTag.Name] = quayTag.ManifestDigest
+		destinations = append(destinations, qotypes.ImageStreamTagDestination{Namespace: namespace.Name, Name: imageStreamName, Tag: quayTag.Name})
+	}
+
+	if len(destinations) == 0 {
+		return reconcile.Result{}, nil
+	}
+
+	isi := &imagev1.ImageStreamImport{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            imageStreamName,
+			Namespace:       namespace.Name,
+			ResourceVersion: imageStream.GetResourceVersion(),
+		},
+		Spec: imagev1.ImageStreamImportSpec{
+			Import: true,
+		},
+	}
+
+	for _, destination := range destinations {
+		isi.Spec.Images = append(isi.Spec.Images, imagev1.ImageImportSpec{
+			From: corev1.ObjectReference{
+				Kind: "DockerImage",
+				Name: fmt.Sprintf("%s/%s/%s@%s", quayRegistryHostname, quayOrganizationName, repositoryName, destinationDigests[destination.Tag]),
+			},
+			To: &corev1.LocalObjectReference{Name: destination.Tag},
+			ImportPolicy: imagev1.TagImportPolicy{
+				Insecure:  quayIntegration.Spec.InsecureRegistry,
+				Scheduled: quayIntegration.Spec.ScheduledImageStreamImport,
+			},
+			ReferencePolicy: imagev1.TagReferencePolicy{
+				Type: imagev1.SourceTagReferencePolicy,
+			},
+		})
+	}
+
+	err = r.CoreComponents.ReconcilerBase.GetClient().Create(ctx, isi)
+	if err != nil {
+		return r.CoreComponents.ManageError(&core.QuayIntegrationCoreError{
+			Object:       namespace,
+			Message:      "Error occurred creating ImageStreamImport",
+			KeyAndValues: []interface{}{"Namespace", namespace.Name, "ImageStream", imageStreamName},
+			Reason:       "ProcessingError",
+			Error:        err,
+		})
+	}
+
+	if importErr := getImageStreamImportError(isi); importErr != nil {
+		r.CoreComponents.ReconcilerBase.GetRecorder().Event(namespace, corev1.EventTypeWarning, "InboundSyncFailed", fmt.Sprintf("Failed to import Quay repository %s/%s: %s", quayOrganizationName, repositoryName, importErr))
+
+		return r.CoreComponents.ManageError(&core.QuayIntegrationCoreError{
+			Object:       namespace,
+			Message:      "Error occurred importing Quay repository tags",
+			KeyAndValues: []interface{}{"Namespace", namespace.Name, "ImageStream", imageStreamName},
+			Reason:       "ProcessingError",
+			Error:        importErr,
+		})
+	}
+
+	// Record the digest of each imported tag so unchanged tags are not imported again
+	for _, destination := range destinations {
+		err = annotateImageStreamTags(ctx, r.CoreComponents.ReconcilerBase.GetClient(), imageStreamKey, []qotypes.ImageStreamTagDestination{destination}, map[string]string{constants.ImageStreamTagDigestAnnotation: destinationDigests[destination.Tag]})
+		if err != nil {
+			return r.CoreComponents.ManageError(&core.QuayIntegrationCoreError{
+				Object:       namespace,
+				Message:      "Error occurred annotating ImageStream tags",
+				KeyAndValues: []interface{}{"Namespace", namespace.Name, "ImageStream", imageStreamName},
+				Reason:       "ProcessingError",
+				Error:        err,
+			})
+		}
+	}
+
+	r.CoreComponents.ReconcilerBase.GetRecorder().Event(namespace, corev1.EventTypeNormal, "InboundSyncImported", fmt.Sprintf("Imported %d tag(s) from Quay repository %s/%s into ImageStream %s", len(destinations), quayOrganizationName, repositoryName, imageStreamName))
+
+	return reconcile.Result{}, nil
+}
+
+// getQuayRepositories returns every repository of a Quay organization
+func getQuayRepositories(quayClient *qclient.Client, quayOrganizationName string) ([]qclient.Repository, error) {
+	var repositories []qclient.Repository
+
+	nextPage := ""
+
+	for {
+		repositoriesResponse, response, repositoriesErr := quayClient.GetRepositories(quayOrganizationName, nextPage)
+		if repositoriesErr.Error != nil {
+			return nil, repositoriesErr.Error
+		}
+
+		if response.StatusCode != 200 {
+			return nil, fmt.Errorf("failed to retrieve repositories of %s from Quay: status code %d", quayOrganizationName, response.StatusCode)
+		}
+
+		repositories = append(repositories, repositoriesResponse.Repositories...)
+
+		if repositoriesResponse.NextPage == "" {
+			return repositories, nil
+		}
+
+		nextPage = repositoriesResponse.NextPage
+	}
+}
+
+// getQuayRepositoryActiveTags returns every active tag of a Quay repository
+func getQuayRepositoryActiveTags(quayClient *qclient.Client, quayOrganizationName string, repositoryName string) ([]qclient.Tag, error) {
+	var tags []qclient.Tag
+
+	for page := 1; ; page++ {
+		tagsResponse, response, tagsErr := quayClient.GetRepositoryTags(quayOrganizationName, repositoryName, url.Values{"onlyActiveTags": {"true"}, "page": {strconv.Itoa(page)}, "limit": {"100"}})
+		if tagsErr.Error != nil {
+			return nil, tagsErr.Error
+		}
+
+		if response.StatusCode != 200 {
+			return nil, fmt.Errorf("failed to retrieve tags of %s/%s from Quay: status code %d", quayOrganizationName, repositoryName, response.StatusCode)
+		}
+
+		tags = append(tags, tagsResponse.Tags...)
+
+		if !tagsResponse.HasAdditional {
+			return tags, nil
+		}
+	}
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *InboundSyncReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		Named("inboundsync").
+		For(&corev1.Namespace{}).
+		Complete(r)
+}
@@ -0,0 +1,147 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	imagev1 "github.com/openshift/api/image/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	quayv1 "github.com/quay/quay-bridge-operator/api/v1"
+	"github.com/quay/quay-bridge-operator/pkg/constants"
+)
+
+var _ = Describe("Inbound sync controller", Ordered, func() {
+
+	const digest = "sha256:9e1c6a9d7e8f0b1e4f1b2e8d6c3a5f7e9b0d2c4a"
+
+	ctx := context.Background()
+
+	var quayIntegration *quayv1.QuayIntegration
+	var namespace *corev1.Namespace
+	var quayOrganizationName string
+	var registryHostname string
+
+	BeforeAll(func() {
+		quayIntegration = createQuayIntegration(ctx, "inbound", "inbound")
+
+		Eventually(func() error {
+			if err := k8sClient.Get(ctx, types.NamespacedName{Name: quayIntegration.Name}, quayIntegration); err != nil {
+				return err
+			}
+			quayIntegration.Spec.InboundSync = &quayv1.InboundSync{Enabled: true, PollInterval: &metav1.Duration{Duration: time.Second}}
+			return k8sClient.Update(ctx, quayIntegration)
+		}, timeout, interval).Should(Succeed())
+
+		namespace = createNamespace(ctx, "inbound")
+		waitForOnboarding(ctx, namespace.Name, quayIntegration)
+
+		quayOrganizationName = quayIntegration.GenerateQuayOrganizationNameFromNamespace(namespace.Name)
+
+		var err error
+		registryHostname, err = quayIntegration.GetRegistryHostname()
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	AfterAll(func() {
+		deleteQuayIntegration(ctx, quayIntegration)
+	})
+
+	It("imports tags pushed to Quay into an ImageStream whose repository is owned", func() {
+		createOwnedImageStream(ctx, namespace.Name, "app", quayIntegration)
+
+		quayServer.SetTag(quayOrganizationName, "app", "v1", digest)
+
+		Eventually(func(g Gomega) {
+			isi := &imagev1.ImageStreamImport{}
+			g.Expect(k8sClient.Get(ctx, types.NamespacedName{Namespace: namespace.Name, Name: "app"}, isi)).To(Succeed())
+			g.Expect(isi.Spec.Images).To(ContainElement(And(
+				HaveField("From.Name", fmt.Sprintf("%s/%s/app@%s", registryHostname, quayOrganizationName, digest)),
+				HaveField("To.Name", "v1"),
+			)))
+		}, timeout, interval).Should(Succeed())
+	})
+
+	It("does not recreate a deleted ImageStream", func() {
+		createOwnedImageStream(ctx, namespace.Name, "removed", quayIntegration)
+		Expect(k8sClient.Delete(ctx, &imagev1.ImageStream{ObjectMeta: metav1.ObjectMeta{Name: "removed", Namespace: namespace.Name}})).To(Succeed())
+
+		quayServer.SetTag(quayOrganizationName, "removed", "latest", digest)
+
+		Consistently(func() bool {
+			return apierrors.IsNotFound(k8sClient.Get(ctx, types.NamespacedName{Namespace: namespace.Name, Name: "removed"}, &imagev1.ImageStream{}))
+		}, 3*time.Second, interval).Should(BeTrue())
+	})
+
+	It("creates ImageStreams for repositories pushed to Quay once opted in", func() {
+		quayServer.AddRepository(quayOrganizationName, "pushed")
+		quayServer.SetTag(quayOrganizationName, "pushed", "latest", digest)
+
+		setNamespaceAnnotation(ctx, namespace.Name, constants.InboundSyncCreateImageStreamsAnnotation, "true")
+
+		Eventually(func(g Gomega) {
+			isi := &imagev1.ImageStreamImport{}
+			g.Expect(k8sClient.Get(ctx, types.NamespacedName{Namespace: namespace.Name, Name: "pushed"}, isi)).To(Succeed())
+			g.Expect(isi.Spec.Images).To(ContainElement(HaveField("To.Name", "latest")))
+		}, timeout, interval).Should(Succeed())
+
+		imageStream := &imagev1.ImageStream{}
+		Expect(k8sClient.Get(ctx, types.NamespacedName{Namespace: namespace.Name, Name: "pushed"}, imageStream)).To(Succeed())
+		Expect(imageStream.Annotations).To(HaveKeyWithValue(constants.RepositoryOwnerAnnotation, quayIntegration.Spec.ClusterID))
+	})
+
+	It("leaves organizations which are not owned untouched", func() {
+		foreignOrganizationName := quayIntegration.GenerateQuayOrganizationNameFromNamespace("inbound-foreign")
+		quayServer.AddOrganization(foreignOrganizationName)
+		quayServer.AddRepository(foreignOrganizationName, "app")
+		quayServer.SetTag(foreignOrganizationName, "app", "latest", digest)
+
+		foreignNamespace := createNamespace(ctx, "inbound-foreign")
+		setNamespaceAnnotation(ctx, foreignNamespace.Name, constants.InboundSyncCreateImageStreamsAnnotation, "true")
+
+		// A recorded repository ownership does not grant access to an organization owned by others
+		Expect(k8sClient.Create(ctx, &imagev1.ImageStream{ObjectMeta: metav1.ObjectMeta{
+			Name:        "app",
+			Namespace:   foreignNamespace.Name,
+			Annotations: map[string]string{constants.RepositoryOwnerAnnotation: quayIntegration.Spec.ClusterID},
+		}})).To(Succeed())
+
+		Consistently(func() bool {
+			return apierrors.IsNotFound(k8sClient.Get(ctx, types.NamespacedName{Namespace: foreignNamespace.Name, Name: "app"}, &imagev1.ImageStreamImport{}))
+		}, 3*time.Second, interval).Should(BeTrue())
+	})
+})
+
+// createOwnedImageStream creates an ImageStream and waits until the ownership of its Quay repository has been recorded
+func createOwnedImageStream(ctx context.Context, namespace string, name string, quayIntegration *quayv1.QuayIntegration) {
+	Expect(k8sClient.Create(ctx, &imagev1.ImageStream{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace}})).To(Succeed())
+
+	Eventually(func(g Gomega) {
+		imageStream := &imagev1.ImageStream{}
+		g.Expect(k8sClient.Get(ctx, types.NamespacedName{Namespace: namespace, Name: name}, imageStream)).To(Succeed())
+		g.Expect(imageStream.Annotations).To(HaveKeyWithValue(constants.RepositoryOwnerAnnotation, quayIntegration.Spec.ClusterID))
+	}, timeout, interval).Should(Succeed())
+}
+
+// setNamespaceAnnotation sets an annotation of a namespace, retrying on conflicts with the controllers updating it
+func setNamespaceAnnotation(ctx context.Context, name string, key string, value string) {
+	Eventually(func() error {
+		namespace := &corev1.Namespace{}
+		if err := k8sClient.Get(ctx, types.NamespacedName{Name: name}, namespace); err != nil {
+			return err
+		}
+
+		if namespace.Annotations == nil {
+			namespace.Annotations = map[string]string{}
+		}
+		namespace.Annotations[key] = value
+
+		return k8sClient.Update(ctx, namespace)
+	}, timeout, interval).Should(Succeed())
+}
@@ -18,10 +18,12 @@ package controllers
 
 import (
 	"context"
-	"crypto/tls"
 	"fmt"
-	"net/http"
 	"net/url"
+	"reflect"
+	"slices"
+	"strings"
+	"time"
 
 	"github.com/go-logr/logr"
 	imagev1 "github.com/openshift/api/image/v1"
@@ -39,13 +41,22 @@ import (
 	"github.com/quay/quay-bridge-operator/pkg/utils"
 	"golang.org/x/sync/errgroup"
 	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
 	ctrl "sigs.k8s.io/controller-runtime"
 
 	"k8s.io/apimachinery/pkg/api/errors"
 
+	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
 	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 	"sigs.k8s.io/controller-runtime/pkg/source"
 )
@@ -57,8 +68,21 @@ var (
 		qotypes.DefaultOpenShiftServiceAccount:  qclient.QuayRoleRead,
 		qotypes.DeployerOpenShiftServiceAccount: qclient.QuayRoleRead,
 	}
+
+	// QuayTeamRoleMatrix contains a mapping between Quay Teams and their organization and repository roles
+	QuayTeamRoleMatrix = map[qotypes.QuayTeam]QuayTeamRoles{
+		qotypes.AdminsQuayTeam:  {TeamRole: qclient.TeamRoleAdmin},
+		qotypes.EditorsQuayTeam: {TeamRole: qclient.TeamRoleMember, RepositoryRole: qclient.QuayRoleWrite},
+		qotypes.ViewersQuayTeam: {TeamRole: qclient.TeamRoleMember, RepositoryRole: qclient.QuayRoleRead},
+	}
 )
 
+// QuayTeamRoles represents the roles of a Quay Team. Teams with the admin role have access to every repository of the organization
+type QuayTeamRoles struct {
+	TeamRole       string
+	RepositoryRole qclient.QuayRole
+}
+
 // NamespaceIntegrationReconciler reconciles a QuayIntegration object
 type NamespaceIntegrationReconciler struct {
 	CoreComponents core.CoreComponents
@@ -68,11 +92,15 @@ type NamespaceIntegrationReconciler struct {
 //+kubebuilder:rbac:groups=quay.redhat.com,resources=quayintegrations,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups=quay.redhat.com,resources=quayintegrations/status,verbs=get;update;patch
 //+kubebuilder:rbac:groups=quay.redhat.com,resources=quayintegrations/finalizers,verbs=update
-//+kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch;create;update;patch
+//+kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups="",resources=events,verbs=get;list;watch;create;update;patch
 //+kubebuilder:rbac:groups="",resources=serviceaccounts,verbs=get;list;watch;create;update;patch
 //+kubebuilder:rbac:groups="",resources=namespaces,verbs=get;list;watch;update
+//+kubebuilder:rbac:groups="",resources=namespaces/status,verbs=get;update;patch
+//+kubebuilder:rbac:groups="",resources=resourcequotas,verbs=get;list;watch
 //+kubebuilder:rbac:groups="image.openshift.io",resources=imagestreams;imagestreamimports,verbs=get;list;watch;create;update;patch
+//+kubebuilder:rbac:groups=rbac.authorization.k8s.io,resources=rolebindings,verbs=get;list;watch
+//+kubebuilder:rbac:groups=external-secrets.io,resources=pushsecrets;externalsecrets,verbs=get;list;watch;create;update;patch;delete
 
 func (r *NamespaceIntegrationReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
 	r.Log.Info("Reconciling Namespace", "Name", req.Name)
@@ -104,6 +132,23 @@ func (r *NamespaceIntegrationReconciler) Reconcile(ctx context.Context, req ctrl
 	}
 
 	if len(quayIntegrations.Items) != 1 {
+		// Service accounts of namespaces managed by a deleted QuayIntegration no longer reference its Secrets
+		if len(quayIntegrations.Items) == 0 && util.HasFinalizer(instance, constants.NamespaceFinalizer) {
+			if err := r.unlinkServiceAccountSecrets(ctx, instance); err != nil {
+				return r.CoreComponents.ManageError(&core.QuayIntegrationCoreError{
+					Object:       instance,
+					Message:      "Failed to unlink Robot Account Secrets from Service Accounts",
+					KeyAndValues: []interface{}{"Namespace", instance.Name},
+					Error:        err,
+				})
+			}
+
+			// Without an integration the organization cannot be deleted, so the namespace is not kept from terminating
+			if util.IsBeingDeleted(instance) {
+				return r.removeNamespaceFinalizer(ctx, instance)
+			}
+		}
+
 		return r.CoreComponents.ManageError(&core.QuayIntegrationCoreError{
 			Object:  instance,
 			Message: "No QuayIntegrations defined or more than 1 integration present",
@@ -113,82 +158,84 @@ func (r *NamespaceIntegrationReconciler) Reconcile(ctx context.Context, req ctrl
 
 	quayIntegration := *&quayIntegrations.Items[0]
 
+	// Managed namespaces are torn down by the QuayIntegrationReconciler once the integration is deleted
+	if util.IsBeingDeleted(&quayIntegration) {
+		return reconcile.Result{}, nil
+	}
+
+	// Forcing finalization leaves the Quay resources of the namespace in place
+	if util.IsBeingDeleted(instance) && util.HasFinalizer(instance, constants.NamespaceFinalizer) && utils.IsFinalizationForced(instance, &quayIntegration) {
+		r.CoreComponents.ReconcilerBase.GetRecorder().Event(instance, "Warning", "FinalizationForced", "Quay resources of the namespace were left in place as finalization was forced")
+		return r.removeNamespaceFinalizer(ctx, instance)
+	}
+
 	// Check is this is a valid namespace (TODO: Use a predicate to filter out?)
 	validNamespace := quayIntegration.IsAllowedNamespace(instance.Name)
 	if !validNamespace {
 		// Not a synchronized namespace
-		return reconcile.Result{}, nil
-	}
+		if !util.HasFinalizer(instance, constants.NamespaceFinalizer) {
+			return reconcile.Result{}, nil
+		}
 
-	if quayIntegration.Spec.CredentialsSecret == nil {
-		return r.CoreComponents.ManageError(&core.QuayIntegrationCoreError{
-			Object:  instance,
-			Message: "Required parameter 'CredentialsSecret' not found",
-			Reason:  "ConfigurationError",
-		})
-	}
+		// Namespaces excluded after being managed are offboarded
+		quayClient, result, err := r.CoreComponents.GetQuayClient(ctx, instance, &quayIntegration)
+		if err != nil || quayClient == nil {
+			return result, err
+		}
 
-	secretCredential := &corev1.Secret{}
+		// Excluded namespaces still exist, so their organization is only deleted when requested
+		deletionPolicy := constants.OrganizationDeletionPolicyRetain
+		if quayIntegration.Spec.OffboardingPolicy == constants.OrganizationDeletionPolicyDelete {
+			deletionPolicy = constants.OrganizationDeletionPolicyDelete
+		}
 
-	err = r.CoreComponents.ReconcilerBase.GetClient().Get(ctx, types.NamespacedName{Namespace: quayIntegration.Spec.CredentialsSecret.Namespace, Name: quayIntegration.Spec.CredentialsSecret.Name}, secretCredential)
-	if err != nil {
-		return r.CoreComponents.ManageError(&core.QuayIntegrationCoreError{
-			Object:       instance,
-			Message:      "Error Locating Quay Integration Secret",
-			Reason:       "ConfigurationError",
-			KeyAndValues: []interface{}{"Namespace", quayIntegration.Spec.CredentialsSecret.Namespace, "Secret", quayIntegration.Spec.CredentialsSecret.Name},
-		})
-	}
+		message, err := r.offboardNamespace(ctx, instance, quayClient, &quayIntegration, deletionPolicy)
+		if err != nil {
+			return r.CoreComponents.ManageError(&core.QuayIntegrationCoreError{
+				Object:       instance,
+				Message:      "Failed to offboard namespace",
+				KeyAndValues: []interface{}{"Namespace", instance.Name},
+				Error:        err,
+			})
+		}
 
-	quaySecretCredentialTokenKey := constants.QuaySecretCredentialTokenKey
+		logging.Log.Info("Offboarded namespace", "Namespace", instance.Name)
+		r.CoreComponents.ReconcilerBase.GetRecorder().Event(instance, "Normal", "NamespaceOffboarded", fmt.Sprintf("Namespace is no longer managed by the QuayIntegration. %s", message))
 
-	if quayIntegration.Spec.CredentialsSecret.Key != "" {
-		quaySecretCredentialTokenKey = quayIntegration.Spec.CredentialsSecret.Key
+		return reconcile.Result{}, nil
 	}
 
-	if _, ok := secretCredential.Data[quaySecretCredentialTokenKey]; !ok {
-		return r.CoreComponents.ManageError(&core.QuayIntegrationCoreError{
-			Object:       instance,
-			Message:      fmt.Sprintf("Credential Secret does not contain key '%s'", quaySecretCredentialTokenKey),
-			Reason:       "ConfigurationError",
-			KeyAndValues: []interface{}{"Namespace", quayIntegration.Spec.CredentialsSecret.Namespace, "Secret", quayIntegration.Spec.CredentialsSecret.Name},
-		})
+	quayClient, result, err := r.CoreComponents.GetQuayClient(ctx, instance, &quayIntegration)
+	if err != nil || quayClient == nil {
+		if util.IsBeingDeleted(instance) && util.HasFinalizer(instance, constants.NamespaceFinalizer) {
+			return r.blockFinalization(ctx, instance, "QuayUnavailable", "Quay resources of the namespace cannot be deleted as this cluster cannot manage Quay", result, err)
+		}
+		return result, err
 	}
 
-	authToken := string(secretCredential.Data[constants.QuaySecretCredentialTokenKey])
-
-	// Setup Quay Client
-	quayClient := qclient.NewClient(&http.Client{
-		Transport: &http.Transport{
-			TLSClientConfig: &tls.Config{InsecureSkipVerify: true},
-		},
-	}, quayIntegration.Spec.QuayHostname, authToken)
-
-	// Create Organization
-	quayOrganizationName := quayIntegration.GenerateQuayOrganizationNameFromNamespace(req.Name)
+	// Resolve the Quay organization of the namespace
+	target, targetErr := utils.GetOrganizationTarget(&quayIntegration, instance)
 
 	if util.IsBeingDeleted(instance) {
 		if !util.HasFinalizer(instance, constants.NamespaceFinalizer) {
 			return reconcile.Result{}, nil
 		}
 
-		// Remove Resources
-		result, err := r.cleanupResources(req, instance, quayClient, quayOrganizationName)
-		if err != nil {
-			return result, err
+		// The resources of a namespace whose organization cannot be resolved cannot be located, so the namespace is not kept from terminating
+		if targetErr != nil {
+			r.CoreComponents.ReconcilerBase.GetRecorder().Event(instance, "Warning", "OrganizationRetained", fmt.Sprintf("Quay resources of the namespace were not deleted as its organization cannot be resolved: %v", targetErr))
+			return r.removeNamespaceFinalizer(ctx, instance)
 		}
 
-		util.RemoveFinalizer(instance, constants.NamespaceFinalizer)
-		err = r.CoreComponents.ReconcilerBase.GetClient().Update(ctx, instance)
-		if err != nil {
-			return r.CoreComponents.ManageError(&core.QuayIntegrationCoreError{
-				Object:       instance,
-				Message:      "Unable to update namespace",
-				KeyAndValues: []interface{}{"Namespace", instance.Name},
-				Error:        err,
-			})
+		// Remove Resources
+		if quayIntegration.Spec.OrganizationDeletionPolicy != constants.OrganizationDeletionPolicyRetain {
+			result, err := r.cleanupResources(req, instance, quayClient, target, quayIntegration.Spec.ClusterID)
+			if err != nil || result.Requeue {
+				return r.blockFinalization(ctx, instance, "CleanupFailed", fmt.Sprintf("Quay resources of the namespace in organization %s could not be deleted", target.Organization), result, err)
+			}
 		}
-		return reconcile.Result{}, nil
+
+		return r.removeNamespaceFinalizer(ctx, instance)
 	}
 
 	// Finalizer Management
@@ -213,17 +260,24 @@ func (r *NamespaceIntegrationReconciler) Reconcile(ctx context.Context, req ctrl
 		return reconcile.Result{}, nil
 	}
 
-	// Setup Resources
-	result, err := r.setupResources(ctx, req, instance, quayClient, quayOrganizationName, quayIntegration.Spec.ClusterID, quayIntegration.Spec.QuayHostname)
-	if err != nil {
-		return result, err
+	if targetErr != nil {
+		return r.CoreComponents.ManageError(&core.QuayIntegrationCoreError{
+			Object:       instance,
+			Message:      "Invalid target Quay Organization for Namespace",
+			KeyAndValues: []interface{}{"Namespace", instance.Name},
+			Reason:       "ConfigurationError",
+			Error:        targetErr,
+		})
 	}
 
-	return reconcile.Result{}, nil
+	// Setup Resources
+	return r.setupResources(ctx, req, instance, quayClient, target, &quayIntegration)
 }
 
-func (r *NamespaceIntegrationReconciler) setupResources(ctx context.Context, request reconcile.Request, namespace *corev1.Namespace, quayClient *qclient.Client, quayOrganizationName string, quayName string, quayHostname string) (reconcile.Result, error) {
-	_, organizationResponse, organizationError := quayClient.GetOrganizationByName(quayOrganizationName)
+func (r *NamespaceIntegrationReconciler) setupResources(ctx context.Context, request reconcile.Request, namespace *corev1.Namespace, quayClient *qclient.Client, target qotypes.OrganizationTarget, quayIntegration *quayv1.QuayIntegration) (reconcile.Result, error) {
+	quayOrganizationName := target.Organization
+
+	organization, organizationResponse, organizationError := quayClient.GetOrganizationByName(quayOrganizationName)
 
 	if organizationError.Error != nil {
 		return r.CoreComponents.ManageError(&core.QuayIntegrationCoreError{
@@ -234,26 +288,28 @@ func (r *NamespaceIntegrationReconciler) setupResources(ctx context.Context, req
 		})
 	}
 
-	// Check to see if Organization Exists (Response Code)
-	if organizationResponse.StatusCode == 404 {
-		// Create Organization
-		logging.Log.Info("Organization Does Not Exist", "Name", quayOrganizationName)
+	var ownership qotypes.OrganizationOwnership
 
-		_, createOrganizationResponse, createOrganizationError := quayClient.CreateOrganization(quayOrganizationName)
-		if createOrganizationError.Error != nil || createOrganizationResponse.StatusCode != 201 {
+	if target.Shared {
+		// Shared organizations are provided by their owners, so only the repositories and robot accounts of the namespace are managed
+		if organizationResponse.StatusCode != 200 {
 			return r.CoreComponents.ManageError(&core.QuayIntegrationCoreError{
 				Object:       namespace,
-				Message:      "Error occurred creating Quay Organization",
-				KeyAndValues: []interface{}{"Status Code", createOrganizationResponse.StatusCode},
-				Error:        organizationError.Error,
+				Message:      "Shared Quay Organization does not exist",
+				KeyAndValues: []interface{}{"Organization", quayOrganizationName, "Status Code", organizationResponse.StatusCode},
+				Reason:       "ConfigurationError",
 			})
 		}
-	} else if organizationResponse.StatusCode != 200 {
-		return r.CoreComponents.ManageError(&core.QuayIntegrationCoreError{
-			Object:       namespace,
-			Message:      "Error occurred retrieving Quay Organization",
-			KeyAndValues: []interface{}{"Organization", quayOrganizationName},
-		})
+
+		ownership = qotypes.OrganizationOwnership{Owned: true, Origin: constants.OwnershipOriginShared}
+	} else {
+		var result reconcile.Result
+		var err error
+
+		ownership, result, err = r.syncOrganization(ctx, namespace, quayClient, quayOrganizationName, quayIntegration, organization, organizationResponse.StatusCode)
+		if err != nil || result.Requeue || !ownership.Owned {
+			return result, err
+		}
 	}
 
 	var g errgroup.Group
@@ -262,7 +318,7 @@ func (r *NamespaceIntegrationReconciler) setupResources(ctx context.Context, req
 	for quayServiceAccountPermissionMatrixKey, quayServiceAccountPermissionMatrixValue := range QuayServiceAccountPermissionMatrix {
 		func(quayServiceAccountPermissionMatrixKey qotypes.OpenShiftServiceAccount, quayServiceAccountPermissionMatrixValue qclient.QuayRole) {
 			g.Go(func() error {
-				if _, robotAccountErr := r.createRobotAccountAssociateToSA(ctx, request, namespace, quayClient, quayOrganizationName, quayServiceAccountPermissionMatrixKey, quayServiceAccountPermissionMatrixValue, quayName, quayHostname); robotAccountErr != nil {
+				if _, robotAccountErr := r.createRobotAccountAssociateToSA(ctx, request, namespace, quayClient, target, quayServiceAccountPermissionMatrixKey, quayServiceAccountPermissionMatrixValue, quayIntegration); robotAccountErr != nil {
 					return robotAccountErr
 				}
 				return nil
@@ -287,250 +343,2024 @@ func (r *NamespaceIntegrationReconciler) setupResources(ctx context.Context, req
 		})
 	}
 
-	for _, imageStream := range imageStreams.Items {
-		imageStreamName := imageStream.Name
-		// Check if Repository Exists
-		_, repositoryHttpResponse, repositoryErr := quayClient.GetRepository(quayOrganizationName, imageStreamName)
-		if repositoryHttpResponse == nil {
-			return r.CoreComponents.ManageError(&core.QuayIntegrationCoreError{
-				Object:       namespace,
-				Message:      "Error creating request to retrieve repository",
-				KeyAndValues: []interface{}{"Namespace", namespace.Name, "Name", imageStreamName},
-				Error:        repositoryErr.Error,
-			})
+	// Synchronize project members to Quay Teams. The teams of a shared organization belong to its owners
+	if !target.Shared {
+		if result, err := r.syncTeams(ctx, namespace, quayClient, quayOrganizationName, quayIntegration); err != nil || result.Requeue {
+			return result, err
 		}
+	}
 
-		if repositoryErr.Error != nil {
-			return r.CoreComponents.ManageError(&core.QuayIntegrationCoreError{
-				Object:       namespace,
-				Message:      "Error Retrieving Repository",
-				KeyAndValues: []interface{}{"Namespace", namespace.Name, "Name", imageStreamName, "Status Code", repositoryHttpResponse.StatusCode},
-				Error:        repositoryErr.Error,
-			})
+	// Mirrored repositories are polled for their sync status
+	var requeueAfter time.Duration
+
+	// Repositories not created by the operator are skipped unless adopted
+	ownedImageStreams := []imagev1.ImageStream{}
+	conflictingRepositories := []string{}
+
+	// ImageStreams whose repository path is invalid or already taken by another ImageStream are skipped
+	repositoryImageStreams := map[string]string{}
+
+	for i := range imageStreams.Items {
+		repositoryName, repositoryNameErr := utils.GetRepositoryName(target, &imageStreams.Items[i])
+		if repositoryNameErr != nil {
+			r.CoreComponents.ReconcilerBase.GetRecorder().Event(&imageStreams.Items[i], corev1.EventTypeWarning, "InvalidRepositoryName", fmt.Sprintf("Unable to determine the Quay repository of the ImageStream: %v", repositoryNameErr))
+			continue
 		}
 
-		// If an Repository reports back that it cannot be found or permission dened
-		if repositoryHttpResponse.StatusCode == 403 || repositoryHttpResponse.StatusCode == 404 {
-			logging.Log.Info("Creating Repository", "Organization", quayOrganizationName, "Name", imageStreamName)
-			_, createRepositoryResponse, createRepositoryErr := quayClient.CreateRepository(quayOrganizationName, imageStreamName)
-			if createRepositoryErr.Error != nil || createRepositoryResponse.StatusCode != 201 {
-				return r.CoreComponents.ManageError(&core.QuayIntegrationCoreError{
-					Object:       namespace,
-					Message:      "Error occurred creating Quay Repository",
-					KeyAndValues: []interface{}{"Quay Repository", fmt.Sprintf("%s/%s", quayOrganizationName, imageStreamName), "Status Code", createRepositoryResponse.StatusCode},
-					Error:        createRepositoryErr.Error,
-				})
+		if imageStreamName, found := repositoryImageStreams[repositoryName]; found {
+			r.CoreComponents.ReconcilerBase.GetRecorder().Event(&imageStreams.Items[i], corev1.EventTypeWarning, "InvalidRepositoryName", fmt.Sprintf("Quay repository %s/%s is already used by ImageStream %s", quayOrganizationName, repositoryName, imageStreamName))
+			continue
+		}
+
+		repositoryImageStreams[repositoryName] = imageStreams.Items[i].Name
+
+		repositoryOwned, result, err := r.syncRepositoryOwnership(ctx, namespace, quayClient, quayOrganizationName, quayIntegration, ownership, &imageStreams.Items[i], repositoryName)
+		if err != nil || result.Requeue {
+			return result, err
+		}
+
+		if !repositoryOwned {
+			conflictingRepositories = append(conflictingRepositories, repositoryName)
+			continue
+		}
+
+		ownedImageStreams = append(ownedImageStreams, imageStreams.Items[i])
+
+		if result, err := r.syncRepository(ctx, namespace, quayClient, quayOrganizationName, quayIntegration, &imageStreams.Items[i], repositoryName); err != nil || result.Requeue {
+			return result, err
+		}
+
+		// Robot accounts of shared organizations have no default permissions and are granted access to the repositories of their namespace only
+		if target.Shared {
+			if result, err := r.syncRepositoryRobotPermissions(namespace, quayClient, target, repositoryName); err != nil || result.Requeue {
+				return result, err
+			}
+		} else {
+			if result, err := r.syncRepositoryTeamPermissions(namespace, quayClient, quayOrganizationName, quayIntegration, repositoryName); err != nil || result.Requeue {
+				return result, err
 			}
-		} else if repositoryHttpResponse.StatusCode != 200 {
-			return r.CoreComponents.ManageError(&core.QuayIntegrationCoreError{
-				Object:       namespace,
-				Message:      "Error Retrieving Repository for Namespace",
-				KeyAndValues: []interface{}{"Quay Repository", fmt.Sprintf("%s/%s", quayOrganizationName, imageStreamName), "Status Code", repositoryHttpResponse.StatusCode},
-			})
 		}
-	}
 
-	return reconcile.Result{}, nil
-}
+		if result, err := r.syncRepositoryNotifications(namespace, quayClient, quayOrganizationName, &imageStreams.Items[i], repositoryName); err != nil || result.Requeue {
+			return result, err
+		}
 
-// createRobotAccountAndSecret creates a robot account, creates a secret and adds the secret to the service account
-func (r *NamespaceIntegrationReconciler) createRobotAccountAssociateToSA(ctx context.Context, request reconcile.Request, namespace *corev1.Namespace, quayClient *qclient.Client, quayOrganizationName string, serviceAccount qotypes.OpenShiftServiceAccount, role qclient.QuayRole, quayName string, quayHostname string) (reconcile.Result, error) {
-	// Setup Robot Account
-	robotAccount, robotAccountResponse, robotAccountError := quayClient.GetOrganizationRobotAccount(quayOrganizationName, string(serviceAccount))
-	if robotAccountResponse == nil {
-		return r.CoreComponents.ManageError(&core.QuayIntegrationCoreError{
-			Object:       namespace,
-			Message:      "Error occured creating HTTP request to fetch Quay Organization Robot Acccount",
-			KeyAndValues: []interface{}{"Quay Repository", quayOrganizationName, "Robot Account", serviceAccount},
-			Error:        robotAccountError.Error,
-		})
-	}
+		result, err = r.syncRepositoryMirror(ctx, namespace, quayClient, target, &imageStreams.Items[i], repositoryName)
+		if err != nil || result.Requeue {
+			return result, err
+		}
 
-	if robotAccountError.Error != nil {
-		return r.CoreComponents.ManageError(&core.QuayIntegrationCoreError{
-			Object:       namespace,
-			Message:      "Error occurred retrieving robot account for Quay Organization",
-			KeyAndValues: []interface{}{"Quay Repository", quayOrganizationName, "Robot Account", serviceAccount, "Status Code", robotAccountResponse.StatusCode},
-			Error:        robotAccountError.Error,
-		})
+		if result.RequeueAfter > 0 {
+			requeueAfter = result.RequeueAfter
+		}
 	}
 
-	// Check to see if Robot Exists
-	if robotAccountResponse.StatusCode == 400 {
-		// Create Robot Account
-		robotAccount, robotAccountResponse, robotAccountError = quayClient.CreateOrganizationRobotAccount(quayOrganizationName, string(serviceAccount))
-		if robotAccountError.Error != nil || robotAccountResponse.StatusCode != 201 {
-			return r.CoreComponents.ManageError(&core.QuayIntegrationCoreError{
-				Object:       namespace,
-				Message:      "Error occurred retrieving robot account for Quay Organization",
-				KeyAndValues: []interface{}{"Quay Repository", quayOrganizationName, "Robot Account", serviceAccount, "Status Code", robotAccountResponse.StatusCode},
-			})
-		}
+	// Grant builders from other namespaces access to push to this namespace
+	result, err := r.syncCrossNamespacePermissions(ctx, namespace, quayClient, target, quayIntegration, ownedImageStreams)
+	if err != nil || result.Requeue {
+		return result, err
 	}
 
-	organizationPrototypes, organizationPrototypesResponse, organizationPrototypesError := quayClient.GetPrototypesByOrganization(quayOrganizationName)
-	if organizationPrototypesError.Error != nil {
-		return r.CoreComponents.ManageError(&core.QuayIntegrationCoreError{
-			Object:       namespace,
-			Message:      "Error occurred retrieving Prototypes for Quay Organization",
-			KeyAndValues: []interface{}{"Quay Repository", quayOrganizationName, "Status Code", robotAccountResponse.StatusCode},
-			Error:        organizationPrototypesError.Error,
-		})
+	result, err = r.updateNamespaceCondition(ctx, namespace, utils.GetOwnershipCondition(quayOrganizationName, ownership, conflictingRepositories), "Error occurred updating Namespace ownership status")
+	if err != nil || result.Requeue {
+		return result, err
 	}
 
-	if organizationPrototypesResponse.StatusCode != 200 {
-		return r.CoreComponents.ManageError(&core.QuayIntegrationCoreError{
+	return reconcile.Result{RequeueAfter: requeueAfter}, nil
+}
+
+// syncOrganization creates the Quay organization generated for a namespace and reconciles its ownership, contact, tag expiration and quota
+func (r *NamespaceIntegrationReconciler) syncOrganization(ctx context.Context, namespace *corev1.Namespace, quayClient *qclient.Client, quayOrganizationName string, quayIntegration *quayv1.QuayIntegration, organization qclient.Organization, organizationStatusCode int) (qotypes.OrganizationOwnership, reconcile.Result, error) {
+	contactSettings, contactErr := utils.GetOrganizationContactSettings(quayIntegration, namespace, quayOrganizationName)
+	if contactErr != nil {
+		result, err := r.CoreComponents.ManageError(&core.QuayIntegrationCoreError{
 			Object:       namespace,
-			Message:      "Error occurred retrieving Prototypes for Quay Organization",
-			KeyAndValues: []interface{}{"Quay Repository", quayOrganizationName, "Status Code", robotAccountResponse.StatusCode},
+			Message:      "Invalid Organization Contact for Namespace",
+			KeyAndValues: []interface{}{"Namespace", namespace.Name},
+			Reason:       "ConfigurationError",
+			Error:        contactErr,
 		})
+		return qotypes.OrganizationOwnership{}, result, err
 	}
 
-	if found := qclient.IsRobotAccountInPrototypeByRole(organizationPrototypes.Prototypes, robotAccount.Name, string(role)); !found {
-		// Create Prototype
-		_, robotPrototypeResponse, robotPrototypeError := quayClient.CreateRobotPermissionForOrganization(quayOrganizationName, robotAccount.Name, string(role))
-		if robotPrototypeError.Error != nil || robotPrototypeResponse.StatusCode != 200 {
-			return r.CoreComponents.ManageError(&core.QuayIntegrationCoreError{
+	// Check to see if Organization Exists (Response Code)
+	if organizationStatusCode == 404 {
+		// Create Organization
+		logging.Log.Info("Organization Does Not Exist", "Name", quayOrganizationName)
+
+		_, createOrganizationResponse, createOrganizationError := quayClient.CreateOrganization(quayOrganizationName, contactSettings.Email)
+		if createOrganizationError.Error != nil || createOrganizationResponse.StatusCode != 201 {
+			result, err := r.CoreComponents.ManageError(&core.QuayIntegrationCoreError{
 				Object:       namespace,
-				Message:      "Error occurred creating Robot account permissions for Prototype",
-				KeyAndValues: []interface{}{"Quay Repository", quayOrganizationName, "Robot Account", robotAccount.Name, "Prototype", role, "Status Code", robotPrototypeResponse.StatusCode},
-				Error:        robotPrototypeError.Error,
+				Message:      "Error occurred creating Quay Organization",
+				KeyAndValues: []interface{}{"Status Code", createOrganizationResponse.StatusCode},
+				Error:        createOrganizationError.Error,
 			})
+			return qotypes.OrganizationOwnership{}, result, err
 		}
-	}
 
-	// Parse out hostname from Quay Hostname
-	quayURL, quayURLErr := url.Parse(quayHostname)
-	if quayURLErr != nil {
-		return r.CoreComponents.ManageError(&core.QuayIntegrationCoreError{
+		organization.Email = contactSettings.Email
+	} else if organizationStatusCode != 200 {
+		result, err := r.CoreComponents.ManageError(&core.QuayIntegrationCoreError{
 			Object:       namespace,
-			Message:      "Failed to parse Quay hostname",
-			KeyAndValues: []interface{}{"Hostname", quayHostname},
-			Error:        quayURLErr,
+			Message:      "Error occurred retrieving Quay Organization",
+			KeyAndValues: []interface{}{"Organization", quayOrganizationName},
 		})
+		return qotypes.OrganizationOwnership{}, result, err
 	}
 
-	// Setup Secret for Quay Robot Account
-	robotSecret, robotSecretErr := credentials.GenerateDockerJsonSecret(utils.GenerateDockerJsonSecretNameForServiceAccount(string(serviceAccount), quayName), quayURL.Host, robotAccount.Name, robotAccount.Token, "")
-	if robotSecretErr != nil {
-		return r.CoreComponents.ManageError(&core.QuayIntegrationCoreError{
-			Object:       namespace,
-			Message:      "Failed to generate Docker JSON Secret for Service Account",
-			KeyAndValues: []interface{}{"Namespace", namespace.Name, "Robot Account", robotAccount.Name, "Service Account", serviceAccount},
-			Error:        robotSecretErr,
-		})
+	// Organizations not created by the operator are only managed once adopted
+	ownership, result, err := r.syncOrganizationOwnership(ctx, namespace, quayClient, quayOrganizationName, quayIntegration, organizationStatusCode == 404)
+	if err != nil || result.Requeue || !ownership.Owned {
+		return ownership, result, err
 	}
 
-	robotSecret.ObjectMeta.Namespace = namespace.Name
-
-	robotCreateSecretErr := r.CoreComponents.ReconcilerBase.CreateOrUpdateResource(ctx, nil, namespace.Name, robotSecret)
-	if robotCreateSecretErr != nil {
-		return reconcile.Result{Requeue: true}, robotSecretErr
+	if result, err := r.syncOrganizationContact(namespace, quayClient, quayOrganizationName, contactSettings, organization); err != nil || result.Requeue {
+		return ownership, result, err
 	}
 
-	existingServiceAccount := &corev1.ServiceAccount{}
-	serviceAccountErr := r.CoreComponents.ReconcilerBase.GetClient().Get(ctx, types.NamespacedName{Namespace: namespace.Name, Name: string(serviceAccount)}, existingServiceAccount)
-	if serviceAccountErr != nil {
-		return r.CoreComponents.ManageError(&core.QuayIntegrationCoreError{
-			Object:       namespace,
-			Message:      "Failed to get existing platform service account",
-			KeyAndValues: []interface{}{"Namespace", namespace.Name, "Service Account", serviceAccount},
-			Error:        serviceAccountErr,
-		})
-
+	if result, err := r.syncUntaggedExpiration(namespace, quayClient, quayOrganizationName, quayIntegration, organization); err != nil || result.Requeue {
+		return ownership, result, err
 	}
 
-	_, updated := r.updateSecretWithMountablePullSecret(existingServiceAccount, robotSecret.Name)
-	if updated {
-		updatedServiceAccountErr := r.CoreComponents.ReconcilerBase.CreateOrUpdateResource(ctx, nil, namespace.Name, existingServiceAccount)
-		if updatedServiceAccountErr != nil {
-			return r.CoreComponents.ManageError(&core.QuayIntegrationCoreError{
-				Object:       namespace,
-				Message:      "Failed to to updated existing platform service account",
-				KeyAndValues: []interface{}{"Namespace", namespace.Name, "Service Account", serviceAccount},
-				Error:        updatedServiceAccountErr,
-			})
-		}
+	if result, err := r.syncOrganizationQuota(ctx, namespace, quayClient, quayOrganizationName, quayIntegration, organization); err != nil || result.Requeue {
+		return ownership, result, err
 	}
 
-	return reconcile.Result{}, nil
+	return ownership, reconcile.Result{}, nil
 }
 
-func (r *NamespaceIntegrationReconciler) cleanupResources(request reconcile.Request, namespace *corev1.Namespace, quayClient *qclient.Client, quayOrganizationName string) (reconcile.Result, error) {
-	logging.Log.Info("Deleting Organization", "Organization Name", quayOrganizationName)
+// syncOrganizationOwnership determines whether the operator may manage the Quay organization of a namespace and records its ownership
+func (r *NamespaceIntegrationReconciler) syncOrganizationOwnership(ctx context.Context, namespace *corev1.Namespace, quayClient *qclient.Client, quayOrganizationName string, quayIntegration *quayv1.QuayIntegration, created bool) (qotypes.OrganizationOwnership, reconcile.Result, error) {
+	ownership := qotypes.OrganizationOwnership{Owned: true, Claim: true, Origin: constants.OwnershipOriginCreated}
 
-	_, organizationResponse, organizationError := quayClient.GetOrganizationByName(quayOrganizationName)
-	if organizationError.Error != nil {
-		return r.CoreComponents.ManageError(&core.QuayIntegrationCoreError{
-			Object:       namespace,
-			Message:      "Error occurred retrieving Organization",
-			KeyAndValues: []interface{}{"Quay Organization", quayOrganizationName, "Status Code", organizationResponse.StatusCode},
-			Error:        organizationError.Error,
-		})
-	}
+	var ownershipRobotAccount *qclient.RobotAccount
 
-	// Check to see if Organization Exists (Response Code)
-	if organizationResponse.StatusCode == 404 {
-		return reconcile.Result{}, nil
-		// Organization is not present
-	} else if organizationResponse.StatusCode == 200 {
-		organizationDeleteResponse, organizationDeleteError := quayClient.DeleteOrganization(quayOrganizationName)
-		if organizationDeleteResponse == nil {
-			return r.CoreComponents.ManageError(&core.QuayIntegrationCoreError{
+	if !created {
+		robotAccount, robotAccountResponse, robotAccountErr := quayClient.GetOrganizationRobotAccount(quayOrganizationName, constants.OwnershipRobotAccountName)
+		if robotAccountErr.Error != nil || (robotAccountResponse.StatusCode != 200 && robotAccountResponse.StatusCode != 400 && robotAccountResponse.StatusCode != 404) {
+			result, err := r.CoreComponents.ManageError(&core.QuayIntegrationCoreError{
 				Object:       namespace,
-				Message:      "Error occurred building HTTP request to delete Organization",
-				KeyAndValues: []interface{}{"Quay Organization", quayOrganizationName},
-				Error:        organizationDeleteError.Error,
+				Message:      "Error occurred retrieving Quay Organization ownership",
+				KeyAndValues: []interface{}{"Organization", quayOrganizationName},
+				Error:        robotAccountErr.Error,
 			})
+			return ownership, result, err
 		}
 
-		if organizationDeleteError.Error != nil {
-			return r.CoreComponents.ManageError(&core.QuayIntegrationCoreError{
-				Object:       namespace,
-				Message:      "Error occurred deleting Organization",
-				KeyAndValues: []interface{}{"Quay Organization", quayOrganizationName, "Status Code", organizationDeleteResponse.StatusCode},
-				Error:        organizationDeleteError.Error,
-			})
+		// Robot accounts that do not exist are reported as 400
+		if robotAccountResponse.StatusCode == 200 {
+			ownershipRobotAccount = &robotAccount
 		}
 
-		if organizationDeleteResponse.StatusCode != 204 {
-			return r.CoreComponents.ManageError(&core.QuayIntegrationCoreError{
+		// Namespaces with the credentials of the builder robot account were managed by this cluster before ownership was recorded
+		previouslyManaged := false
+		if ownershipRobotAccount == nil {
+			builderSecret := &corev1.Secret{}
+			err := r.CoreComponents.ReconcilerBase.GetClient().Get(ctx, types.NamespacedName{Namespace: namespace.Name, Name: utils.GenerateDockerJsonSecretNameForServiceAccount(string(qotypes.BuilderOpenShiftServiceAccount), quayIntegration.Spec.ClusterID)}, builderSecret)
+			if err != nil && !errors.IsNotFound(err) {
+				result, err := r.CoreComponents.ManageError(&core.QuayIntegrationCoreError{
+					Object:       namespace,
+					Message:      "Error occurred retrieving builder robot account Secret",
+					KeyAndValues: []interface{}{"Namespace", namespace.Name},
+					Error:        err,
+				})
+				return ownership, result, err
+			}
+			previouslyManaged = err == nil
+		}
+
+		adopt, adoptErr := utils.IsAdoptionRequested(namespace)
+		if adoptErr != nil {
+			result, err := r.CoreComponents.ManageError(&core.QuayIntegrationCoreError{
 				Object:       namespace,
-				Message:      "Error occurred deleting Organization",
-				KeyAndValues: []interface{}{"Quay Organization", quayOrganizationName, "Status Code", organizationDeleteResponse.StatusCode},
+				Message:      "Invalid adopt annotation for Namespace",
+				KeyAndValues: []interface{}{"Namespace", namespace.Name},
+				Reason:       "ConfigurationError",
+				Error:        adoptErr,
 			})
+			return ownership, result, err
 		}
-		return reconcile.Result{}, nil
 
-	} else {
-		return r.CoreComponents.ManageError(&core.QuayIntegrationCoreError{
-			Object:       namespace,
-			Message:      "Error occurred retrieving Organization",
-			KeyAndValues: []interface{}{"Quay Organization", quayOrganizationName, "Status Code", organizationResponse.StatusCode},
-		})
+		ownership = utils.GetOrganizationOwnership(ownershipRobotAccount, quayIntegration.Spec.ClusterID, previouslyManaged, adopt)
 	}
-}
 
-func (r *NamespaceIntegrationReconciler) updateSecretWithMountablePullSecret(serviceAccount *corev1.ServiceAccount, name string) (*corev1.ServiceAccount, bool) {
-	var updated bool
+	if !ownership.Owned {
+		logging.Log.Info("Quay Organization is not managed by the operator", "Organization", quayOrganizationName, "Owner", ownership.Owner)
+		result, err := r.updateNamespaceCondition(ctx, namespace, utils.GetOwnershipCondition(quayOrganizationName, ownership, nil), "Error occurred updating Namespace ownership status")
+		return ownership, result, err
+	}
 
-	if found := utils.LocalObjectReferenceNameExists(serviceAccount.ImagePullSecrets, name); !found {
-		serviceAccount.ImagePullSecrets = append(serviceAccount.ImagePullSecrets, corev1.LocalObjectReference{Name: name})
+	if !ownership.Claim {
+		return ownership, reconcile.Result{}, nil
+	}
+
+	// The ownership robot account of another cluster is replaced
+	if ownershipRobotAccount != nil {
+		deleteResponse, deleteErr := quayClient.DeleteOrganizationRobotAccount(quayOrganizationName, constants.OwnershipRobotAccountName)
+		if deleteErr.Error != nil || deleteResponse.StatusCode != 204 {
+			result, err := r.CoreComponents.ManageError(&core.QuayIntegrationCoreError{
+				Object:       namespace,
+				Message:      "Error occurred deleting Quay Organization ownership of another cluster",
+				KeyAndValues: []interface{}{"Organization", quayOrganizationName, "Owner", ownership.Owner},
+				Error:        deleteErr.Error,
+			})
+			return ownership, result, err
+		}
+	}
+
+	logging.Log.Info("Claiming Quay Organization", "Organization", quayOrganizationName, "Origin", ownership.Origin)
+	_, claimResponse, claimErr := quayClient.CreateOrganizationRobotAccountWithMetadata(quayOrganizationName, constants.OwnershipRobotAccountName, utils.GetOwnershipRobotAccountRequest(quayIntegration.Spec.ClusterID, namespace.Name, ownership.Origin))
+	if claimErr.Error != nil || (claimResponse.StatusCode != 200 && claimResponse.StatusCode != 201) {
+		result, err := r.CoreComponents.ManageError(&core.QuayIntegrationCoreError{
+			Object:       namespace,
+			Message:      "Error occurred recording Quay Organization ownership",
+			KeyAndValues: []interface{}{"Organization", quayOrganizationName},
+			Error:        claimErr.Error,
+		})
+		return ownership, result, err
+	}
+
+	if ownership.Origin == constants.OwnershipOriginAdopted {
+		r.CoreComponents.ReconcilerBase.GetRecorder().Event(namespace, "Normal", "OrganizationAdopted", fmt.Sprintf("Adopted Quay organization %s", quayOrganizationName))
+	}
+
+	ownership.Claim = false
+	ownership.Owner = quayIntegration.Spec.ClusterID
+
+	return ownership, reconcile.Result{}, nil
+}
+
+// syncRepositoryOwnership determines whether the operator may manage the Quay repository of an ImageStream
+func (r *NamespaceIntegrationReconciler) syncRepositoryOwnership(ctx context.Context, namespace *corev1.Namespace, quayClient *qclient.Client, quayOrganizationName string, quayIntegration *quayv1.QuayIntegration, ownership qotypes.OrganizationOwnership, imageStream *imagev1.ImageStream, repositoryName string) (bool, reconcile.Result, error) {
+	if imageStream.Annotations[constants.RepositoryOwnerAnnotation] == quayIntegration.Spec.ClusterID {
+		return true, reconcile.Result{}, nil
+	}
+
+	repositoryExists := false
+
+	// Every repository of an organization created by the operator is managed
+	if ownership.Origin != constants.OwnershipOriginCreated {
+		_, repositoryResponse, repositoryErr := quayClient.GetRepository(quayOrganizationName, repositoryName)
+		if repositoryErr.Error != nil || (repositoryResponse.StatusCode != 200 && repositoryResponse.StatusCode != 403 && repositoryResponse.StatusCode != 404) {
+			result, err := r.CoreComponents.ManageError(&core.QuayIntegrationCoreError{
+				Object:       namespace,
+				Message:      "Error Retrieving Repository",
+				KeyAndValues: []interface{}{"Quay Repository", fmt.Sprintf("%s/%s", quayOrganizationName, repositoryName)},
+				Error:        repositoryErr.Error,
+			})
+			return false, result, err
+		}
+
+		repositoryExists = repositoryResponse.StatusCode == 200
+	}
+
+	if repositoryExists {
+		adopt, adoptErr := utils.IsAdoptionRequested(imageStream, namespace)
+		if adoptErr != nil {
+			result, err := r.CoreComponents.ManageError(&core.QuayIntegrationCoreError{
+				Object:       imageStream,
+				Message:      "Invalid adopt annotation for ImageStream",
+				KeyAndValues: []interface{}{"Namespace", namespace.Name, "Name", imageStream.Name},
+				Reason:       "ConfigurationError",
+				Error:        adoptErr,
+			})
+			return false, result, err
+		}
+
+		if !adopt {
+			logging.Log.Info("Quay Repository is not managed by the operator", "Organization", quayOrganizationName, "Name", repositoryName)
+			r.CoreComponents.ReconcilerBase.GetRecorder().Event(imageStream, "Warning", "RepositoryConflict", fmt.Sprintf("Quay repository %s/%s was not created by the operator. Set the %s annotation to adopt it", quayOrganizationName, repositoryName, constants.AdoptAnnotation))
+			return false, reconcile.Result{}, nil
+		}
+
+		r.CoreComponents.ReconcilerBase.GetRecorder().Event(imageStream, "Normal", "RepositoryAdopted", fmt.Sprintf("Adopted Quay repository %s/%s", quayOrganizationName, repositoryName))
+	}
+
+	if imageStream.Annotations == nil {
+		imageStream.Annotations = map[string]string{}
+	}
+	imageStream.Annotations[constants.RepositoryOwnerAnnotation] = quayIntegration.Spec.ClusterID
+
+	if err := r.CoreComponents.ReconcilerBase.GetClient().Update(ctx, imageStream); err != nil {
+		result, err := r.CoreComponents.ManageError(&core.QuayIntegrationCoreError{
+			Object:       imageStream,
+			Message:      "Error occurred recording Quay Repository ownership on ImageStream",
+			KeyAndValues: []interface{}{"Namespace", namespace.Name, "Name", imageStream.Name},
+			Error:        err,
+		})
+		return false, result, err
+	}
+
+	return true, reconcile.Result{}, nil
+}
+
+// isOrganizationOwned determines whether the ownership robot account of an organization records this cluster as its owner
+func isOrganizationOwned(quayClient *qclient.Client, quayOrganizationName string, clusterID string) (bool, error) {
+	robotAccount, robotAccountResponse, robotAccountErr := quayClient.GetOrganizationRobotAccount(quayOrganizationName, constants.OwnershipRobotAccountName)
+	if robotAccountErr.Error != nil {
+		return false, fmt.Errorf("failed to retrieve ownership of Quay organization %s: %w", quayOrganizationName, robotAccountErr.Error)
+	}
+
+	switch robotAccountResponse.StatusCode {
+	case 200:
+		return utils.GetOrganizationOwnership(&robotAccount, clusterID, false, false).Owned, nil
+	case 400, 404:
+		return false, nil
+	default:
+		return false, fmt.Errorf("failed to retrieve ownership of Quay organization %s: status code %d", quayOrganizationName, robotAccountResponse.StatusCode)
+	}
+}
+
+// syncRepository creates the Quay repository for an ImageStream and reconciles its settings
+func (r *NamespaceIntegrationReconciler) syncRepository(ctx context.Context, namespace *corev1.Namespace, quayClient *qclient.Client, quayOrganizationName string, quayIntegration *quayv1.QuayIntegration, imageStream *imagev1.ImageStream, repositoryName string) (reconcile.Result, error) {
+	imageStreamName := imageStream.Name
+
+	repositorySettings, repositorySettingsErr := utils.GetRepositorySettings(quayIntegration, namespace, imageStream)
+	if repositorySettingsErr != nil {
+		return r.CoreComponents.ManageError(&core.QuayIntegrationCoreError{
+			Object:       imageStream,
+			Message:      "Invalid Repository settings for ImageStream",
+			KeyAndValues: []interface{}{"Namespace", namespace.Name, "Name", imageStreamName},
+			Reason:       "ConfigurationError",
+			Error:        repositorySettingsErr,
+		})
+	}
+
+	// Check if Repository Exists
+	repository, repositoryHttpResponse, repositoryErr := quayClient.GetRepository(quayOrganizationName, repositoryName)
+	if repositoryHttpResponse == nil {
+		return r.CoreComponents.ManageError(&core.QuayIntegrationCoreError{
+			Object:       namespace,
+			Message:      "Error creating request to retrieve repository",
+			KeyAndValues: []interface{}{"Namespace", namespace.Name, "Name", imageStreamName},
+			Error:        repositoryErr.Error,
+		})
+	}
+
+	if repositoryErr.Error != nil {
+		return r.CoreComponents.ManageError(&core.QuayIntegrationCoreError{
+			Object:       namespace,
+			Message:      "Error Retrieving Repository",
+			KeyAndValues: []interface{}{"Namespace", namespace.Name, "Name", imageStreamName, "Status Code", repositoryHttpResponse.StatusCode},
+			Error:        repositoryErr.Error,
+		})
+	}
+
+	// If an Repository reports back that it cannot be found or permission dened
+	if repositoryHttpResponse.StatusCode == 403 || repositoryHttpResponse.StatusCode == 404 {
+		logging.Log.Info("Creating Repository", "Organization", quayOrganizationName, "Name", repositoryName)
+		_, createRepositoryResponse, createRepositoryErr := quayClient.CreateRepository(quayOrganizationName, repositoryName, repositorySettings.Visibility, repositorySettings.Description, repositorySettings.Kind)
+		if createRepositoryErr.Error != nil || createRepositoryResponse.StatusCode != 201 {
+			return r.CoreComponents.ManageError(&core.QuayIntegrationCoreError{
+				Object:       namespace,
+				Message:      "Error occurred creating Quay Repository",
+				KeyAndValues: []interface{}{"Quay Repository", fmt.Sprintf("%s/%s", quayOrganizationName, repositoryName), "Status Code", createRepositoryResponse.StatusCode},
+				Error:        createRepositoryErr.Error,
+			})
+		}
+
+		return r.syncTagRetention(ctx, namespace, quayClient, quayOrganizationName, quayIntegration, imageStream, repositoryName)
+	} else if repositoryHttpResponse.StatusCode != 200 {
+		return r.CoreComponents.ManageError(&core.QuayIntegrationCoreError{
+			Object:       namespace,
+			Message:      "Error Retrieving Repository for Namespace",
+			KeyAndValues: []interface{}{"Quay Repository", fmt.Sprintf("%s/%s", quayOrganizationName, repositoryName), "Status Code", repositoryHttpResponse.StatusCode},
+		})
+	}
+
+	// Reconcile the settings of the existing Repository
+	if repository.IsPublic != (repositorySettings.Visibility == qclient.RepositoryVisibilityPublic) {
+		logging.Log.Info("Updating Repository visibility", "Organization", quayOrganizationName, "Name", repositoryName, "Visibility", repositorySettings.Visibility)
+		visibilityResponse, visibilityErr := quayClient.ChangeRepositoryVisibility(quayOrganizationName, repositoryName, repositorySettings.Visibility)
+		if visibilityErr.Error != nil || visibilityResponse.StatusCode != 200 {
+			return r.CoreComponents.ManageError(&core.QuayIntegrationCoreError{
+				Object:       namespace,
+				Message:      "Error occurred updating Quay Repository visibility",
+				KeyAndValues: []interface{}{"Quay Repository", fmt.Sprintf("%s/%s", quayOrganizationName, repositoryName), "Visibility", repositorySettings.Visibility},
+				Error:        visibilityErr.Error,
+			})
+		}
+	}
+
+	if repository.Description != repositorySettings.Description {
+		logging.Log.Info("Updating Repository description", "Organization", quayOrganizationName, "Name", repositoryName)
+		updateResponse, updateErr := quayClient.UpdateRepository(quayOrganizationName, repositoryName, repositorySettings.Description)
+		if updateErr.Error != nil || updateResponse.StatusCode != 200 {
+			return r.CoreComponents.ManageError(&core.QuayIntegrationCoreError{
+				Object:       namespace,
+				Message:      "Error occurred updating Quay Repository description",
+				KeyAndValues: []interface{}{"Quay Repository", fmt.Sprintf("%s/%s", quayOrganizationName, repositoryName)},
+				Error:        updateErr.Error,
+			})
+		}
+	}
+
+	return r.syncTagRetention(ctx, namespace, quayClient, quayOrganizationName, quayIntegration, imageStream, repositoryName)
+}
+
+// syncTagRetention reconciles the auto-prune policy of the Quay repository for an ImageStream and records the effective retention on the ImageStream
+func (r *NamespaceIntegrationReconciler) syncTagRetention(ctx context.Context, namespace *corev1.Namespace, quayClient *qclient.Client, quayOrganizationName string, quayIntegration *quayv1.QuayIntegration, imageStream *imagev1.ImageStream, repositoryName string) (reconcile.Result, error) {
+	imageStreamName := imageStream.Name
+
+	tagRetention, tagRetentionErr := utils.GetTagRetentionSettings(quayIntegration, namespace, imageStream)
+	if tagRetentionErr != nil {
+		return r.CoreComponents.ManageError(&core.QuayIntegrationCoreError{
+			Object:       imageStream,
+			Message:      "Invalid Tag Retention settings for ImageStream",
+			KeyAndValues: []interface{}{"Namespace", namespace.Name, "Name", imageStreamName},
+			Reason:       "ConfigurationError",
+			Error:        tagRetentionErr,
+		})
+	}
+
+	var desiredPolicy *qclient.AutoPrunePolicy
+	if tagRetention.KeepLastTags > 0 {
+		desiredPolicy = &qclient.AutoPrunePolicy{Method: qclient.AutoPruneMethodNumberOfTags, Value: intstr.FromInt(tagRetention.KeepLastTags)}
+	} else if tagRetention.MaxTagAge != "" {
+		desiredPolicy = &qclient.AutoPrunePolicy{Method: qclient.AutoPruneMethodCreationDate, Value: intstr.FromString(tagRetention.MaxTagAge)}
+	}
+
+	// Only the policy recorded on the ImageStream was created by the operator, policies created in Quay are left untouched
+	policyUUID := imageStream.Annotations[constants.TagRetentionPolicyAnnotation]
+
+	if desiredPolicy != nil || policyUUID != "" {
+		policies, policiesResponse, policiesErr := quayClient.GetRepositoryAutoPrunePolicies(quayOrganizationName, repositoryName)
+		if policiesErr.Error == nil && policiesResponse.StatusCode == 404 {
+			logging.Log.Info("Quay does not support Repository auto-prune policies", "Organization", quayOrganizationName, "Name", repositoryName)
+			return reconcile.Result{}, nil
+		}
+
+		if policiesErr.Error != nil || policiesResponse.StatusCode != 200 {
+			return r.CoreComponents.ManageError(&core.QuayIntegrationCoreError{
+				Object:       namespace,
+				Message:      "Error occurred retrieving Quay Repository auto-prune policies",
+				KeyAndValues: []interface{}{"Quay Repository", fmt.Sprintf("%s/%s", quayOrganizationName, repositoryName)},
+				Error:        policiesErr.Error,
+			})
+		}
+
+		var recordedPolicy *qclient.AutoPrunePolicy
+		for i := range policies.Policies {
+			if policyUUID != "" && policies.Policies[i].UUID == policyUUID {
+				recordedPolicy = &policies.Policies[i]
+			}
+		}
+
+		if recordedPolicy == nil {
+			policyUUID = ""
+		} else if desiredPolicy == nil || recordedPolicy.Method != desiredPolicy.Method || recordedPolicy.Value.String() != desiredPolicy.Value.String() {
+			logging.Log.Info("Deleting Repository auto-prune policy", "Organization", quayOrganizationName, "Name", repositoryName, "Method", recordedPolicy.Method, "Value", recordedPolicy.Value.String())
+			deleteResponse, deleteErr := quayClient.DeleteRepositoryAutoPrunePolicy(quayOrganizationName, repositoryName, policyUUID)
+			if deleteErr.Error != nil || deleteResponse.StatusCode != 200 {
+				return r.CoreComponents.ManageError(&core.QuayIntegrationCoreError{
+					Object:       namespace,
+					Message:      "Error occurred deleting Quay Repository auto-prune policy",
+					KeyAndValues: []interface{}{"Quay Repository", fmt.Sprintf("%s/%s", quayOrganizationName, repositoryName), "Policy", policyUUID},
+					Error:        deleteErr.Error,
+				})
+			}
+			policyUUID = ""
+		}
+
+		if desiredPolicy != nil && policyUUID == "" {
+			logging.Log.Info("Creating Repository auto-prune policy", "Organization", quayOrganizationName, "Name", repositoryName, "Method", desiredPolicy.Method, "Value", desiredPolicy.Value.String())
+			createdPolicy, createResponse, createErr := quayClient.CreateRepositoryAutoPrunePolicy(quayOrganizationName, repositoryName, *desiredPolicy)
+			if createErr.Error != nil || createResponse.StatusCode != 201 {
+				return r.CoreComponents.ManageError(&core.QuayIntegrationCoreError{
+					Object:       namespace,
+					Message:      "Error occurred creating Quay Repository auto-prune policy",
+					KeyAndValues: []interface{}{"Quay Repository", fmt.Sprintf("%s/%s", quayOrganizationName, repositoryName)},
+					Error:        createErr.Error,
+				})
+			}
+			policyUUID = createdPolicy.UUID
+		}
+	}
+
+	if imageStream.Annotations[constants.EffectiveTagRetentionAnnotation] != tagRetention.String() || imageStream.Annotations[constants.TagRetentionPolicyAnnotation] != policyUUID {
+		if imageStream.Annotations == nil {
+			imageStream.Annotations = map[string]string{}
+		}
+		imageStream.Annotations[constants.EffectiveTagRetentionAnnotation] = tagRetention.String()
+
+		if policyUUID != "" {
+			imageStream.Annotations[constants.TagRetentionPolicyAnnotation] = policyUUID
+		} else {
+			delete(imageStream.Annotations, constants.TagRetentionPolicyAnnotation)
+		}
+
+		if err := r.CoreComponents.ReconcilerBase.GetClient().Update(ctx, imageStream); err != nil {
+			return r.CoreComponents.ManageError(&core.QuayIntegrationCoreError{
+				Object:       imageStream,
+				Message:      "Error occurred recording effective Tag Retention on ImageStream",
+				KeyAndValues: []interface{}{"Namespace", namespace.Name, "Name", imageStreamName},
+				Error:        err,
+			})
+		}
+	}
+
+	return reconcile.Result{}, nil
+}
+
+// syncOrganizationQuota reconciles the storage quota of the Quay organization of a namespace and reports its usage on the Namespace status
+func (r *NamespaceIntegrationReconciler) syncOrganizationQuota(ctx context.Context, namespace *corev1.Namespace, quayClient *qclient.Client, quayOrganizationName string, quayIntegration *quayv1.QuayIntegration, organization qclient.Organization) (reconcile.Result, error) {
+	resourceQuotas := corev1.ResourceQuotaList{}
+
+	err := r.CoreComponents.ReconcilerBase.GetClient().List(ctx, &resourceQuotas, &client.ListOptions{Namespace: namespace.Name})
+	if err != nil {
+		return r.CoreComponents.ManageError(&core.QuayIntegrationCoreError{
+			Object:       namespace,
+			Message:      "Error occurred retrieving ResourceQuotas",
+			KeyAndValues: []interface{}{"Namespace", namespace.Name},
+			Error:        err,
+		})
+	}
+
+	quotaSettings, quotaFound, quotaErr := utils.GetOrganizationQuotaSettings(quayIntegration, namespace, resourceQuotas.Items)
+	if quotaErr != nil {
+		return r.CoreComponents.ManageError(&core.QuayIntegrationCoreError{
+			Object:       namespace,
+			Message:      "Invalid Organization Quota for Namespace",
+			KeyAndValues: []interface{}{"Namespace", namespace.Name},
+			Reason:       "ConfigurationError",
+			Error:        quotaErr,
+		})
+	}
+
+	// Organizations are left untouched unless a quota has been requested
+	if !quotaFound {
+		return reconcile.Result{}, nil
+	}
+
+	quotas, quotasResponse, quotasErr := quayClient.GetOrganizationQuotas(quayOrganizationName)
+	if quotasErr.Error != nil || quotasResponse.StatusCode != 200 {
+		return r.CoreComponents.ManageError(&core.QuayIntegrationCoreError{
+			Object:       namespace,
+			Message:      "Error occurred retrieving Quay Organization quota",
+			KeyAndValues: []interface{}{"Organization", quayOrganizationName},
+			Error:        quotasErr.Error,
+		})
+	}
+
+	if quotaSettings.LimitBytes == 0 {
+		for _, quota := range quotas {
+			logging.Log.Info("Deleting Organization quota", "Organization", quayOrganizationName, "Quota", quota.ID)
+			deleteResponse, deleteErr := quayClient.DeleteOrganizationQuota(quayOrganizationName, quota.ID)
+			if deleteErr.Error != nil || (deleteResponse.StatusCode != 200 && deleteResponse.StatusCode != 204) {
+				return r.CoreComponents.ManageError(&core.QuayIntegrationCoreError{
+					Object:       namespace,
+					Message:      "Error occurred deleting Quay Organization quota",
+					KeyAndValues: []interface{}{"Organization", quayOrganizationName, "Quota", quota.ID},
+					Error:        deleteErr.Error,
+				})
+			}
+		}
+	} else {
+		if len(quotas) == 0 {
+			logging.Log.Info("Creating Organization quota", "Organization", quayOrganizationName, "Limit Bytes", quotaSettings.LimitBytes)
+			createResponse, createErr := quayClient.CreateOrganizationQuota(quayOrganizationName, quotaSettings.LimitBytes)
+			if createErr.Error != nil || createResponse.StatusCode != 201 {
+				return r.CoreComponents.ManageError(&core.QuayIntegrationCoreError{
+					Object:       namespace,
+					Message:      "Error occurred creating Quay Organization quota",
+					KeyAndValues: []interface{}{"Organization", quayOrganizationName},
+					Error:        createErr.Error,
+				})
+			}
+
+			// Retrieve the created quota to obtain its identifier
+			quotas, quotasResponse, quotasErr = quayClient.GetOrganizationQuotas(quayOrganizationName)
+			if quotasErr.Error != nil || quotasResponse.StatusCode != 200 || len(quotas) == 0 {
+				return r.CoreComponents.ManageError(&core.QuayIntegrationCoreError{
+					Object:       namespace,
+					Message:      "Error occurred retrieving Quay Organization quota",
+					KeyAndValues: []interface{}{"Organization", quayOrganizationName},
+					Error:        quotasErr.Error,
+				})
+			}
+		} else if quotas[0].LimitBytes != quotaSettings.LimitBytes {
+			logging.Log.Info("Updating Organization quota", "Organization", quayOrganizationName, "Limit Bytes", quotaSettings.LimitBytes)
+			updateResponse, updateErr := quayClient.UpdateOrganizationQuota(quayOrganizationName, quotas[0].ID, quotaSettings.LimitBytes)
+			if updateErr.Error != nil || updateResponse.StatusCode != 200 {
+				return r.CoreComponents.ManageError(&core.QuayIntegrationCoreError{
+					Object:       namespace,
+					Message:      "Error occurred updating Quay Organization quota",
+					KeyAndValues: []interface{}{"Organization", quayOrganizationName, "Quota", quotas[0].ID},
+					Error:        updateErr.Error,
+				})
+			}
+		}
+
+		if result, err := r.syncOrganizationQuotaLimits(namespace, quayClient, quayOrganizationName, quotas[0], quotaSettings); err != nil || result.Requeue {
+			return result, err
+		}
+	}
+
+	// Report the usage of the organization against its quota
+	var usageBytes int64
+	if organization.QuotaReport != nil {
+		usageBytes = organization.QuotaReport.QuotaBytes
+	}
+
+	return r.updateNamespaceCondition(ctx, namespace, utils.GetOrganizationQuotaCondition(quayOrganizationName, quotaSettings, usageBytes), "Error occurred updating Namespace quota status")
+}
+
+// updateNamespaceCondition records a condition on the Namespace status, emitting a warning event when the condition becomes true or its reason changes
+func (r *NamespaceIntegrationReconciler) updateNamespaceCondition(ctx context.Context, namespace *corev1.Namespace, condition corev1.NamespaceCondition, errorMessage string) (reconcile.Result, error) {
+	var existingCondition *corev1.NamespaceCondition
+	for i := range namespace.Status.Conditions {
+		if namespace.Status.Conditions[i].Type == condition.Type {
+			existingCondition = &namespace.Status.Conditions[i]
+		}
+	}
+
+	if existingCondition != nil && existingCondition.Status == condition.Status && existingCondition.Reason == condition.Reason && existingCondition.Message == condition.Message {
+		return reconcile.Result{}, nil
+	}
+
+	if condition.Status == corev1.ConditionTrue && (existingCondition == nil || existingCondition.Reason != condition.Reason) {
+		r.CoreComponents.ReconcilerBase.GetRecorder().Event(namespace, "Warning", condition.Reason, condition.Message)
+	}
+
+	condition.LastTransitionTime = metav1.Now()
+	if existingCondition != nil {
+		if existingCondition.Status == condition.Status {
+			condition.LastTransitionTime = existingCondition.LastTransitionTime
+		}
+		*existingCondition = condition
+	} else {
+		namespace.Status.Conditions = append(namespace.Status.Conditions, condition)
+	}
+
+	if err := r.CoreComponents.ReconcilerBase.GetClient().Status().Update(ctx, namespace); err != nil {
+		return r.CoreComponents.ManageError(&core.QuayIntegrationCoreError{
+			Object:       namespace,
+			Message:      errorMessage,
+			KeyAndValues: []interface{}{"Namespace", namespace.Name},
+			Error:        err,
+		})
+	}
+
+	return reconcile.Result{}, nil
+}
+
+// syncOrganizationQuotaLimits reconciles the warning (soft) and reject (hard) limits of an organization quota
+func (r *NamespaceIntegrationReconciler) syncOrganizationQuotaLimits(namespace *corev1.Namespace, quayClient *qclient.Client, quayOrganizationName string, quota qclient.OrganizationQuota, quotaSettings qotypes.OrganizationQuotaSettings) (reconcile.Result, error) {
+
+	desiredLimits := map[string]int{
+		qclient.QuotaLimitTypeReject: quotaSettings.RejectThresholdPercent,
+	}
+
+	if quotaSettings.WarningThresholdPercent > 0 {
+		desiredLimits[qclient.QuotaLimitTypeWarning] = quotaSettings.WarningThresholdPercent
+	}
+
+	for _, limit := range quota.Limits {
+		thresholdPercent, found := desiredLimits[limit.Type]
+
+		if !found {
+			logging.Log.Info("Deleting Organization quota limit", "Organization", quayOrganizationName, "Type", limit.Type)
+			deleteResponse, deleteErr := quayClient.DeleteOrganizationQuotaLimit(quayOrganizationName, quota.ID, limit.ID)
+			if deleteErr.Error != nil || (deleteResponse.StatusCode != 200 && deleteResponse.StatusCode != 204) {
+				return r.CoreComponents.ManageError(&core.QuayIntegrationCoreError{
+					Object:       namespace,
+					Message:      "Error occurred deleting Quay Organization quota limit",
+					KeyAndValues: []interface{}{"Organization", quayOrganizationName, "Type", limit.Type},
+					Error:        deleteErr.Error,
+				})
+			}
+			continue
+		}
+
+		delete(desiredLimits, limit.Type)
+
+		if limit.LimitPercent != thresholdPercent {
+			logging.Log.Info("Updating Organization quota limit", "Organization", quayOrganizationName, "Type", limit.Type, "Threshold Percent", thresholdPercent)
+			updateResponse, updateErr := quayClient.UpdateOrganizationQuotaLimit(quayOrganizationName, quota.ID, limit.ID, limit.Type, thresholdPercent)
+			if updateErr.Error != nil || updateResponse.StatusCode != 200 {
+				return r.CoreComponents.ManageError(&core.QuayIntegrationCoreError{
+					Object:       namespace,
+					Message:      "Error occurred updating Quay Organization quota limit",
+					KeyAndValues: []interface{}{"Organization", quayOrganizationName, "Type", limit.Type},
+					Error:        updateErr.Error,
+				})
+			}
+		}
+	}
+
+	for limitType, thresholdPercent := range desiredLimits {
+		logging.Log.Info("Creating Organization quota limit", "Organization", quayOrganizationName, "Type", limitType, "Threshold Percent", thresholdPercent)
+		createResponse, createErr := quayClient.CreateOrganizationQuotaLimit(quayOrganizationName, quota.ID, limitType, thresholdPercent)
+		if createErr.Error != nil || createResponse.StatusCode != 201 {
+			return r.CoreComponents.ManageError(&core.QuayIntegrationCoreError{
+				Object:       namespace,
+				Message:      "Error occurred creating Quay Organization quota limit",
+				KeyAndValues: []interface{}{"Organization", quayOrganizationName, "Type", limitType},
+				Error:        createErr.Error,
+			})
+		}
+	}
+
+	return reconcile.Result{}, nil
+}
+
+// syncUntaggedExpiration reconciles how long untagged images are retained in the Quay organization of a namespace
+func (r *NamespaceIntegrationReconciler) syncUntaggedExpiration(namespace *corev1.Namespace, quayClient *qclient.Client, quayOrganizationName string, quayIntegration *quayv1.QuayIntegration, organization qclient.Organization) (reconcile.Result, error) {
+
+	tagExpiration, tagExpirationFound, tagExpirationErr := utils.GetUntaggedExpiration(quayIntegration, namespace)
+	if tagExpirationErr != nil {
+		return r.CoreComponents.ManageError(&core.QuayIntegrationCoreError{
+			Object:       namespace,
+			Message:      "Invalid Untagged Expiration for Namespace",
+			KeyAndValues: []interface{}{"Namespace", namespace.Name},
+			Reason:       "ConfigurationError",
+			Error:        tagExpirationErr,
+		})
+	}
+
+	if !tagExpirationFound || organization.TagExpirationS == tagExpiration {
+		return reconcile.Result{}, nil
+	}
+
+	logging.Log.Info("Updating Organization tag expiration", "Organization", quayOrganizationName, "Seconds", tagExpiration)
+	updateResponse, updateErr := quayClient.UpdateOrganization(quayOrganizationName, qclient.OrganizationUpdateRequest{TagExpirationS: &tagExpiration})
+	if updateErr.Error != nil || updateResponse.StatusCode != 200 {
+		return r.CoreComponents.ManageError(&core.QuayIntegrationCoreError{
+			Object:       namespace,
+			Message:      "Error occurred updating Quay Organization tag expiration",
+			KeyAndValues: []interface{}{"Organization", quayOrganizationName},
+			Error:        updateErr.Error,
+		})
+	}
+
+	return reconcile.Result{}, nil
+}
+
+// syncOrganizationContact updates the email and invoice email address of an existing organization when they differ from the contact settings
+func (r *NamespaceIntegrationReconciler) syncOrganizationContact(namespace *corev1.Namespace, quayClient *qclient.Client, quayOrganizationName string, contactSettings qotypes.OrganizationContactSettings, organization qclient.Organization) (reconcile.Result, error) {
+
+	organizationUpdate := qclient.OrganizationUpdateRequest{}
+	updateRequired := false
+
+	if contactSettings.Email != "" && organization.Email != contactSettings.Email {
+		organizationUpdate.Email = &contactSettings.Email
+		updateRequired = true
+	}
+
+	invoiceEmail := contactSettings.InvoiceEmailAddress != ""
+	if organization.InvoiceEmail != invoiceEmail || organization.InvoiceEmailAddress != contactSettings.InvoiceEmailAddress {
+		organizationUpdate.InvoiceEmail = &invoiceEmail
+		organizationUpdate.InvoiceEmailAddress = &contactSettings.InvoiceEmailAddress
+		updateRequired = true
+	}
+
+	if !updateRequired {
+		return reconcile.Result{}, nil
+	}
+
+	logging.Log.Info("Updating Organization contact", "Organization", quayOrganizationName, "Email", contactSettings.Email, "Invoice Email", contactSettings.InvoiceEmailAddress)
+	updateResponse, updateErr := quayClient.UpdateOrganization(quayOrganizationName, organizationUpdate)
+	if updateErr.Error != nil || updateResponse.StatusCode != 200 {
+		return r.CoreComponents.ManageError(&core.QuayIntegrationCoreError{
+			Object:       namespace,
+			Message:      "Error occurred updating Quay Organization contact",
+			KeyAndValues: []interface{}{"Organization", quayOrganizationName},
+			Error:        updateErr.Error,
+		})
+	}
+
+	return reconcile.Result{}, nil
+}
+
+// syncTeams synchronizes the users bound to the admin, edit and view roles of a namespace to the Quay teams of its organization
+func (r *NamespaceIntegrationReconciler) syncTeams(ctx context.Context, namespace *corev1.Namespace, quayClient *qclient.Client, quayOrganizationName string, quayIntegration *quayv1.QuayIntegration) (reconcile.Result, error) {
+	if quayIntegration.Spec.TeamSync == nil {
+		return reconcile.Result{}, nil
+	}
+
+	roleBindings := rbacv1.RoleBindingList{}
+
+	err := r.CoreComponents.ReconcilerBase.GetClient().List(ctx, &roleBindings, &client.ListOptions{Namespace: namespace.Name})
+	if err != nil {
+		return r.CoreComponents.ManageError(&core.QuayIntegrationCoreError{
+			Object:       namespace,
+			Message:      "Error occurred retrieving RoleBindings",
+			KeyAndValues: []interface{}{"Namespace", namespace.Name},
+			Error:        err,
+		})
+	}
+
+	teamMembers, teamMembersErr := utils.GetQuayTeamMembers(quayIntegration.Spec.TeamSync, roleBindings.Items)
+	if teamMembersErr != nil {
+		return r.CoreComponents.ManageError(&core.QuayIntegrationCoreError{
+			Object:       namespace,
+			Message:      "Invalid Team Sync configuration",
+			KeyAndValues: []interface{}{"Namespace", namespace.Name},
+			Reason:       "ConfigurationError",
+			Error:        teamMembersErr,
+		})
+	}
+
+	managedMembersUpdated := false
+
+	for quayTeam, quayTeamRoles := range QuayTeamRoleMatrix {
+		teamName := string(quayTeam)
+
+		// Only members added by the operator are removed, leaving those added directly in Quay in place
+		previousMembers := utils.GetManagedTeamMembers(namespace, quayTeam)
+		managedMembers := []string{}
+
+		_, teamResponse, teamErr := quayClient.CreateOrUpdateTeam(quayOrganizationName, teamName, quayTeamRoles.TeamRole, fmt.Sprintf("Members of the %s team of namespace %s", teamName, namespace.Name))
+		if teamErr.Error != nil || teamResponse.StatusCode != 200 {
+			return r.CoreComponents.ManageError(&core.QuayIntegrationCoreError{
+				Object:       namespace,
+				Message:      "Error occurred creating Quay Team",
+				KeyAndValues: []interface{}{"Organization", quayOrganizationName, "Team", teamName},
+				Error:        teamErr.Error,
+			})
+		}
+
+		members, membersResponse, membersErr := quayClient.GetTeamMembers(quayOrganizationName, teamName)
+		if membersErr.Error != nil || membersResponse.StatusCode != 200 {
+			return r.CoreComponents.ManageError(&core.QuayIntegrationCoreError{
+				Object:       namespace,
+				Message:      "Error occurred retrieving Quay Team members",
+				KeyAndValues: []interface{}{"Organization", quayOrganizationName, "Team", teamName},
+				Error:        membersErr.Error,
+			})
+		}
+
+		existingMembers := []string{}
+		for _, member := range members.Members {
+			if member.IsRobot {
+				continue
+			}
+
+			existingMembers = append(existingMembers, member.Name)
+
+			if slices.Contains(teamMembers[quayTeam], member.Name) {
+				managedMembers = append(managedMembers, member.Name)
+				continue
+			}
+
+			if !slices.Contains(previousMembers, member.Name) {
+				continue
+			}
+
+			logging.Log.Info("Removing Quay Team member", "Organization", quayOrganizationName, "Team", teamName, "Member", member.Name)
+			removeResponse, removeErr := quayClient.RemoveTeamMember(quayOrganizationName, teamName, member.Name)
+			if removeErr.Error != nil || (removeResponse.StatusCode != 200 && removeResponse.StatusCode != 204) {
+				return r.CoreComponents.ManageError(&core.QuayIntegrationCoreError{
+					Object:       namespace,
+					Message:      "Error occurred removing Quay Team member",
+					KeyAndValues: []interface{}{"Organization", quayOrganizationName, "Team", teamName, "Member", member.Name},
+					Error:        removeErr.Error,
+				})
+			}
+		}
+
+		for _, memberName := range teamMembers[quayTeam] {
+			if slices.Contains(existingMembers, memberName) {
+				continue
+			}
+
+			logging.Log.Info("Adding Quay Team member", "Organization", quayOrganizationName, "Team", teamName, "Member", memberName)
+			addResponse, addErr := quayClient.AddTeamMember(quayOrganizationName, teamName, memberName)
+			if addErr.Error != nil || addResponse == nil {
+				return r.CoreComponents.ManageError(&core.QuayIntegrationCoreError{
+					Object:       namespace,
+					Message:      "Error occurred adding Quay Team member",
+					KeyAndValues: []interface{}{"Organization", quayOrganizationName, "Team", teamName, "Member", memberName},
+					Error:        addErr.Error,
+				})
+			}
+
+			// Users which have not logged in to Quay do not exist and cannot be added until they do
+			if addResponse.StatusCode == 400 || addResponse.StatusCode == 404 {
+				r.CoreComponents.ReconcilerBase.GetRecorder().Event(namespace, "Warning", "QuayUserNotFound", fmt.Sprintf("Quay user %s could not be added to team %s of organization %s", memberName, teamName, quayOrganizationName))
+				continue
+			}
+
+			if addResponse.StatusCode != 200 {
+				return r.CoreComponents.ManageError(&core.QuayIntegrationCoreError{
+					Object:       namespace,
+					Message:      "Error occurred adding Quay Team member",
+					KeyAndValues: []interface{}{"Organization", quayOrganizationName, "Team", teamName, "Member", memberName, "Status Code", addResponse.StatusCode},
+				})
+			}
+
+			managedMembers = append(managedMembers, memberName)
+		}
+
+		if utils.SetManagedTeamMembers(namespace, quayTeam, managedMembers) {
+			managedMembersUpdated = true
+		}
+	}
+
+	if managedMembersUpdated {
+		if err := r.CoreComponents.ReconcilerBase.GetClient().Update(ctx, namespace); err != nil {
+			return r.CoreComponents.ManageError(&core.QuayIntegrationCoreError{
+				Object:       namespace,
+				Message:      "Unable to update namespace",
+				KeyAndValues: []interface{}{"Namespace", namespace.Name},
+				Error:        err,
+			})
+		}
+	}
+
+	return reconcile.Result{}, nil
+}
+
+// syncRepositoryTeamPermissions grants the Quay teams of an organization their role on a repository
+func (r *NamespaceIntegrationReconciler) syncRepositoryTeamPermissions(namespace *corev1.Namespace, quayClient *qclient.Client, quayOrganizationName string, quayIntegration *quayv1.QuayIntegration, repositoryName string) (reconcile.Result, error) {
+	if quayIntegration.Spec.TeamSync == nil {
+		return reconcile.Result{}, nil
+	}
+
+	permissions, permissionsResponse, permissionsErr := quayClient.GetRepositoryTeamPermissions(quayOrganizationName, repositoryName)
+	if permissionsErr.Error != nil || permissionsResponse.StatusCode != 200 {
+		return r.CoreComponents.ManageError(&core.QuayIntegrationCoreError{
+			Object:       namespace,
+			Message:      "Error occurred retrieving Quay Repository team permissions",
+			KeyAndValues: []interface{}{"Quay Repository", fmt.Sprintf("%s/%s", quayOrganizationName, repositoryName)},
+			Error:        permissionsErr.Error,
+		})
+	}
+
+	for quayTeam, quayTeamRoles := range QuayTeamRoleMatrix {
+		if quayTeamRoles.RepositoryRole == "" {
+			continue
+		}
+
+		teamName := string(quayTeam)
+
+		if permission, found := permissions.Permissions[teamName]; found && permission.Role == string(quayTeamRoles.RepositoryRole) {
+			continue
+		}
+
+		logging.Log.Info("Granting Quay Team Repository permission", "Quay Repository", fmt.Sprintf("%s/%s", quayOrganizationName, repositoryName), "Team", teamName, "Role", quayTeamRoles.RepositoryRole)
+		_, permissionResponse, permissionErr := quayClient.SetRepositoryTeamPermission(quayOrganizationName, repositoryName, teamName, string(quayTeamRoles.RepositoryRole))
+		if permissionErr.Error != nil || permissionResponse.StatusCode != 200 {
+			return r.CoreComponents.ManageError(&core.QuayIntegrationCoreError{
+				Object:       namespace,
+				Message:      "Error occurred granting Quay Team Repository permission",
+				KeyAndValues: []interface{}{"Quay Repository", fmt.Sprintf("%s/%s", quayOrganizationName, repositoryName), "Team", teamName},
+				Error:        permissionErr.Error,
+			})
+		}
+	}
+
+	return reconcile.Result{}, nil
+}
+
+// syncRepositoryRobotPermissions grants the robot accounts of a namespace targeting a shared organization their role on a repository of the namespace
+func (r *NamespaceIntegrationReconciler) syncRepositoryRobotPermissions(namespace *corev1.Namespace, quayClient *qclient.Client, target qotypes.OrganizationTarget, repositoryName string) (reconcile.Result, error) {
+	quayOrganizationName := target.Organization
+
+	permissions, permissionsResponse, permissionsErr := quayClient.GetRepositoryUserPermissions(quayOrganizationName, repositoryName)
+	if permissionsErr.Error != nil || permissionsResponse.StatusCode != 200 {
+		return r.CoreComponents.ManageError(&core.QuayIntegrationCoreError{
+			Object:       namespace,
+			Message:      "Error occurred retrieving Quay Repository permissions",
+			KeyAndValues: []interface{}{"Quay Repository", fmt.Sprintf("%s/%s", quayOrganizationName, repositoryName)},
+			Error:        permissionsErr.Error,
+		})
+	}
+
+	for serviceAccount, role := range QuayServiceAccountPermissionMatrix {
+		robotAccount := utils.FormatOrganizationRobotAccountName(quayOrganizationName, target.RobotAccountName(string(serviceAccount)))
+
+		if permission, found := permissions.Permissions[robotAccount]; found && permission.Role == string(role) {
+			continue
+		}
+
+		logging.Log.Info("Granting Robot Account Repository permission", "Quay Repository", fmt.Sprintf("%s/%s", quayOrganizationName, repositoryName), "Robot Account", robotAccount, "Role", role)
+		_, permissionResponse, permissionErr := quayClient.SetRepositoryUserPermission(quayOrganizationName, repositoryName, robotAccount, string(role))
+		if permissionErr.Error != nil || permissionResponse.StatusCode != 200 {
+			return r.CoreComponents.ManageError(&core.QuayIntegrationCoreError{
+				Object:       namespace,
+				Message:      "Error occurred granting Robot Account Repository permission",
+				KeyAndValues: []interface{}{"Quay Repository", fmt.Sprintf("%s/%s", quayOrganizationName, repositoryName), "Robot Account", robotAccount},
+				Error:        permissionErr.Error,
+			})
+		}
+	}
+
+	return reconcile.Result{}, nil
+}
+
+// syncRepositoryNotifications reconciles the notifications declared for an ImageStream into notifications of its Quay repository. Notifications not created by the operator are left untouched
+func (r *NamespaceIntegrationReconciler) syncRepositoryNotifications(namespace *corev1.Namespace, quayClient *qclient.Client, quayOrganizationName string, imageStream *imagev1.ImageStream, repositoryName string) (reconcile.Result, error) {
+	imageStreamName := imageStream.Name
+
+	notifications, notificationsErr := utils.GetRepositoryNotifications(namespace, imageStream)
+	if notificationsErr != nil {
+		return r.CoreComponents.ManageError(&core.QuayIntegrationCoreError{
+			Object:       imageStream,
+			Message:      "Invalid Repository notifications for ImageStream",
+			KeyAndValues: []interface{}{"Namespace", namespace.Name, "Name", imageStreamName},
+			Reason:       "ConfigurationError",
+			Error:        notificationsErr,
+		})
+	}
+
+	existingNotifications, existingNotificationsResponse, existingNotificationsErr := quayClient.GetRepositoryNotifications(quayOrganizationName, repositoryName)
+	if existingNotificationsErr.Error != nil || existingNotificationsResponse.StatusCode != 200 {
+		return r.CoreComponents.ManageError(&core.QuayIntegrationCoreError{
+			Object:       namespace,
+			Message:      "Error occurred retrieving Quay Repository notifications",
+			KeyAndValues: []interface{}{"Quay Repository", fmt.Sprintf("%s/%s", quayOrganizationName, repositoryName)},
+			Error:        existingNotificationsErr.Error,
+		})
+	}
+
+	desiredNotifications := map[string]qotypes.RepositoryNotification{}
+	for _, notification := range notifications {
+		desiredNotifications[constants.RepositoryNotificationTitlePrefix+notification.Name] = notification
+	}
+
+	for _, existingNotification := range existingNotifications.Notifications {
+		if !strings.HasPrefix(existingNotification.Title, constants.RepositoryNotificationTitlePrefix) {
+			continue
+		}
+
+		if notification, found := desiredNotifications[existingNotification.Title]; found && notificationMatches(notification, existingNotification) {
+			delete(desiredNotifications, existingNotification.Title)
+			continue
+		}
+
+		// Quay notifications cannot be updated, so changed notifications are deleted and recreated
+		logging.Log.Info("Deleting Repository notification", "Quay Repository", fmt.Sprintf("%s/%s", quayOrganizationName, repositoryName), "Title", existingNotification.Title)
+		deleteResponse, deleteErr := quayClient.DeleteRepositoryNotification(quayOrganizationName, repositoryName, existingNotification.UUID)
+		if deleteErr.Error != nil || (deleteResponse.StatusCode != 200 && deleteResponse.StatusCode != 204) {
+			return r.CoreComponents.ManageError(&core.QuayIntegrationCoreError{
+				Object:       namespace,
+				Message:      "Error occurred deleting Quay Repository notification",
+				KeyAndValues: []interface{}{"Quay Repository", fmt.Sprintf("%s/%s", quayOrganizationName, repositoryName), "Title", existingNotification.Title},
+				Error:        deleteErr.Error,
+			})
+		}
+	}
+
+	for _, notification := range notifications {
+		title := constants.RepositoryNotificationTitlePrefix + notification.Name
+		if _, found := desiredNotifications[title]; !found {
+			continue
+		}
+
+		logging.Log.Info("Creating Repository notification", "Quay Repository", fmt.Sprintf("%s/%s", quayOrganizationName, repositoryName), "Title", title)
+		_, createResponse, createErr := quayClient.CreateRepositoryNotification(quayOrganizationName, repositoryName, qclient.NotificationRequest{
+			Title:       title,
+			Event:       notification.Event,
+			Method:      notification.Method,
+			Config:      notification.Config,
+			EventConfig: notification.EventConfig,
+		})
+		if createErr.Error != nil || createResponse.StatusCode != 201 {
+			return r.CoreComponents.ManageError(&core.QuayIntegrationCoreError{
+				Object:       imageStream,
+				Message:      "Error occurred creating Quay Repository notification",
+				KeyAndValues: []interface{}{"Quay Repository", fmt.Sprintf("%s/%s", quayOrganizationName, repositoryName), "Title", title},
+				Error:        createErr.Error,
+			})
+		}
+	}
+
+	return reconcile.Result{}, nil
+}
+
+// notificationMatches returns whether an existing Quay notification matches its declaration
+func notificationMatches(notification qotypes.RepositoryNotification, existingNotification qclient.Notification) bool {
+	if notification.Event != existingNotification.Event || notification.Method != existingNotification.Method {
+		return false
+	}
+
+	return (len(notification.Config) == 0 && len(existingNotification.Config) == 0 || reflect.DeepEqual(notification.Config, existingNotification.Config)) &&
+		(len(notification.EventConfig) == 0 && len(existingNotification.EventConfig) == 0 || reflect.DeepEqual(notification.EventConfig, existingNotification.EventConfig))
+}
+
+// syncRepositoryMirror reconciles the mirror configuration of the Quay repository for an ImageStream and records the mirror sync status on the ImageStream
+func (r *NamespaceIntegrationReconciler) syncRepositoryMirror(ctx context.Context, namespace *corev1.Namespace, quayClient *qclient.Client, target qotypes.OrganizationTarget, imageStream *imagev1.ImageStream, repositoryName string) (reconcile.Result, error) {
+	quayOrganizationName := target.Organization
+	imageStreamName := imageStream.Name
+
+	mirrorSettings, mirrored, mirrorSettingsErr := utils.GetRepositoryMirrorSettings(imageStream)
+	if mirrorSettingsErr != nil {
+		return r.CoreComponents.ManageError(&core.QuayIntegrationCoreError{
+			Object:       imageStream,
+			Message:      "Invalid Repository mirror for ImageStream",
+			KeyAndValues: []interface{}{"Namespace", namespace.Name, "Name", imageStreamName},
+			Reason:       "ConfigurationError",
+			Error:        mirrorSettingsErr,
+		})
+	}
+
+	previousSyncStatus, previouslyMirrored := imageStream.Annotations[constants.MirrorSyncStatusAnnotation]
+	if !mirrored && !previouslyMirrored {
+		return reconcile.Result{}, nil
+	}
+
+	repository, repositoryResponse, repositoryErr := quayClient.GetRepository(quayOrganizationName, repositoryName)
+	if repositoryErr.Error != nil || repositoryResponse.StatusCode != 200 {
+		return r.CoreComponents.ManageError(&core.QuayIntegrationCoreError{
+			Object:       namespace,
+			Message:      "Error Retrieving Repository",
+			KeyAndValues: []interface{}{"Quay Repository", fmt.Sprintf("%s/%s", quayOrganizationName, repositoryName)},
+			Error:        repositoryErr.Error,
+		})
+	}
+
+	desiredState := qclient.RepositoryStateNormal
+	if mirrored {
+		desiredState = qclient.RepositoryStateMirror
+	}
+
+	if repository.State != desiredState {
+		logging.Log.Info("Changing Repository state", "Quay Repository", fmt.Sprintf("%s/%s", quayOrganizationName, repositoryName), "State", desiredState)
+		stateResponse, stateErr := quayClient.ChangeRepositoryState(quayOrganizationName, repositoryName, desiredState)
+		if stateErr.Error != nil || stateResponse.StatusCode != 200 {
+			return r.CoreComponents.ManageError(&core.QuayIntegrationCoreError{
+				Object:       namespace,
+				Message:      "Error occurred changing Quay Repository state",
+				KeyAndValues: []interface{}{"Quay Repository", fmt.Sprintf("%s/%s", quayOrganizationName, repositoryName), "State", desiredState},
+				Error:        stateErr.Error,
+			})
+		}
+	}
+
+	// The mirror configuration is retained by Quay but no longer applies once the repository returns to the normal state
+	if !mirrored {
+		delete(imageStream.Annotations, constants.MirrorSyncStatusAnnotation)
+		delete(imageStream.Annotations, constants.MirrorCredentialsVersionAnnotation)
+
+		if err := r.CoreComponents.ReconcilerBase.GetClient().Update(ctx, imageStream); err != nil {
+			return r.CoreComponents.ManageError(&core.QuayIntegrationCoreError{
+				Object:       imageStream,
+				Message:      "Error occurred removing Repository mirror status from ImageStream",
+				KeyAndValues: []interface{}{"Namespace", namespace.Name, "Name", imageStreamName},
+				Error:        err,
+			})
+		}
+
+		return reconcile.Result{}, nil
+	}
+
+	desiredMirror := qclient.RepositoryMirrorRequest{
+		IsEnabled:              true,
+		ExternalReference:      mirrorSettings.ExternalReference,
+		ExternalRegistryConfig: qclient.RepositoryMirrorRegistryConfig{VerifyTLS: mirrorSettings.VerifyTLS},
+		SyncInterval:           int(mirrorSettings.SyncInterval.Seconds()),
+		RootRule:               qclient.RepositoryMirrorRule{RuleKind: qclient.MirrorRuleKindTagGlobCSV, RuleValue: mirrorSettings.TagPatterns},
+		// The builder robot account of the namespace has write access to the repositories of the namespace
+		RobotUsername: utils.FormatOrganizationRobotAccountName(quayOrganizationName, target.RobotAccountName(string(qotypes.BuilderOpenShiftServiceAccount))),
+	}
+
+	credentialsVersion := ""
+
+	if mirrorSettings.CredentialsSecret != "" {
+		credentialsSecret := &corev1.Secret{}
+		err := r.CoreComponents.ReconcilerBase.GetClient().Get(ctx, types.NamespacedName{Namespace: namespace.Name, Name: mirrorSettings.CredentialsSecret}, credentialsSecret)
+		if err != nil {
+			return r.CoreComponents.ManageError(&core.QuayIntegrationCoreError{
+				Object:       imageStream,
+				Message:      "Error occurred retrieving Repository mirror credentials Secret",
+				KeyAndValues: []interface{}{"Namespace", namespace.Name, "Secret", mirrorSettings.CredentialsSecret},
+				Reason:       "ConfigurationError",
+				Error:        err,
+			})
+		}
+
+		username, password := string(credentialsSecret.Data[corev1.BasicAuthUsernameKey]), string(credentialsSecret.Data[corev1.BasicAuthPasswordKey])
+		if username == "" || password == "" {
+			return r.CoreComponents.ManageError(&core.QuayIntegrationCoreError{
+				Object:       imageStream,
+				Message:      "Repository mirror credentials Secret must contain a username and password",
+				KeyAndValues: []interface{}{"Namespace", namespace.Name, "Secret", mirrorSettings.CredentialsSecret},
+				Reason:       "ConfigurationError",
+				Error:        fmt.Errorf("secret %s is missing the %s or %s key", mirrorSettings.CredentialsSecret, corev1.BasicAuthUsernameKey, corev1.BasicAuthPasswordKey),
+			})
+		}
+
+		desiredMirror.ExternalRegistryUsername = username
+		desiredMirror.ExternalRegistryPassword = password
+		credentialsVersion = credentialsSecret.ResourceVersion
+	}
+
+	mirror, mirrorResponse, mirrorErr := quayClient.GetRepositoryMirror(quayOrganizationName, repositoryName)
+	if mirrorErr.Error != nil && (mirrorResponse == nil || mirrorResponse.StatusCode != 404) {
+		return r.CoreComponents.ManageError(&core.QuayIntegrationCoreError{
+			Object:       namespace,
+			Message:      "Error occurred retrieving Quay Repository mirror",
+			KeyAndValues: []interface{}{"Quay Repository", fmt.Sprintf("%s/%s", quayOrganizationName, repositoryName)},
+			Error:        mirrorErr.Error,
+		})
+	}
+
+	syncStatus := mirror.SyncStatus
+
+	switch {
+	case mirrorResponse.StatusCode == 404:
+		logging.Log.Info("Creating Repository mirror", "Quay Repository", fmt.Sprintf("%s/%s", quayOrganizationName, repositoryName), "Source", desiredMirror.ExternalReference)
+		desiredMirror.SyncStartDate = time.Now().UTC().Format(time.RFC3339)
+
+		createResponse, createErr := quayClient.CreateRepositoryMirror(quayOrganizationName, repositoryName, desiredMirror)
+		if createErr.Error != nil || createResponse.StatusCode != 201 {
+			return r.CoreComponents.ManageError(&core.QuayIntegrationCoreError{
+				Object:       imageStream,
+				Message:      "Error occurred creating Quay Repository mirror",
+				KeyAndValues: []interface{}{"Quay Repository", fmt.Sprintf("%s/%s", quayOrganizationName, repositoryName)},
+				Error:        createErr.Error,
+			})
+		}
+
+		syncStatus = qclient.MirrorSyncStatusNeverRun
+	case mirrorResponse.StatusCode != 200:
+		return r.CoreComponents.ManageError(&core.QuayIntegrationCoreError{
+			Object:       namespace,
+			Message:      "Error occurred retrieving Quay Repository mirror",
+			KeyAndValues: []interface{}{"Quay Repository", fmt.Sprintf("%s/%s", quayOrganizationName, repositoryName), "Status Code", mirrorResponse.StatusCode},
+		})
+	case !repositoryMirrorMatches(desiredMirror, mirror) || imageStream.Annotations[constants.MirrorCredentialsVersionAnnotation] != credentialsVersion:
+		logging.Log.Info("Updating Repository mirror", "Quay Repository", fmt.Sprintf("%s/%s", quayOrganizationName, repositoryName), "Source", desiredMirror.ExternalReference)
+
+		updateResponse, updateErr := quayClient.UpdateRepositoryMirror(quayOrganizationName, repositoryName, desiredMirror)
+		if updateErr.Error != nil || (updateResponse.StatusCode != 200 && updateResponse.StatusCode != 201) {
+			return r.CoreComponents.ManageError(&core.QuayIntegrationCoreError{
+				Object:       imageStream,
+				Message:      "Error occurred updating Quay Repository mirror",
+				KeyAndValues: []interface{}{"Quay Repository", fmt.Sprintf("%s/%s", quayOrganizationName, repositoryName)},
+				Error:        updateErr.Error,
+			})
+		}
+	}
+
+	if !previouslyMirrored || previousSyncStatus != syncStatus || imageStream.Annotations[constants.MirrorCredentialsVersionAnnotation] != credentialsVersion {
+		imageStream.Annotations[constants.MirrorSyncStatusAnnotation] = syncStatus
+		if credentialsVersion != "" {
+			imageStream.Annotations[constants.MirrorCredentialsVersionAnnotation] = credentialsVersion
+		} else {
+			delete(imageStream.Annotations, constants.MirrorCredentialsVersionAnnotation)
+		}
+
+		if err := r.CoreComponents.ReconcilerBase.GetClient().Update(ctx, imageStream); err != nil {
+			return r.CoreComponents.ManageError(&core.QuayIntegrationCoreError{
+				Object:       imageStream,
+				Message:      "Error occurred recording Repository mirror status on ImageStream",
+				KeyAndValues: []interface{}{"Namespace", namespace.Name, "Name", imageStreamName},
+				Error:        err,
+			})
+		}
+
+		if previousSyncStatus != syncStatus {
+			switch syncStatus {
+			case qclient.MirrorSyncStatusFail:
+				r.CoreComponents.ReconcilerBase.GetRecorder().Event(imageStream, corev1.EventTypeWarning, "MirrorSyncFailed", fmt.Sprintf("Mirroring %s into Quay repository %s/%s failed", desiredMirror.ExternalReference, quayOrganizationName, repositoryName))
+			case qclient.MirrorSyncStatusSuccess:
+				r.CoreComponents.ReconcilerBase.GetRecorder().Event(imageStream, corev1.EventTypeNormal, "MirrorSyncSucceeded", fmt.Sprintf("Mirrored %s into Quay repository %s/%s", desiredMirror.ExternalReference, quayOrganizationName, repositoryName))
+			}
+		}
+	}
+
+	// Quay synchronizes mirrors on its own schedule, so the status is polled
+	return reconcile.Result{RequeueAfter: constants.MirrorStatusPollInterval}, nil
+}
+
+// repositoryMirrorMatches returns whether an existing Quay repository mirror matches its declaration. Credentials cannot be retrieved from Quay and are not compared
+func repositoryMirrorMatches(desiredMirror qclient.RepositoryMirrorRequest, mirror qclient.RepositoryMirror) bool {
+	return desiredMirror.IsEnabled == mirror.IsEnabled &&
+		desiredMirror.ExternalReference == mirror.ExternalReference &&
+		desiredMirror.ExternalRegistryUsername == mirror.ExternalRegistryUsername &&
+		desiredMirror.ExternalRegistryConfig.VerifyTLS == mirror.ExternalRegistryConfig.VerifyTLS &&
+		desiredMirror.SyncInterval == mirror.SyncInterval &&
+		desiredMirror.RobotUsername == mirror.RobotUsername &&
+		desiredMirror.RootRule.RuleKind == mirror.RootRule.RuleKind &&
+		slices.Equal(desiredMirror.RootRule.RuleValue, mirror.RootRule.RuleValue)
+}
+
+// syncCrossNamespacePermissions grants the builder robot accounts of namespaces bound to the image pusher role write access to the repositories of this namespace
+func (r *NamespaceIntegrationReconciler) syncCrossNamespacePermissions(ctx context.Context, namespace *corev1.Namespace, quayClient *qclient.Client, target qotypes.OrganizationTarget, quayIntegration *quayv1.QuayIntegration, imageStreams []imagev1.ImageStream) (reconcile.Result, error) {
+	quayOrganizationName := target.Organization
+
+	roleBindings := rbacv1.RoleBindingList{}
+
+	err := r.CoreComponents.ReconcilerBase.GetClient().List(ctx, &roleBindings, &client.ListOptions{Namespace: namespace.Name})
+	if err != nil {
+		return r.CoreComponents.ManageError(&core.QuayIntegrationCoreError{
+			Object:       namespace,
+			Message:      "Error Retrieving RoleBindings for Namespace",
+			KeyAndValues: []interface{}{"Namespace", namespace.Name},
+			Error:        err,
+		})
+	}
+
+	desiredRobotAccounts := map[string]bool{}
+
+	for i := range roleBindings.Items {
+		for _, pusherNamespace := range utils.GetImagePusherNamespaces(&roleBindings.Items[i]) {
+			if pusherNamespace != namespace.Name && quayIntegration.IsAllowedNamespace(pusherNamespace) {
+				pusherTarget, pusherTargetErr := r.CoreComponents.GetOrganizationTarget(ctx, quayIntegration, pusherNamespace)
+				if pusherTargetErr != nil {
+					return r.CoreComponents.ManageError(&core.QuayIntegrationCoreError{
+						Object:       namespace,
+						Message:      "Error occurred resolving the Quay Organization of an image pusher namespace",
+						KeyAndValues: []interface{}{"Namespace", namespace.Name, "Pusher Namespace", pusherNamespace},
+						Error:        pusherTargetErr,
+					})
+				}
+
+				desiredRobotAccounts[utils.FormatOrganizationRobotAccountName(pusherTarget.Organization, pusherTarget.RobotAccountName(string(qotypes.BuilderOpenShiftServiceAccount)))] = true
+			}
+		}
+	}
+
+	for i := range imageStreams {
+		// The repositories of ImageStreams with an invalid path are never created
+		repositoryName, repositoryNameErr := utils.GetRepositoryName(target, &imageStreams[i])
+		if repositoryNameErr != nil {
+			continue
+		}
+
+		permissions, permissionsResponse, permissionsErr := quayClient.GetRepositoryUserPermissions(quayOrganizationName, repositoryName)
+		if permissionsErr.Error != nil || permissionsResponse.StatusCode != 200 {
+			return r.CoreComponents.ManageError(&core.QuayIntegrationCoreError{
+				Object:       namespace,
+				Message:      "Error occurred retrieving Quay Repository permissions",
+				KeyAndValues: []interface{}{"Quay Repository", fmt.Sprintf("%s/%s", quayOrganizationName, repositoryName)},
+				Error:        permissionsErr.Error,
+			})
+		}
+
+		for robotAccount := range desiredRobotAccounts {
+			if permission, found := permissions.Permissions[robotAccount]; found && permission.Role == string(qclient.QuayRoleWrite) {
+				continue
+			}
+
+			logging.Log.Info("Granting cross namespace builder access", "Quay Repository", fmt.Sprintf("%s/%s", quayOrganizationName, repositoryName), "Robot Account", robotAccount)
+			_, permissionResponse, permissionErr := quayClient.SetRepositoryUserPermission(quayOrganizationName, repositoryName, robotAccount, string(qclient.QuayRoleWrite))
+			if permissionErr.Error != nil || permissionResponse.StatusCode != 200 {
+				return r.CoreComponents.ManageError(&core.QuayIntegrationCoreError{
+					Object:       namespace,
+					Message:      "Error occurred granting cross namespace builder access to Quay Repository",
+					KeyAndValues: []interface{}{"Quay Repository", fmt.Sprintf("%s/%s", quayOrganizationName, repositoryName), "Robot Account", robotAccount},
+					Error:        permissionErr.Error,
+				})
+			}
+		}
+
+		// Revoke access from builders of other bridge managed organizations no longer bound to the image pusher role
+		for robotAccount, permission := range permissions.Permissions {
+			if !permission.IsRobot || desiredRobotAccounts[robotAccount] || !isCrossNamespaceBuilderRobotAccount(quayIntegration, target, robotAccount) {
+				continue
+			}
+
+			logging.Log.Info("Revoking cross namespace builder access", "Quay Repository", fmt.Sprintf("%s/%s", quayOrganizationName, repositoryName), "Robot Account", robotAccount)
+			permissionResponse, permissionErr := quayClient.DeleteRepositoryUserPermission(quayOrganizationName, repositoryName, robotAccount)
+			if permissionErr.Error != nil || permissionResponse.StatusCode != 204 {
+				return r.CoreComponents.ManageError(&core.QuayIntegrationCoreError{
+					Object:       namespace,
+					Message:      "Error occurred revoking cross namespace builder access to Quay Repository",
+					KeyAndValues: []interface{}{"Quay Repository", fmt.Sprintf("%s/%s", quayOrganizationName, repositoryName), "Robot Account", robotAccount},
+					Error:        permissionErr.Error,
+				})
+			}
+		}
+	}
+
+	return reconcile.Result{}, nil
+}
+
+// isCrossNamespaceBuilderRobotAccount determines whether a robot account is the builder robot account of another namespace managed by the operator, either within an organization generated for a namespace or within a shared organization
+func isCrossNamespaceBuilderRobotAccount(quayIntegration *quayv1.QuayIntegration, target qotypes.OrganizationTarget, robotAccount string) bool {
+	builder := string(qotypes.BuilderOpenShiftServiceAccount)
+
+	if robotAccount == utils.FormatOrganizationRobotAccountName(target.Organization, target.RobotAccountName(builder)) {
+		return false
+	}
+
+	organization, shortName, found := strings.Cut(robotAccount, "+")
+	if !found {
+		return false
+	}
+
+	if strings.HasPrefix(organization, fmt.Sprintf("%s_", strings.ToLower(quayIntegration.Spec.ClusterID))) {
+		return shortName == builder
+	}
+
+	for _, sharedOrganization := range quayIntegration.Spec.SharedOrganizations {
+		if sharedOrganization.Name == organization {
+			return strings.HasPrefix(shortName, builder+"_")
+		}
+	}
+
+	return false
+}
+
+// createRobotAccountAndSecret creates a robot account, delivers its credentials according to the robot credentials mode and adds the resulting secret to the service account
+func (r *NamespaceIntegrationReconciler) createRobotAccountAssociateToSA(ctx context.Context, request reconcile.Request, namespace *corev1.Namespace, quayClient *qclient.Client, target qotypes.OrganizationTarget, serviceAccount qotypes.OpenShiftServiceAccount, role qclient.QuayRole, quayIntegration *quayv1.QuayIntegration) (reconcile.Result, error) {
+	quayOrganizationName := target.Organization
+	robotAccountName := target.RobotAccountName(string(serviceAccount))
+
+	// Setup Robot Account
+	robotAccount, robotAccountResponse, robotAccountError := quayClient.GetOrganizationRobotAccount(quayOrganizationName, robotAccountName)
+	if robotAccountResponse == nil {
+		return r.CoreComponents.ManageError(&core.QuayIntegrationCoreError{
+			Object:       namespace,
+			Message:      "Error occured creating HTTP request to fetch Quay Organization Robot Acccount",
+			KeyAndValues: []interface{}{"Quay Repository", quayOrganizationName, "Robot Account", serviceAccount},
+			Error:        robotAccountError.Error,
+		})
+	}
+
+	if robotAccountError.Error != nil {
+		return r.CoreComponents.ManageError(&core.QuayIntegrationCoreError{
+			Object:       namespace,
+			Message:      "Error occurred retrieving robot account for Quay Organization",
+			KeyAndValues: []interface{}{"Quay Repository", quayOrganizationName, "Robot Account", serviceAccount, "Status Code", robotAccountResponse.StatusCode},
+			Error:        robotAccountError.Error,
+		})
+	}
+
+	// Check to see if Robot Exists
+	if robotAccountResponse.StatusCode == 400 {
+		// Create Robot Account
+		robotAccount, robotAccountResponse, robotAccountError = quayClient.CreateOrganizationRobotAccount(quayOrganizationName, robotAccountName)
+		if robotAccountError.Error != nil || robotAccountResponse.StatusCode != 201 {
+			return r.CoreComponents.ManageError(&core.QuayIntegrationCoreError{
+				Object:       namespace,
+				Message:      "Error occurred retrieving robot account for Quay Organization",
+				KeyAndValues: []interface{}{"Quay Repository", quayOrganizationName, "Robot Account", serviceAccount, "Status Code", robotAccountResponse.StatusCode},
+			})
+		}
+	}
+
+	// Default permissions would grant access to the repositories of every namespace sharing an organization
+	if !target.Shared {
+		organizationPrototypes, organizationPrototypesResponse, organizationPrototypesError := quayClient.GetPrototypesByOrganization(quayOrganizationName)
+		if organizationPrototypesError.Error != nil {
+			return r.CoreComponents.ManageError(&core.QuayIntegrationCoreError{
+				Object:       namespace,
+				Message:      "Error occurred retrieving Prototypes for Quay Organization",
+				KeyAndValues: []interface{}{"Quay Repository", quayOrganizationName, "Status Code", robotAccountResponse.StatusCode},
+				Error:        organizationPrototypesError.Error,
+			})
+		}
+
+		if organizationPrototypesResponse.StatusCode != 200 {
+			return r.CoreComponents.ManageError(&core.QuayIntegrationCoreError{
+				Object:       namespace,
+				Message:      "Error occurred retrieving Prototypes for Quay Organization",
+				KeyAndValues: []interface{}{"Quay Repository", quayOrganizationName, "Status Code", robotAccountResponse.StatusCode},
+			})
+		}
+
+		if found := qclient.IsRobotAccountInPrototypeByRole(organizationPrototypes.Prototypes, robotAccount.Name, string(role)); !found {
+			// Create Prototype
+			_, robotPrototypeResponse, robotPrototypeError := quayClient.CreateRobotPermissionForOrganization(quayOrganizationName, robotAccount.Name, string(role))
+			if robotPrototypeError.Error != nil || robotPrototypeResponse.StatusCode != 200 {
+				return r.CoreComponents.ManageError(&core.QuayIntegrationCoreError{
+					Object:       namespace,
+					Message:      "Error occurred creating Robot account permissions for Prototype",
+					KeyAndValues: []interface{}{"Quay Repository", quayOrganizationName, "Robot Account", robotAccount.Name, "Prototype", role, "Status Code", robotPrototypeResponse.StatusCode},
+					Error:        robotPrototypeError.Error,
+				})
+			}
+		}
+	}
+
+	robotCredentialsSettings, robotCredentialsErr := utils.GetRobotCredentialsSettings(quayIntegration, namespace.Name, string(serviceAccount), quayOrganizationName, robotAccount.Name)
+	if robotCredentialsErr != nil {
+		return r.CoreComponents.ManageError(&core.QuayIntegrationCoreError{
+			Object:       namespace,
+			Message:      "Invalid robot credentials configuration",
+			KeyAndValues: []interface{}{"Namespace", namespace.Name, "Service Account", serviceAccount},
+			Error:        robotCredentialsErr,
+			SkipRequeue:  true,
+		})
+	}
+
+	robotSecretName := utils.GenerateDockerJsonSecretNameForServiceAccount(string(serviceAccount), quayIntegration.Spec.ClusterID)
+	robotSecretLabels := utils.GetBridgeLabels(quayIntegration.Name, string(serviceAccount))
+
+	existingServiceAccount := &corev1.ServiceAccount{}
+	serviceAccountErr := r.CoreComponents.ReconcilerBase.GetClient().Get(ctx, types.NamespacedName{Namespace: namespace.Name, Name: string(serviceAccount)}, existingServiceAccount)
+	if serviceAccountErr != nil {
+		return r.CoreComponents.ManageError(&core.QuayIntegrationCoreError{
+			Object:       namespace,
+			Message:      "Failed to get existing platform service account",
+			KeyAndValues: []interface{}{"Namespace", namespace.Name, "Service Account", serviceAccount},
+			Error:        serviceAccountErr,
+		})
+
+	}
+
+	switch robotCredentialsSettings.Mode {
+	case credentials.RobotCredentialsModeSecret, credentials.RobotCredentialsModePushSecret:
+		// Parse out hostname from Quay Hostname
+		quayURL, quayURLErr := url.Parse(quayIntegration.Spec.QuayHostname)
+		if quayURLErr != nil {
+			return r.CoreComponents.ManageError(&core.QuayIntegrationCoreError{
+				Object:       namespace,
+				Message:      "Failed to parse Quay hostname",
+				KeyAndValues: []interface{}{"Hostname", quayIntegration.Spec.QuayHostname},
+				Error:        quayURLErr,
+			})
+		}
+
+		servers := []string{quayURL.Host}
+		if quayIntegration.Spec.PullSecrets != nil {
+			servers = append(servers, quayIntegration.Spec.PullSecrets.AdditionalRegistries...)
+		}
+
+		// Setup Secret for Quay Robot Account
+		robotSecret, robotSecretErr := credentials.GenerateDockerJsonSecretForServers(robotSecretName, servers, robotAccount.Name, robotAccount.Token, "")
+		if robotSecretErr != nil {
+			return r.CoreComponents.ManageError(&core.QuayIntegrationCoreError{
+				Object:       namespace,
+				Message:      "Failed to generate Docker JSON Secret for Service Account",
+				KeyAndValues: []interface{}{"Namespace", namespace.Name, "Robot Account", robotAccount.Name, "Service Account", serviceAccount},
+				Error:        robotSecretErr,
+			})
+		}
+
+		robotSecret.ObjectMeta.Namespace = namespace.Name
+		robotSecret.ObjectMeta.Labels = robotSecretLabels
+
+		if quayIntegration.Spec.PullSecrets != nil && quayIntegration.Spec.PullSecrets.Merge {
+			if mergeErr := r.mergeLinkedPullSecrets(ctx, existingServiceAccount, robotSecret); mergeErr != nil {
+				return r.CoreComponents.ManageError(&core.QuayIntegrationCoreError{
+					Object:       namespace,
+					Message:      "Failed to merge the pull secrets linked to the Service Account",
+					KeyAndValues: []interface{}{"Namespace", namespace.Name, "Service Account", serviceAccount},
+					Error:        mergeErr,
+				})
+			}
+		}
+
+		robotCreateSecretErr := r.CoreComponents.ReconcilerBase.CreateOrUpdateResource(ctx, quayIntegration, namespace.Name, robotSecret)
+		if robotCreateSecretErr != nil {
+			return reconcile.Result{Requeue: true}, robotSecretErr
+		}
+
+		if robotCredentialsSettings.Mode == credentials.RobotCredentialsModePushSecret {
+			pushSecret := credentials.GeneratePushSecret(robotSecretName, robotSecretName, robotCredentialsSettings.StoreName, robotCredentialsSettings.StoreKind, robotCredentialsSettings.RemoteKey, robotCredentialsSettings.RefreshInterval)
+			pushSecret.SetLabels(robotSecretLabels)
+
+			if pushSecretErr := r.CoreComponents.ReconcilerBase.CreateOrUpdateResource(ctx, quayIntegration, namespace.Name, pushSecret); pushSecretErr != nil {
+				return r.CoreComponents.ManageError(&core.QuayIntegrationCoreError{
+					Object:       namespace,
+					Message:      "Failed to create PushSecret for Robot Account",
+					KeyAndValues: []interface{}{"Namespace", namespace.Name, "Robot Account", robotAccount.Name, "Remote Key", robotCredentialsSettings.RemoteKey},
+					Error:        pushSecretErr,
+				})
+			}
+		}
+	case credentials.RobotCredentialsModeExternalSecret:
+		externalSecret := credentials.GenerateExternalSecret(robotSecretName, robotSecretName, robotCredentialsSettings.StoreName, robotCredentialsSettings.StoreKind, robotCredentialsSettings.RemoteKey, robotCredentialsSettings.RefreshInterval)
+		externalSecret.SetLabels(robotSecretLabels)
+
+		if externalSecretErr := r.CoreComponents.ReconcilerBase.CreateOrUpdateResource(ctx, quayIntegration, namespace.Name, externalSecret); externalSecretErr != nil {
+			return r.CoreComponents.ManageError(&core.QuayIntegrationCoreError{
+				Object:       namespace,
+				Message:      "Failed to create ExternalSecret for Robot Account",
+				KeyAndValues: []interface{}{"Namespace", namespace.Name, "Robot Account", robotAccount.Name, "Remote Key", robotCredentialsSettings.RemoteKey},
+				Error:        externalSecretErr,
+			})
+		}
+	}
+
+	// Secrets previously written by the operator, such as those named after a former ClusterID or written before switching robot credentials mode, are removed
+	if staleSecretsErr := r.deleteStaleRobotSecrets(ctx, namespace.Name, quayIntegration, string(serviceAccount), robotSecretName, robotCredentialsSettings.Mode); staleSecretsErr != nil {
+		return r.CoreComponents.ManageError(&core.QuayIntegrationCoreError{
+			Object:       namespace,
+			Message:      "Failed to delete stale Robot Account Secrets",
+			KeyAndValues: []interface{}{"Namespace", namespace.Name, "Service Account", serviceAccount},
+			Error:        staleSecretsErr,
+		})
+	}
+
+	var updated bool
+
+	// Referenced credentials are provided by other means, so the robot account and remote key they are expected under are recorded on the service account
+	if robotCredentialsSettings.Mode == credentials.RobotCredentialsModeReference {
+		updated = r.updateServiceAccountRobotCredentialsReference(existingServiceAccount, robotAccount.Name, robotCredentialsSettings.RemoteKey)
+	}
+
+	secretExists, secretExistsErr := r.robotSecretExists(ctx, namespace.Name, robotSecretName, robotCredentialsSettings)
+	if secretExistsErr != nil {
+		return r.CoreComponents.ManageError(&core.QuayIntegrationCoreError{
+			Object:       namespace,
+			Message:      "Failed to get Robot Account Secret",
+			KeyAndValues: []interface{}{"Namespace", namespace.Name, "Secret", robotSecretName},
+			Error:        secretExistsErr,
+		})
+	}
+
+	desiredSecrets := []string{}
+	if secretExists {
+		desiredSecrets = append(desiredSecrets, robotSecretName)
+	} else {
+		logging.Log.Info("Waiting for Robot Account Secret before linking it to the Service Account", "Namespace", namespace.Name, "Secret", robotSecretName, "Service Account", serviceAccount, "Mode", robotCredentialsSettings.Mode)
+	}
+
+	if linked := utils.LinkServiceAccountSecrets(existingServiceAccount, desiredSecrets); linked {
 		updated = true
 	}
 
-	if found := utils.ObjectReferenceNameExists(serviceAccount.Secrets, name); !found {
-		serviceAccount.Secrets = append(serviceAccount.Secrets, corev1.ObjectReference{Name: name})
-		updated = true
+	if updated {
+		updatedServiceAccountErr := r.CoreComponents.ReconcilerBase.CreateOrUpdateResource(ctx, nil, namespace.Name, existingServiceAccount)
+		if updatedServiceAccountErr != nil {
+			return r.CoreComponents.ManageError(&core.QuayIntegrationCoreError{
+				Object:       namespace,
+				Message:      "Failed to to updated existing platform service account",
+				KeyAndValues: []interface{}{"Namespace", namespace.Name, "Service Account", serviceAccount},
+				Error:        updatedServiceAccountErr,
+			})
+		}
+	}
+
+	return reconcile.Result{}, nil
+}
+
+// mergeLinkedPullSecrets adds the registries of the other dockerconfigjson Secrets linked to a service account to the Secret written by the operator
+func (r *NamespaceIntegrationReconciler) mergeLinkedPullSecrets(ctx context.Context, serviceAccount *corev1.ServiceAccount, robotSecret *corev1.Secret) error {
+	linkedSecrets := strings.Split(serviceAccount.Annotations[constants.LinkedSecretsAnnotation], ",")
+
+	for _, reference := range serviceAccount.ImagePullSecrets {
+		if reference.Name == robotSecret.Name || slices.Contains(linkedSecrets, reference.Name) {
+			continue
+		}
+
+		secret := &corev1.Secret{}
+		if err := r.CoreComponents.ReconcilerBase.GetClient().Get(ctx, types.NamespacedName{Namespace: serviceAccount.Namespace, Name: reference.Name}, secret); err != nil {
+			if errors.IsNotFound(err) {
+				continue
+			}
+			return err
+		}
+
+		if secret.Type != corev1.SecretTypeDockerConfigJson || secret.Labels[constants.ManagedByLabel] == constants.ManagedByLabelValue {
+			continue
+		}
+
+		if err := credentials.MergeDockerConfigJSON(robotSecret, secret.Data[corev1.DockerConfigJsonKey]); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// deleteStaleRobotSecrets deletes the Secrets written by the operator for a service account other than the desired one
+func (r *NamespaceIntegrationReconciler) deleteStaleRobotSecrets(ctx context.Context, namespace string, quayIntegration *quayv1.QuayIntegration, serviceAccount string, name string, mode string) error {
+	secrets := corev1.SecretList{}
+	if err := r.CoreComponents.ReconcilerBase.GetClient().List(ctx, &secrets, client.InNamespace(namespace), client.MatchingLabels{constants.ManagedByLabel: constants.ManagedByLabelValue, constants.ServiceAccountLabel: serviceAccount}); err != nil {
+		return err
+	}
+
+	for i := range secrets.Items {
+		// Secrets materialized by the External Secrets Operator are owned by their ExternalSecret
+		if !metav1.IsControlledBy(&secrets.Items[i], quayIntegration) {
+			continue
+		}
+
+		if secrets.Items[i].Name == name && (mode == credentials.RobotCredentialsModeSecret || mode == credentials.RobotCredentialsModePushSecret) {
+			continue
+		}
+
+		logging.Log.Info("Deleting stale Robot Account Secret", "Namespace", namespace, "Secret", secrets.Items[i].Name, "Service Account", serviceAccount)
+
+		if err := r.CoreComponents.ReconcilerBase.DeleteResourceIfExists(ctx, &secrets.Items[i]); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// unlinkServiceAccountSecrets removes the Secrets linked by the operator from the service accounts of a namespace and deletes the objects written by the operator for them
+func (r *NamespaceIntegrationReconciler) unlinkServiceAccountSecrets(ctx context.Context, namespace *corev1.Namespace) error {
+	for serviceAccount := range QuayServiceAccountPermissionMatrix {
+		existingServiceAccount := &corev1.ServiceAccount{}
+		if err := r.CoreComponents.ReconcilerBase.GetClient().Get(ctx, types.NamespacedName{Namespace: namespace.Name, Name: string(serviceAccount)}, existingServiceAccount); err != nil {
+			if errors.IsNotFound(err) {
+				continue
+			}
+			return err
+		}
+
+		if updated := utils.LinkServiceAccountSecrets(existingServiceAccount, nil); updated {
+			logging.Log.Info("Unlinking Robot Account Secrets from Service Account", "Namespace", namespace.Name, "Service Account", serviceAccount)
+
+			if err := r.CoreComponents.ReconcilerBase.GetClient().Update(ctx, existingServiceAccount); err != nil {
+				return err
+			}
+		}
+	}
+
+	managedLabels := client.MatchingLabels{constants.ManagedByLabel: constants.ManagedByLabelValue}
+
+	secrets := corev1.SecretList{}
+	if err := r.CoreComponents.ReconcilerBase.GetClient().List(ctx, &secrets, client.InNamespace(namespace.Name), managedLabels); err != nil {
+		return err
+	}
+
+	for i := range secrets.Items {
+		if err := r.CoreComponents.ReconcilerBase.DeleteResourceIfExists(ctx, &secrets.Items[i]); err != nil {
+			return err
+		}
+	}
+
+	// The External Secrets Operator may not be installed
+	for _, gvk := range []schema.GroupVersionKind{credentials.PushSecretGroupVersionKind, credentials.ExternalSecretGroupVersionKind} {
+		objects := &unstructured.UnstructuredList{}
+		objects.SetGroupVersionKind(gvk.GroupVersion().WithKind(gvk.Kind + "List"))
+
+		if err := r.CoreComponents.ReconcilerBase.GetClient().List(ctx, objects, client.InNamespace(namespace.Name), managedLabels); err != nil {
+			if apimeta.IsNoMatchError(err) {
+				continue
+			}
+			return err
+		}
+
+		for i := range objects.Items {
+			if err := r.CoreComponents.ReconcilerBase.DeleteResourceIfExists(ctx, &objects.Items[i]); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// offboardNamespace removes the pull secrets, Quay resources and finalizer of a namespace that is no longer managed, applying the given organization deletion policy
+func (r *NamespaceIntegrationReconciler) offboardNamespace(ctx context.Context, namespace *corev1.Namespace, quayClient *qclient.Client, quayIntegration *quayv1.QuayIntegration, deletionPolicy string) (string, error) {
+	if err := r.unlinkServiceAccountSecrets(ctx, namespace); err != nil {
+		return "", fmt.Errorf("failed to unlink pull secrets: %w", err)
+	}
+
+	target, targetErr := utils.GetOrganizationTarget(quayIntegration, namespace)
+	quayOrganizationName := target.Organization
+
+	var message string
+
+	// Forcing finalization leaves Quay untouched
+	forced := utils.IsFinalizationForced(namespace, quayIntegration)
+
+	// Organizations owned by others are left untouched
+	owned := targetErr == nil
+	if owned && quayClient != nil && !target.Shared && !forced {
+		var err error
+		if owned, err = isOrganizationOwned(quayClient, quayOrganizationName, quayIntegration.Spec.ClusterID); err != nil {
+			return "", err
+		}
+	}
+
+	if targetErr != nil {
+		message = fmt.Sprintf("Left Quay untouched as the organization of the namespace cannot be resolved: %v", targetErr)
+	} else if forced {
+		message = fmt.Sprintf("Left Quay organization %s untouched as finalization was forced", quayOrganizationName)
+	} else if !owned {
+		message = fmt.Sprintf("Left Quay organization %s untouched as it is not managed by the operator", quayOrganizationName)
+	} else if deletionPolicy != constants.OrganizationDeletionPolicyRetain {
+		if quayClient == nil {
+			return "", fmt.Errorf("unable to communicate with Quay to delete organization %s", quayOrganizationName)
+		}
+
+		result, err := r.cleanupResources(reconcile.Request{NamespacedName: types.NamespacedName{Name: namespace.Name}}, namespace, quayClient, target, quayIntegration.Spec.ClusterID)
+		if err != nil {
+			return "", fmt.Errorf("failed to delete Quay organization %s: %w", quayOrganizationName, err)
+		}
+
+		if result.Requeue {
+			return "", fmt.Errorf("failed to delete Quay organization %s", quayOrganizationName)
+		}
+
+		if target.Shared {
+			message = fmt.Sprintf("Deleted the repositories and robot accounts of the namespace from shared Quay organization %s", quayOrganizationName)
+		} else {
+			message = fmt.Sprintf("Deleted Quay organization %s", quayOrganizationName)
+		}
+	} else if quayClient != nil {
+		if err := r.deleteRobotAccounts(quayClient, target); err != nil {
+			return "", err
+		}
+
+		message = fmt.Sprintf("Retained Quay organization %s and deleted its robot accounts", quayOrganizationName)
+	} else {
+		message = fmt.Sprintf("Retained Quay organization %s and its robot accounts", quayOrganizationName)
+	}
+
+	util.RemoveFinalizer(namespace, constants.NamespaceFinalizer)
+	if err := r.CoreComponents.ReconcilerBase.GetClient().Update(ctx, namespace); err != nil {
+		return "", fmt.Errorf("failed to remove namespace finalizer: %w", err)
+	}
+
+	return message, nil
+}
+
+// blockFinalization reports on a terminating namespace that its Quay resources cannot be deleted, returning the result of the failed attempt
+func (r *NamespaceIntegrationReconciler) blockFinalization(ctx context.Context, namespace *corev1.Namespace, reason string, message string, result reconcile.Result, err error) (reconcile.Result, error) {
+	if conditionResult, conditionErr := r.updateNamespaceCondition(ctx, namespace, utils.GetFinalizationBlockedCondition(reason, message), "Error occurred updating Namespace finalization status"); conditionErr != nil {
+		return conditionResult, conditionErr
+	}
+
+	return result, err
+}
+
+// deleteRobotAccounts deletes the robot accounts associated to the service accounts of a namespace within its organization
+func (r *NamespaceIntegrationReconciler) deleteRobotAccounts(quayClient *qclient.Client, target qotypes.OrganizationTarget) error {
+	quayOrganizationName := target.Organization
+
+	for serviceAccount := range QuayServiceAccountPermissionMatrix {
+		robotAccountName := target.RobotAccountName(string(serviceAccount))
+
+		robotAccountResponse, robotAccountError := quayClient.DeleteOrganizationRobotAccount(quayOrganizationName, robotAccountName)
+		if robotAccountError.Error != nil {
+			return fmt.Errorf("failed to delete robot account %s of Quay organization %s: %w", robotAccountName, quayOrganizationName, robotAccountError.Error)
+		}
+
+		// Robot accounts and organizations that do not exist are reported as 400 and 404 respectively
+		if robotAccountResponse.StatusCode != 204 && robotAccountResponse.StatusCode != 400 && robotAccountResponse.StatusCode != 404 {
+			return fmt.Errorf("failed to delete robot account %s of Quay organization %s: status code %d", robotAccountName, quayOrganizationName, robotAccountResponse.StatusCode)
+		}
+	}
+
+	return nil
+}
+
+func (r *NamespaceIntegrationReconciler) removeNamespaceFinalizer(ctx context.Context, namespace *corev1.Namespace) (reconcile.Result, error) {
+	util.RemoveFinalizer(namespace, constants.NamespaceFinalizer)
+	err := r.CoreComponents.ReconcilerBase.GetClient().Update(ctx, namespace)
+	if err != nil {
+		return r.CoreComponents.ManageError(&core.QuayIntegrationCoreError{
+			Object:       namespace,
+			Message:      "Unable to update namespace",
+			KeyAndValues: []interface{}{"Namespace", namespace.Name},
+			Error:        err,
+		})
+	}
+	return reconcile.Result{}, nil
+}
+
+func (r *NamespaceIntegrationReconciler) cleanupResources(request reconcile.Request, namespace *corev1.Namespace, quayClient *qclient.Client, target qotypes.OrganizationTarget, clusterID string) (reconcile.Result, error) {
+	quayOrganizationName := target.Organization
+
+	// Only the resources of the namespace are deleted from a shared organization
+	if target.Shared {
+		return r.cleanupSharedResources(namespace, quayClient, target)
+	}
+
+	logging.Log.Info("Deleting Organization", "Organization Name", quayOrganizationName)
+
+	_, organizationResponse, organizationError := quayClient.GetOrganizationByName(quayOrganizationName)
+	if organizationError.Error != nil {
+		return r.CoreComponents.ManageError(&core.QuayIntegrationCoreError{
+			Object:       namespace,
+			Message:      "Error occurred retrieving Organization",
+			KeyAndValues: []interface{}{"Quay Organization", quayOrganizationName, "Status Code", organizationResponse.StatusCode},
+			Error:        organizationError.Error,
+		})
+	}
+
+	// Check to see if Organization Exists (Response Code)
+	if organizationResponse.StatusCode == 404 {
+		return reconcile.Result{}, nil
+		// Organization is not present
+	} else if organizationResponse.StatusCode == 200 {
+		// Organizations owned by others are never deleted
+		owned, err := isOrganizationOwned(quayClient, quayOrganizationName, clusterID)
+		if err != nil {
+			return r.CoreComponents.ManageError(&core.QuayIntegrationCoreError{
+				Object:       namespace,
+				Message:      "Error occurred retrieving Organization ownership",
+				KeyAndValues: []interface{}{"Quay Organization", quayOrganizationName},
+				Error:        err,
+			})
+		}
+
+		if !owned {
+			logging.Log.Info("Retaining Organization not managed by the operator", "Organization Name", quayOrganizationName)
+			r.CoreComponents.ReconcilerBase.GetRecorder().Event(namespace, "Warning", "OrganizationRetained", fmt.Sprintf("Quay organization %s was not deleted as it is not managed by the operator", quayOrganizationName))
+			return reconcile.Result{}, nil
+		}
+
+		organizationDeleteResponse, organizationDeleteError := quayClient.DeleteOrganization(quayOrganizationName)
+		if organizationDeleteResponse == nil {
+			return r.CoreComponents.ManageError(&core.QuayIntegrationCoreError{
+				Object:       namespace,
+				Message:      "Error occurred building HTTP request to delete Organization",
+				KeyAndValues: []interface{}{"Quay Organization", quayOrganizationName},
+				Error:        organizationDeleteError.Error,
+			})
+		}
+
+		if organizationDeleteError.Error != nil {
+			return r.CoreComponents.ManageError(&core.QuayIntegrationCoreError{
+				Object:       namespace,
+				Message:      "Error occurred deleting Organization",
+				KeyAndValues: []interface{}{"Quay Organization", quayOrganizationName, "Status Code", organizationDeleteResponse.StatusCode},
+				Error:        organizationDeleteError.Error,
+			})
+		}
+
+		if organizationDeleteResponse.StatusCode != 204 {
+			return r.CoreComponents.ManageError(&core.QuayIntegrationCoreError{
+				Object:       namespace,
+				Message:      "Error occurred deleting Organization",
+				KeyAndValues: []interface{}{"Quay Organization", quayOrganizationName, "Status Code", organizationDeleteResponse.StatusCode},
+			})
+		}
+		return reconcile.Result{}, nil
+
+	} else {
+		return r.CoreComponents.ManageError(&core.QuayIntegrationCoreError{
+			Object:       namespace,
+			Message:      "Error occurred retrieving Organization",
+			KeyAndValues: []interface{}{"Quay Organization", quayOrganizationName, "Status Code", organizationResponse.StatusCode},
+		})
+	}
+}
+
+// cleanupSharedResources deletes the repositories carrying the prefix of a namespace and the robot accounts of the namespace from a shared organization. Repositories of namespaces using the Unprefixed naming scheme cannot be attributed to the namespace and are retained
+func (r *NamespaceIntegrationReconciler) cleanupSharedResources(namespace *corev1.Namespace, quayClient *qclient.Client, target qotypes.OrganizationTarget) (reconcile.Result, error) {
+	quayOrganizationName := target.Organization
+
+	logging.Log.Info("Deleting Namespace resources from shared Organization", "Organization Name", quayOrganizationName, "Namespace", namespace.Name)
+
+	if target.RepositoryPrefix != "" {
+		repositories, err := getQuayRepositories(quayClient, quayOrganizationName)
+		if err != nil {
+			return r.CoreComponents.ManageError(&core.QuayIntegrationCoreError{
+				Object:       namespace,
+				Message:      "Error occurred retrieving Repositories of shared Organization",
+				KeyAndValues: []interface{}{"Quay Organization", quayOrganizationName},
+				Error:        err,
+			})
+		}
+
+		for _, repository := range repositories {
+			if _, found := target.ImageStreamName(repository.Name); !found {
+				continue
+			}
+
+			deleteResponse, deleteErr := quayClient.DeleteRepository(quayOrganizationName, repository.Name)
+			if deleteErr.Error != nil || (deleteResponse.StatusCode != 204 && deleteResponse.StatusCode != 404) {
+				return r.CoreComponents.ManageError(&core.QuayIntegrationCoreError{
+					Object:       namespace,
+					Message:      "Error occurred deleting Repository of shared Organization",
+					KeyAndValues: []interface{}{"Quay Repository", fmt.Sprintf("%s/%s", quayOrganizationName, repository.Name)},
+					Error:        deleteErr.Error,
+				})
+			}
+		}
+	}
+
+	if err := r.deleteRobotAccounts(quayClient, target); err != nil {
+		return r.CoreComponents.ManageError(&core.QuayIntegrationCoreError{
+			Object:       namespace,
+			Message:      "Error occurred deleting Robot Accounts of shared Organization",
+			KeyAndValues: []interface{}{"Quay Organization", quayOrganizationName},
+			Error:        err,
+		})
+	}
+
+	return reconcile.Result{}, nil
+}
+
+// robotSecretExists determines whether the Secret holding the credentials of a robot account can be linked to a service account. Secrets written by the operator always exist, while those provided by the External Secrets Operator or other means are linked once they appear
+func (r *NamespaceIntegrationReconciler) robotSecretExists(ctx context.Context, namespace string, name string, robotCredentialsSettings qotypes.RobotCredentialsSettings) (bool, error) {
+	if robotCredentialsSettings.Mode == credentials.RobotCredentialsModeSecret || robotCredentialsSettings.Mode == credentials.RobotCredentialsModePushSecret {
+		return true, nil
 	}
 
-	return serviceAccount, updated
+	err := r.CoreComponents.ReconcilerBase.GetClient().Get(ctx, types.NamespacedName{Namespace: namespace, Name: name}, &corev1.Secret{})
+	if errors.IsNotFound(err) {
+		return false, nil
+	}
+
+	return err == nil, err
+}
+
+func (r *NamespaceIntegrationReconciler) updateServiceAccountRobotCredentialsReference(serviceAccount *corev1.ServiceAccount, robotAccountName string, remoteKey string) bool {
+	if serviceAccount.Annotations[constants.RobotAccountAnnotation] == robotAccountName && serviceAccount.Annotations[constants.RobotCredentialsRemoteKeyAnnotation] == remoteKey {
+		return false
+	}
+
+	if serviceAccount.Annotations == nil {
+		serviceAccount.Annotations = map[string]string{}
+	}
+
+	serviceAccount.Annotations[constants.RobotAccountAnnotation] = robotAccountName
+	serviceAccount.Annotations[constants.RobotCredentialsRemoteKeyAnnotation] = remoteKey
+
+	return true
 }
 
 // SetupWithManager sets up the controller with the Manager.
 func (r *NamespaceIntegrationReconciler) SetupWithManager(mgr ctrl.Manager) error {
-	//Retriggers a reconcilation of a namespace upon a change to an ImageStream within a namespace. Currently only supports adding repositories to Quay
-	imageStreamToNamespace := handler.MapFunc(
+	//Retriggers a reconcilation of a namespace upon a change to an ImageStream, RoleBinding or ResourceQuota within a namespace
+	objectToNamespace := handler.MapFunc(
 		func(a client.Object) []reconcile.Request {
 			res := []reconcile.Request{}
 			res = append(res, reconcile.Request{
@@ -541,8 +2371,120 @@ func (r *NamespaceIntegrationReconciler) SetupWithManager(mgr ctrl.Manager) erro
 			return res
 		})
 
+	// Retriggers a reconciliation of every managed namespace upon a change to the credentials Secret of the QuayIntegration, or of a namespace upon the appearance of a robot account Secret provided by other means or a change to a pull secret merged into those of the operator
+	secretToNamespaces := handler.MapFunc(
+		func(a client.Object) []reconcile.Request {
+			quayIntegrations := quayv1.QuayIntegrationList{}
+			if err := r.CoreComponents.ReconcilerBase.GetClient().List(context.TODO(), &quayIntegrations); err != nil || len(quayIntegrations.Items) != 1 {
+				return nil
+			}
+
+			if isProvidedRobotAccountSecret(&quayIntegrations.Items[0], a) || isMergedPullSecret(&quayIntegrations.Items[0], a) {
+				return []reconcile.Request{{NamespacedName: types.NamespacedName{Name: a.GetNamespace()}}}
+			}
+
+			if !quayIntegrations.Items[0].IsCredentialsSecret(a) {
+				return nil
+			}
+
+			namespaces := corev1.NamespaceList{}
+			if err := r.CoreComponents.ReconcilerBase.GetClient().List(context.TODO(), &namespaces); err != nil {
+				return nil
+			}
+
+			res := []reconcile.Request{}
+			for _, namespace := range namespaces.Items {
+				if quayIntegrations.Items[0].IsAllowedNamespace(namespace.Name) {
+					res = append(res, reconcile.Request{NamespacedName: types.NamespacedName{Name: namespace.Name}})
+				}
+			}
+			return res
+		})
+
+	// Retriggers a reconciliation of every namespace managed by a deleted QuayIntegration, or of every allowed namespace once the QuayIntegration claims its ClusterID
+	quayIntegrationToNamespaces := handler.MapFunc(
+		func(a client.Object) []reconcile.Request {
+			namespaces := corev1.NamespaceList{}
+			if err := r.CoreComponents.ReconcilerBase.GetClient().List(context.TODO(), &namespaces); err != nil {
+				return nil
+			}
+
+			quayIntegration, ok := a.(*quayv1.QuayIntegration)
+			if !ok {
+				return nil
+			}
+
+			res := []reconcile.Request{}
+			for i := range namespaces.Items {
+				if util.HasFinalizer(&namespaces.Items[i], constants.NamespaceFinalizer) || (!util.IsBeingDeleted(quayIntegration) && quayIntegration.IsAllowedNamespace(namespaces.Items[i].Name)) {
+					res = append(res, reconcile.Request{NamespacedName: types.NamespacedName{Name: namespaces.Items[i].Name}})
+				}
+			}
+			return res
+		})
+
+	quayIntegrationPredicates := predicate.Funcs{
+		CreateFunc: func(e event.CreateEvent) bool {
+			return false
+		},
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			oldQuayIntegration, oldOk := e.ObjectOld.(*quayv1.QuayIntegration)
+			newQuayIntegration, newOk := e.ObjectNew.(*quayv1.QuayIntegration)
+			if !oldOk || !newOk {
+				return false
+			}
+
+			// Namespaces are onboarded or offboarded when the allowlist or denylist changes
+			if !slices.Equal(oldQuayIntegration.Spec.AllowlistNamespaces, newQuayIntegration.Spec.AllowlistNamespaces) || !slices.Equal(oldQuayIntegration.Spec.DenylistNamespaces, newQuayIntegration.Spec.DenylistNamespaces) {
+				return true
+			}
+
+			return !core.IsClusterClaimed(oldQuayIntegration) && core.IsClusterClaimed(newQuayIntegration)
+		},
+		GenericFunc: func(e event.GenericEvent) bool {
+			return false
+		},
+	}
+
 	return ctrl.NewControllerManagedBy(mgr).
 		For(&corev1.Namespace{}).
-		Watches(&source.Kind{Type: &imagev1.ImageStream{}}, handler.EnqueueRequestsFromMapFunc(imageStreamToNamespace)).
+		Watches(&source.Kind{Type: &imagev1.ImageStream{}}, handler.EnqueueRequestsFromMapFunc(objectToNamespace)).
+		Watches(&source.Kind{Type: &rbacv1.RoleBinding{}}, handler.EnqueueRequestsFromMapFunc(objectToNamespace)).
+		Watches(&source.Kind{Type: &corev1.ResourceQuota{}}, handler.EnqueueRequestsFromMapFunc(objectToNamespace)).
+		Watches(&source.Kind{Type: &corev1.Secret{}}, handler.EnqueueRequestsFromMapFunc(secretToNamespaces)).
+		Watches(&source.Kind{Type: &quayv1.QuayIntegration{}}, handler.EnqueueRequestsFromMapFunc(quayIntegrationToNamespaces), builder.WithPredicates(quayIntegrationPredicates)).
 		Complete(r)
 }
+
+// isProvidedRobotAccountSecret determines whether a Secret holds the credentials of a robot account that are provided by the External Secrets Operator or other means
+func isProvidedRobotAccountSecret(quayIntegration *quayv1.QuayIntegration, secret client.Object) bool {
+	if quayIntegration.Spec.RobotCredentials == nil || (quayIntegration.Spec.RobotCredentials.Mode != credentials.RobotCredentialsModeExternalSecret && quayIntegration.Spec.RobotCredentials.Mode != credentials.RobotCredentialsModeReference) {
+		return false
+	}
+
+	if !quayIntegration.IsAllowedNamespace(secret.GetNamespace()) {
+		return false
+	}
+
+	for serviceAccount := range QuayServiceAccountPermissionMatrix {
+		if secret.GetName() == utils.GenerateDockerJsonSecretNameForServiceAccount(string(serviceAccount), quayIntegration.Spec.ClusterID) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// isMergedPullSecret determines whether a Secret is a dockerconfigjson Secret that may be merged into the Secrets written by the operator
+func isMergedPullSecret(quayIntegration *quayv1.QuayIntegration, object client.Object) bool {
+	if quayIntegration.Spec.PullSecrets == nil || !quayIntegration.Spec.PullSecrets.Merge {
+		return false
+	}
+
+	secret, ok := object.(*corev1.Secret)
+	if !ok || secret.Type != corev1.SecretTypeDockerConfigJson || secret.Labels[constants.ManagedByLabel] == constants.ManagedByLabelValue {
+		return false
+	}
+
+	return quayIntegration.IsAllowedNamespace(secret.Namespace)
+}
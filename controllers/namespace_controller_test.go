@@ -0,0 +1,419 @@
+package controllers
+
+import (
+	"context"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	imagev1 "github.com/openshift/api/image/v1"
+	"github.com/redhat-cop/operator-utils/pkg/util"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
+
+	quayv1 "github.com/quay/quay-bridge-operator/api/v1"
+	qclient "github.com/quay/quay-bridge-operator/pkg/client/quay"
+	"github.com/quay/quay-bridge-operator/pkg/client/quay/fakequay"
+	"github.com/quay/quay-bridge-operator/pkg/constants"
+	qotypes "github.com/quay/quay-bridge-operator/pkg/types"
+	"github.com/quay/quay-bridge-operator/pkg/utils"
+)
+
+var _ = Describe("Namespace controller", Ordered, func() {
+
+	ctx := context.Background()
+
+	var quayIntegration *quayv1.QuayIntegration
+	var namespace *corev1.Namespace
+	var quayOrganizationName string
+
+	BeforeAll(func() {
+		quayIntegration = createQuayIntegration(ctx, "namespaces", "namespaces")
+	})
+
+	AfterAll(func() {
+		deleteQuayIntegration(ctx, quayIntegration)
+	})
+
+	It("onboards a namespace", func() {
+		namespace = createNamespace(ctx, "onboarding")
+		quayOrganizationName = quayIntegration.GenerateQuayOrganizationNameFromNamespace(namespace.Name)
+
+		Eventually(func(g Gomega) {
+			g.Expect(k8sClient.Get(ctx, types.NamespacedName{Name: namespace.Name}, namespace)).To(Succeed())
+			g.Expect(util.HasFinalizer(namespace, constants.NamespaceFinalizer)).To(BeTrue())
+		}, timeout, interval).Should(Succeed())
+
+		Eventually(func(g Gomega) {
+			_, found := quayServer.Organization(quayOrganizationName)
+			g.Expect(found).To(BeTrue())
+
+			for serviceAccountName, role := range QuayServiceAccountPermissionMatrix {
+				robotAccount, found := quayServer.RobotAccount(quayOrganizationName, string(serviceAccountName))
+				g.Expect(found).To(BeTrue())
+				g.Expect(qclient.IsRobotAccountInPrototypeByRole(quayServer.Prototypes(quayOrganizationName), robotAccount.Name, string(role))).To(BeTrue())
+
+				secretName := utils.GenerateDockerJsonSecretNameForServiceAccount(string(serviceAccountName), quayIntegration.Spec.ClusterID)
+
+				secret := &corev1.Secret{}
+				g.Expect(k8sClient.Get(ctx, types.NamespacedName{Namespace: namespace.Name, Name: secretName}, secret)).To(Succeed())
+				g.Expect(secret.Type).To(Equal(corev1.SecretTypeDockerConfigJson))
+				g.Expect(secret.Data).To(HaveKey(corev1.DockerConfigJsonKey))
+
+				serviceAccount := &corev1.ServiceAccount{}
+				g.Expect(k8sClient.Get(ctx, types.NamespacedName{Namespace: namespace.Name, Name: string(serviceAccountName)}, serviceAccount)).To(Succeed())
+				g.Expect(utils.ObjectReferenceNameExists(serviceAccount.Secrets, secretName)).To(BeTrue())
+				g.Expect(utils.LocalObjectReferenceNameExists(serviceAccount.ImagePullSecrets, secretName)).To(BeTrue())
+			}
+		}, timeout, interval).Should(Succeed())
+	})
+
+	It("creates a repository for an ImageStream", func() {
+		imageStream := &imagev1.ImageStream{ObjectMeta: metav1.ObjectMeta{Name: "app", Namespace: namespace.Name}}
+		Expect(k8sClient.Create(ctx, imageStream)).To(Succeed())
+
+		Eventually(func() bool {
+			_, found := quayServer.Repository(quayOrganizationName, imageStream.Name)
+			return found
+		}, timeout, interval).Should(BeTrue())
+
+		Eventually(func(g Gomega) {
+			g.Expect(k8sClient.Get(ctx, types.NamespacedName{Namespace: namespace.Name, Name: imageStream.Name}, imageStream)).To(Succeed())
+			g.Expect(imageStream.Annotations).To(HaveKey(constants.RepositoryOwnerAnnotation))
+		}, timeout, interval).Should(Succeed())
+	})
+
+	It("mirrors a repository with the builder robot account", func() {
+		imageStream := &imagev1.ImageStream{ObjectMeta: metav1.ObjectMeta{
+			Name:        "mirrored",
+			Namespace:   namespace.Name,
+			Annotations: map[string]string{constants.MirrorSourceAnnotation: "registry.example.com/upstream/mirrored"},
+		}}
+		Expect(k8sClient.Create(ctx, imageStream)).To(Succeed())
+
+		Eventually(func(g Gomega) {
+			mirror, found := quayServer.RepositoryMirror(quayOrganizationName, imageStream.Name)
+			g.Expect(found).To(BeTrue())
+			g.Expect(mirror.ExternalReference).To(Equal("registry.example.com/upstream/mirrored"))
+			g.Expect(mirror.RobotUsername).To(Equal(utils.FormatOrganizationRobotAccountName(quayOrganizationName, string(qotypes.BuilderOpenShiftServiceAccount))))
+		}, timeout, interval).Should(Succeed())
+	})
+
+	It("manages only the auto-prune policy it created", func() {
+		userPolicyUUID := quayServer.AddAutoPrunePolicy(quayOrganizationName, "app", qclient.AutoPrunePolicy{Method: qclient.AutoPruneMethodCreationDate, Value: intstr.FromString("7d")})
+
+		imageStream := &imagev1.ImageStream{}
+		Expect(k8sClient.Get(ctx, types.NamespacedName{Namespace: namespace.Name, Name: "app"}, imageStream)).To(Succeed())
+		imageStream.Annotations[constants.TagRetentionKeepLastTagsAnnotation] = "5"
+		Expect(k8sClient.Update(ctx, imageStream)).To(Succeed())
+
+		Eventually(func(g Gomega) {
+			g.Expect(k8sClient.Get(ctx, types.NamespacedName{Namespace: namespace.Name, Name: "app"}, imageStream)).To(Succeed())
+			g.Expect(imageStream.Annotations).To(HaveKey(constants.TagRetentionPolicyAnnotation))
+
+			policies := quayServer.AutoPrunePolicies(quayOrganizationName, "app")
+			g.Expect(policies).To(HaveLen(2))
+			g.Expect(policies[0].UUID).To(Equal(userPolicyUUID))
+			g.Expect(policies[1].UUID).To(Equal(imageStream.Annotations[constants.TagRetentionPolicyAnnotation]))
+			g.Expect(policies[1].Method).To(Equal(qclient.AutoPruneMethodNumberOfTags))
+		}, timeout, interval).Should(Succeed())
+
+		delete(imageStream.Annotations, constants.TagRetentionKeepLastTagsAnnotation)
+		Expect(k8sClient.Update(ctx, imageStream)).To(Succeed())
+
+		Eventually(func(g Gomega) {
+			g.Expect(k8sClient.Get(ctx, types.NamespacedName{Namespace: namespace.Name, Name: "app"}, imageStream)).To(Succeed())
+			g.Expect(imageStream.Annotations).NotTo(HaveKey(constants.TagRetentionPolicyAnnotation))
+
+			policies := quayServer.AutoPrunePolicies(quayOrganizationName, "app")
+			g.Expect(policies).To(HaveLen(1))
+			g.Expect(policies[0].UUID).To(Equal(userPolicyUUID))
+		}, timeout, interval).Should(Succeed())
+	})
+
+	It("applies the untagged expiration to the whole organization", func() {
+		Expect(k8sClient.Get(ctx, types.NamespacedName{Name: namespace.Name}, namespace)).To(Succeed())
+		if namespace.Annotations == nil {
+			namespace.Annotations = map[string]string{}
+		}
+		namespace.Annotations[constants.UntaggedExpirationAnnotation] = "1d"
+		Expect(k8sClient.Update(ctx, namespace)).To(Succeed())
+
+		Eventually(func() int {
+			organization, _ := quayServer.Organization(quayOrganizationName)
+			return organization.TagExpirationS
+		}, timeout, interval).Should(Equal(86400))
+	})
+
+	It("tolerates Quay versions without auto-prune policies", func() {
+		quayServer.InjectFault(fakequay.Fault{Method: "GET", PathPrefix: "/api/v1/repository/" + quayOrganizationName + "/web/autoprunepolicy", StatusCode: 404})
+		defer quayServer.ClearFaults()
+
+		imageStream := &imagev1.ImageStream{ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: namespace.Name, Annotations: map[string]string{constants.TagRetentionKeepLastTagsAnnotation: "5"}}}
+		Expect(k8sClient.Create(ctx, imageStream)).To(Succeed())
+
+		Eventually(func() []fakequay.Call {
+			return quayServer.CallsTo("GET", "/api/v1/repository/"+quayOrganizationName+"/web/autoprunepolicy")
+		}, timeout, interval).ShouldNot(BeEmpty())
+
+		// A missing auto-prune API is not reported as a reconcile error
+		Consistently(func(g Gomega) {
+			events := &corev1.EventList{}
+			g.Expect(k8sClient.List(ctx, events)).To(Succeed())
+
+			for _, event := range events.Items {
+				if event.InvolvedObject.Name == namespace.Name {
+					g.Expect(event.Message).NotTo(ContainSubstring("auto-prune"))
+				}
+			}
+		}, 2*time.Second, interval).Should(Succeed())
+
+		Expect(quayServer.AutoPrunePolicies(quayOrganizationName, "web")).To(BeEmpty())
+	})
+
+	It("keeps the organization of an offboarded namespace by default", func() {
+		offboardedNamespace := createNamespace(ctx, "offboarded")
+		waitForOnboarding(ctx, offboardedNamespace.Name, quayIntegration)
+
+		offboardedOrganizationName := quayIntegration.GenerateQuayOrganizationNameFromNamespace(offboardedNamespace.Name)
+
+		Eventually(func() error {
+			if err := k8sClient.Get(ctx, types.NamespacedName{Name: quayIntegration.Name}, quayIntegration); err != nil {
+				return err
+			}
+			quayIntegration.Spec.DenylistNamespaces = append(quayIntegration.Spec.DenylistNamespaces, offboardedNamespace.Name)
+			return k8sClient.Update(ctx, quayIntegration)
+		}, timeout, interval).Should(Succeed())
+
+		Eventually(func(g Gomega) {
+			g.Expect(k8sClient.Get(ctx, types.NamespacedName{Name: offboardedNamespace.Name}, offboardedNamespace)).To(Succeed())
+			g.Expect(util.HasFinalizer(offboardedNamespace, constants.NamespaceFinalizer)).To(BeFalse())
+		}, timeout, interval).Should(Succeed())
+
+		_, found := quayServer.Organization(offboardedOrganizationName)
+		Expect(found).To(BeTrue())
+
+		_, found = quayServer.RobotAccount(offboardedOrganizationName, string(qotypes.BuilderOpenShiftServiceAccount))
+		Expect(found).To(BeFalse())
+	})
+
+	It("removes only the team members it added", func() {
+		quayServer.AddUser("alice")
+		quayServer.AddUser("bob")
+
+		Eventually(func() error {
+			if err := k8sClient.Get(ctx, types.NamespacedName{Name: quayIntegration.Name}, quayIntegration); err != nil {
+				return err
+			}
+			quayIntegration.Spec.TeamSync = &quayv1.TeamSync{}
+			return k8sClient.Update(ctx, quayIntegration)
+		}, timeout, interval).Should(Succeed())
+
+		roleBinding := &rbacv1.RoleBinding{
+			ObjectMeta: metav1.ObjectMeta{Name: "alice-edit", Namespace: namespace.Name},
+			RoleRef:    rbacv1.RoleRef{APIGroup: rbacv1.GroupName, Kind: "ClusterRole", Name: "edit"},
+			Subjects:   []rbacv1.Subject{{APIGroup: rbacv1.GroupName, Kind: rbacv1.UserKind, Name: "alice"}},
+		}
+		Expect(k8sClient.Create(ctx, roleBinding)).To(Succeed())
+
+		Eventually(func() []string {
+			return quayServer.TeamMembers(quayOrganizationName, string(qotypes.EditorsQuayTeam))
+		}, timeout, interval).Should(ContainElement("alice"))
+
+		Eventually(func(g Gomega) {
+			g.Expect(k8sClient.Get(ctx, types.NamespacedName{Name: namespace.Name}, namespace)).To(Succeed())
+			g.Expect(namespace.Annotations).To(HaveKeyWithValue(constants.TeamMembersAnnotationPrefix+string(qotypes.EditorsQuayTeam), "alice"))
+		}, timeout, interval).Should(Succeed())
+
+		quayServer.AddTeamMember(quayOrganizationName, string(qotypes.EditorsQuayTeam), "bob")
+
+		Expect(k8sClient.Delete(ctx, roleBinding)).To(Succeed())
+
+		Eventually(func() []string {
+			return quayServer.TeamMembers(quayOrganizationName, string(qotypes.EditorsQuayTeam))
+		}, timeout, interval).ShouldNot(ContainElement("alice"))
+
+		Consistently(func() []string {
+			return quayServer.TeamMembers(quayOrganizationName, string(qotypes.EditorsQuayTeam))
+		}, 2*time.Second, interval).Should(ContainElement("bob"))
+	})
+
+	It("keeps the namespace until its organization is deleted", func() {
+		quayServer.InjectFault(fakequay.Fault{Method: "DELETE", PathPrefix: "/api/v1/organization/" + quayOrganizationName, StatusCode: 503})
+
+		Expect(k8sClient.Delete(ctx, namespace)).To(Succeed())
+
+		Eventually(func() []fakequay.Call {
+			return quayServer.CallsTo("DELETE", "/api/v1/organization/"+quayOrganizationName)
+		}, timeout, interval).ShouldNot(BeEmpty())
+
+		Consistently(func(g Gomega) {
+			g.Expect(k8sClient.Get(ctx, types.NamespacedName{Name: namespace.Name}, namespace)).To(Succeed())
+			g.Expect(util.HasFinalizer(namespace, constants.NamespaceFinalizer)).To(BeTrue())
+		}, 2*time.Second, interval).Should(Succeed())
+
+		Expect(namespace.Status.Conditions).To(ContainElement(And(
+			HaveField("Type", corev1.NamespaceConditionType(constants.FinalizationBlockedConditionType)),
+			HaveField("Status", corev1.ConditionTrue),
+			HaveField("Reason", "CleanupFailed"),
+		)))
+
+		quayServer.ClearFaults()
+
+		// envtest does not finalize namespaces, so the namespace remains terminating once the finalizer of the operator is removed
+		Eventually(func(g Gomega) {
+			err := k8sClient.Get(ctx, types.NamespacedName{Name: namespace.Name}, namespace)
+			if apierrors.IsNotFound(err) {
+				return
+			}
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(util.HasFinalizer(namespace, constants.NamespaceFinalizer)).To(BeFalse())
+		}, timeout, interval).Should(Succeed())
+
+		_, found := quayServer.Organization(quayOrganizationName)
+		Expect(found).To(BeFalse())
+	})
+
+	It("leaves the organization in place when finalization is forced", func() {
+		forcedNamespace := createNamespace(ctx, "forced")
+		forcedOrganizationName := quayIntegration.GenerateQuayOrganizationNameFromNamespace(forcedNamespace.Name)
+		waitForOnboarding(ctx, forcedNamespace.Name, quayIntegration)
+
+		quayServer.InjectFault(fakequay.Fault{Method: "DELETE", PathPrefix: "/api/v1/organization/" + forcedOrganizationName, StatusCode: 503})
+		defer quayServer.ClearFaults()
+
+		Expect(k8sClient.Delete(ctx, forcedNamespace)).To(Succeed())
+
+		Eventually(func(g Gomega) {
+			g.Expect(k8sClient.Get(ctx, types.NamespacedName{Name: forcedNamespace.Name}, forcedNamespace)).To(Succeed())
+			g.Expect(forcedNamespace.Status.Conditions).To(ContainElement(HaveField("Type", corev1.NamespaceConditionType(constants.FinalizationBlockedConditionType))))
+		}, timeout, interval).Should(Succeed())
+
+		Eventually(func() error {
+			if err := k8sClient.Get(ctx, types.NamespacedName{Name: forcedNamespace.Name}, forcedNamespace); err != nil {
+				return err
+			}
+			forcedNamespace.Annotations = map[string]string{constants.ForceFinalizeAnnotation: "true"}
+			return k8sClient.Update(ctx, forcedNamespace)
+		}, timeout, interval).Should(Succeed())
+
+		Eventually(func(g Gomega) {
+			g.Expect(k8sClient.Get(ctx, types.NamespacedName{Name: forcedNamespace.Name}, forcedNamespace)).To(Succeed())
+			g.Expect(util.HasFinalizer(forcedNamespace, constants.NamespaceFinalizer)).To(BeFalse())
+		}, timeout, interval).Should(Succeed())
+
+		_, found := quayServer.Organization(forcedOrganizationName)
+		Expect(found).To(BeTrue())
+	})
+})
+
+var _ = Describe("Namespace controller with a shared organization", Ordered, func() {
+
+	ctx := context.Background()
+
+	const sharedOrganizationName = "platform"
+
+	var quayIntegration *quayv1.QuayIntegration
+	var namespace *corev1.Namespace
+	var target qotypes.OrganizationTarget
+
+	BeforeAll(func() {
+		quayServer.AddOrganization(sharedOrganizationName)
+
+		quayIntegration = createQuayIntegration(ctx, "shared", "shared")
+
+		Eventually(func() error {
+			if err := k8sClient.Get(ctx, types.NamespacedName{Name: quayIntegration.Name}, quayIntegration); err != nil {
+				return err
+			}
+			quayIntegration.Spec.SharedOrganizations = []quayv1.SharedOrganization{
+				{Name: sharedOrganizationName, NamespaceSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"team": "platform"}}},
+			}
+			return k8sClient.Update(ctx, quayIntegration)
+		}, timeout, interval).Should(Succeed())
+	})
+
+	AfterAll(func() {
+		deleteQuayIntegration(ctx, quayIntegration)
+	})
+
+	It("creates robot accounts of the namespace in the shared organization", func() {
+		namespace = &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{
+			Name:        "shared-app",
+			Labels:      map[string]string{"team": "platform"},
+			Annotations: map[string]string{constants.TargetOrganizationAnnotation: sharedOrganizationName},
+		}}
+		Expect(k8sClient.Create(ctx, namespace)).To(Succeed())
+
+		for serviceAccountName := range QuayServiceAccountPermissionMatrix {
+			Expect(k8sClient.Create(ctx, &corev1.ServiceAccount{ObjectMeta: metav1.ObjectMeta{Name: string(serviceAccountName), Namespace: namespace.Name}})).To(Succeed())
+		}
+
+		var err error
+		target, err = utils.GetOrganizationTarget(quayIntegration, namespace)
+		Expect(err).NotTo(HaveOccurred())
+
+		Eventually(func(g Gomega) {
+			for serviceAccountName := range QuayServiceAccountPermissionMatrix {
+				_, found := quayServer.RobotAccount(sharedOrganizationName, target.RobotAccountName(string(serviceAccountName)))
+				g.Expect(found).To(BeTrue())
+			}
+		}, timeout, interval).Should(Succeed())
+
+		Expect(quayServer.Prototypes(sharedOrganizationName)).To(BeEmpty())
+	})
+
+	It("creates a prefixed repository for an ImageStream", func() {
+		imageStream := &imagev1.ImageStream{ObjectMeta: metav1.ObjectMeta{Name: "app", Namespace: namespace.Name}}
+		Expect(k8sClient.Create(ctx, imageStream)).To(Succeed())
+
+		repositoryName := target.RepositoryName(imageStream.Name)
+
+		Eventually(func(g Gomega) {
+			_, found := quayServer.Repository(sharedOrganizationName, repositoryName)
+			g.Expect(found).To(BeTrue())
+
+			permissions := quayServer.RepositoryPermissions(sharedOrganizationName, repositoryName)
+			g.Expect(permissions).To(HaveKey(utils.FormatOrganizationRobotAccountName(sharedOrganizationName, target.RobotAccountName(string(qotypes.BuilderOpenShiftServiceAccount)))))
+		}, timeout, interval).Should(Succeed())
+	})
+
+	It("mirrors a repository with the builder robot account of the namespace", func() {
+		imageStream := &imagev1.ImageStream{ObjectMeta: metav1.ObjectMeta{
+			Name:        "mirrored",
+			Namespace:   namespace.Name,
+			Annotations: map[string]string{constants.MirrorSourceAnnotation: "registry.example.com/upstream/mirrored"},
+		}}
+		Expect(k8sClient.Create(ctx, imageStream)).To(Succeed())
+
+		Eventually(func(g Gomega) {
+			mirror, found := quayServer.RepositoryMirror(sharedOrganizationName, target.RepositoryName(imageStream.Name))
+			g.Expect(found).To(BeTrue())
+			g.Expect(mirror.RobotUsername).To(Equal(utils.FormatOrganizationRobotAccountName(sharedOrganizationName, target.RobotAccountName(string(qotypes.BuilderOpenShiftServiceAccount)))))
+		}, timeout, interval).Should(Succeed())
+	})
+
+	It("deletes only the resources of the namespace from the shared organization", func() {
+		quayServer.AddRepository(sharedOrganizationName, "other_app")
+
+		Expect(k8sClient.Delete(ctx, namespace)).To(Succeed())
+
+		Eventually(func(g Gomega) {
+			_, found := quayServer.Repository(sharedOrganizationName, target.RepositoryName("app"))
+			g.Expect(found).To(BeFalse())
+
+			_, found = quayServer.RobotAccount(sharedOrganizationName, target.RobotAccountName(string(qotypes.BuilderOpenShiftServiceAccount)))
+			g.Expect(found).To(BeFalse())
+		}, timeout, interval).Should(Succeed())
+
+		_, found := quayServer.Organization(sharedOrganizationName)
+		Expect(found).To(BeTrue())
+
+		_, found = quayServer.Repository(sharedOrganizationName, "other_app")
+		Expect(found).To(BeTrue())
+	})
+})
@@ -19,15 +19,32 @@ package controllers
 import (
 	"context"
 	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
 
 	"github.com/go-logr/logr"
 
 	quayv1 "github.com/quay/quay-bridge-operator/api/v1"
+	qclient "github.com/quay/quay-bridge-operator/pkg/client/quay"
+	"github.com/quay/quay-bridge-operator/pkg/constants"
+	"github.com/quay/quay-bridge-operator/pkg/core"
+	"github.com/quay/quay-bridge-operator/pkg/credentials"
+	qotypes "github.com/quay/quay-bridge-operator/pkg/types"
+	"github.com/quay/quay-bridge-operator/pkg/utils"
 	"github.com/redhat-cop/operator-utils/pkg/util"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
 )
 
 // QuayIntegrationReconciler reconciles a QuayIntegration object
@@ -55,10 +72,42 @@ func (r *QuayIntegrationReconciler) Reconcile(ctx context.Context, req ctrl.Requ
 		return reconcile.Result{}, err
 	}
 
+	if util.IsBeingDeleted(instance) {
+		if !util.HasFinalizer(instance, constants.QuayIntegrationFinalizer) {
+			return reconcile.Result{}, nil
+		}
+
+		return r.teardown(ctx, instance)
+	}
+
+	// Finalizer Management
+	if !util.HasFinalizer(instance, constants.QuayIntegrationFinalizer) {
+		util.AddFinalizer(instance, constants.QuayIntegrationFinalizer)
+		if err := r.GetClient().Update(ctx, instance); err != nil {
+			logger.Error(err, "Failed to add QuayIntegration finalizer")
+			return reconcile.Result{Requeue: true}, err
+		}
+		return reconcile.Result{}, nil
+	}
+
+	// The claim of the ClusterID is renewed on every reconciliation
+	if err := r.syncClusterClaim(ctx, instance); err != nil {
+		logger.Error(err, "Failed to synchronize the claim of the ClusterID")
+
+		if statusErr := r.recordClusterClaimFailure(ctx, instance, err); statusErr != nil {
+			logger.Error(statusErr, "Failed to update QuayIntegration status")
+		}
+
+		return reconcile.Result{}, err
+	}
+
+	// Rotating the credentials triggers revalidation even though the spec is unchanged
+	credentialsVersion := r.getCredentialsVersion(ctx, instance)
+
 	specBytes, _ := json.Marshal(instance.Spec)
-	if r.LastSeenSpec[req.NamespacedName] == string(specBytes) {
-		logger.Info("No changes to QuayIntegration spec, skipping reconciliation")
-		return reconcile.Result{Requeue: false}, nil
+	if r.LastSeenSpec[req.NamespacedName] == string(specBytes)+credentialsVersion {
+		logger.Info("No changes to QuayIntegration spec or credentials, skipping reconciliation")
+		return r.requeueResult(instance), nil
 	}
 
 	instance, err = instance.SetStatus(&quayv1.QuayIntegrationStatus{})
@@ -71,6 +120,8 @@ func (r *QuayIntegrationReconciler) Reconcile(ctx context.Context, req ctrl.Requ
 		return reconcile.Result{Requeue: true}, err
 	}
 
+	r.validateCredentials(ctx, instance)
+
 	err = r.GetClient().Status().Update(ctx, instance)
 	if err != nil {
 		logger.Error(err, "Failed to update QuayIntegration status")
@@ -80,14 +131,407 @@ func (r *QuayIntegrationReconciler) Reconcile(ctx context.Context, req ctrl.Requ
 	logger.Info("Updated QuayIntegration status")
 
 	specBytes, _ = json.Marshal(instance.Spec)
-	r.LastSeenSpec[req.NamespacedName] = string(specBytes)
+	r.LastSeenSpec[req.NamespacedName] = string(specBytes) + credentialsVersion
 
-	return reconcile.Result{Requeue: false}, nil
+	return r.requeueResult(instance), nil
+}
+
+// teardown removes the artifacts of the operator from the managed namespaces in batches, reporting progress in the status, and releases the QuayIntegration once every namespace is torn down
+func (r *QuayIntegrationReconciler) teardown(ctx context.Context, instance *quayv1.QuayIntegration) (reconcile.Result, error) {
+	logger := r.Log.WithValues("quayintegration", instance.Name)
+
+	namespaces := corev1.NamespaceList{}
+	if err := r.GetClient().List(ctx, &namespaces); err != nil {
+		logger.Error(err, "Failed to list Namespaces")
+		return reconcile.Result{Requeue: true}, err
+	}
+
+	teardownStatus := instance.Status.Teardown
+	if teardownStatus == nil {
+		teardownStatus = &quayv1.TeardownStatus{}
+	}
+
+	// Namespaces that failed during the last attempt are retried after the others so they do not hold up the teardown
+	previouslyFailedNamespaces := teardownStatus.FailedNamespaces
+
+	managedNamespaces := []*corev1.Namespace{}
+	for i := range namespaces.Items {
+		if util.HasFinalizer(&namespaces.Items[i], constants.NamespaceFinalizer) {
+			managedNamespaces = append(managedNamespaces, &namespaces.Items[i])
+		}
+	}
+
+	slices.SortStableFunc(managedNamespaces, func(a, b *corev1.Namespace) int {
+		return compareBool(slices.Contains(previouslyFailedNamespaces, a.Name), slices.Contains(previouslyFailedNamespaces, b.Name))
+	})
+
+	if instance.Status.Teardown == nil {
+		teardownStatus.TotalNamespaces = len(managedNamespaces)
+	}
+
+	coreComponents := core.NewCoreComponents(r.ReconcilerBase)
+	namespaceReconciler := &NamespaceIntegrationReconciler{CoreComponents: coreComponents, Log: r.Log}
+
+	var quayClient *qclient.Client
+	if len(managedNamespaces) > 0 {
+		quayClient, _, _ = coreComponents.GetQuayClient(ctx, instance, instance)
+	}
+
+	teardownStatus.FailedNamespaces = nil
+	teardownStatus.RemainingNamespaces = len(managedNamespaces)
+
+	for i, namespace := range managedNamespaces {
+		if i == constants.TeardownBatchSize {
+			break
+		}
+
+		message, err := namespaceReconciler.offboardNamespace(ctx, namespace, quayClient, instance, instance.Spec.OrganizationDeletionPolicy)
+		if err != nil {
+			logger.Error(err, "Failed to tear down Namespace", "Namespace", namespace.Name)
+			teardownStatus.FailedNamespaces = append(teardownStatus.FailedNamespaces, namespace.Name)
+			continue
+		}
+
+		logger.Info("Tore down Namespace", "Namespace", namespace.Name, "Result", message)
+		r.GetRecorder().Event(namespace, "Normal", "NamespaceOffboarded", fmt.Sprintf("The QuayIntegration was deleted. %s", message))
+		teardownStatus.RemainingNamespaces--
+	}
+
+	condition := metav1.Condition{
+		Type:               constants.TeardownConditionType,
+		Status:             metav1.ConditionTrue,
+		Reason:             "InProgress",
+		Message:            fmt.Sprintf("Tore down %d of %d namespaces", teardownStatus.TotalNamespaces-teardownStatus.RemainingNamespaces, teardownStatus.TotalNamespaces),
+		ObservedGeneration: instance.Generation,
+	}
+
+	if len(teardownStatus.FailedNamespaces) > 0 {
+		condition.Reason = "Failed"
+		condition.Message = fmt.Sprintf("%s. Failed to tear down namespaces: %s. Set the %s annotation to complete the teardown and leave their Quay resources in place", condition.Message, strings.Join(teardownStatus.FailedNamespaces, ", "), constants.ForceFinalizeAnnotation)
+	} else if teardownStatus.RemainingNamespaces == 0 {
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = "Completed"
+	}
+
+	apimeta.SetStatusCondition(&instance.Status.Conditions, condition)
+	instance.Status.Teardown = teardownStatus
+
+	instance, _ = instance.SetStatus(&instance.Status)
+	if err := r.GetClient().Status().Update(ctx, instance); err != nil {
+		logger.Error(err, "Failed to update QuayIntegration status")
+		return reconcile.Result{Requeue: true}, err
+	}
+
+	if len(teardownStatus.FailedNamespaces) > 0 {
+		r.GetRecorder().Event(instance, "Warning", "TeardownFailed", condition.Message)
+		return reconcile.Result{RequeueAfter: constants.RequeuePeriod}, nil
+	}
+
+	if teardownStatus.RemainingNamespaces > 0 {
+		return reconcile.Result{Requeue: true}, nil
+	}
+
+	util.RemoveFinalizer(instance, constants.QuayIntegrationFinalizer)
+	if err := r.GetClient().Update(ctx, instance); err != nil {
+		logger.Error(err, "Failed to remove QuayIntegration finalizer")
+		return reconcile.Result{Requeue: true}, err
+	}
+
+	delete(r.LastSeenSpec, types.NamespacedName{Name: instance.Name})
+
+	// Releasing the claim allows another cluster to claim the ClusterID without waiting for it to expire
+	if err := r.releaseClusterClaim(ctx, instance); err != nil {
+		logger.Error(err, "Failed to release the claim of the ClusterID")
+	}
+
+	logger.Info("Completed QuayIntegration teardown", "Namespaces", teardownStatus.TotalNamespaces)
+
+	return reconcile.Result{}, nil
+}
+
+// compareBool orders false before true
+func compareBool(a bool, b bool) int {
+	if a == b {
+		return 0
+	}
+
+	if a {
+		return 1
+	}
+
+	return -1
+}
+
+// getCredentialsVersion returns a fingerprint of the credentials of the QuayIntegration
+func (r *QuayIntegrationReconciler) getCredentialsVersion(ctx context.Context, instance *quayv1.QuayIntegration) string {
+	coreComponents := core.NewCoreComponents(r.ReconcilerBase)
+
+	quayCredentials, err := coreComponents.GetQuayCredentials(ctx, instance)
+	if err != nil {
+		return ""
+	}
+
+	return credentials.Fingerprint(quayCredentials)
+}
+
+// requeueResult requeues QuayIntegrations so that the claim of their ClusterID is renewed and, when their credentials are not watched, rotated credentials are revalidated
+func (r *QuayIntegrationReconciler) requeueResult(instance *quayv1.QuayIntegration) reconcile.Result {
+	requeueAfter := constants.ClusterClaimRenewPeriod
+	if !core.IsCredentialsSourceWatched(instance) && constants.CredentialsRevalidationPeriod < requeueAfter {
+		requeueAfter = constants.CredentialsRevalidationPeriod
+	}
+
+	// Claims are renewed when due rather than a full period after the last reconciliation, well before their lease expires
+	if instance.Status.ClusterClaim != nil && instance.Status.ClusterClaim.RenewTime != nil {
+		if renewAfter := time.Until(instance.Status.ClusterClaim.RenewTime.Add(constants.ClusterClaimRenewPeriod)); renewAfter < requeueAfter {
+			requeueAfter = renewAfter
+		}
+
+		if requeueAfter < constants.RequeuePeriod {
+			requeueAfter = constants.RequeuePeriod
+		}
+	}
+
+	return reconcile.Result{RequeueAfter: requeueAfter}
+}
+
+// syncClusterClaim claims the ClusterID of the QuayIntegration in Quay through the metadata of a robot account of a dedicated organization, acting as a lease renewed by its holder. The live claim of another cluster is only taken over when its holder is named by the takeover annotation, which is removed once the claim is taken over
+func (r *QuayIntegrationReconciler) syncClusterClaim(ctx context.Context, instance *quayv1.QuayIntegration) error {
+	clusterUID, err := r.getClusterUID(ctx)
+	if err != nil {
+		return err
+	}
+
+	coreComponents := core.NewCoreComponents(r.ReconcilerBase)
+
+	// Credentials are reported by validateCredentials
+	quayClient, _, _ := coreComponents.NewQuayClient(ctx, instance, instance)
+	if quayClient == nil {
+		return nil
+	}
+
+	claimOrganizationName := instance.GenerateClusterClaimOrganizationName()
+
+	_, organizationResponse, organizationErr := quayClient.GetOrganizationByName(claimOrganizationName)
+	if organizationErr.Error != nil {
+		return fmt.Errorf("failed to retrieve Quay organization %s: %w", claimOrganizationName, organizationErr.Error)
+	}
+
+	if organizationResponse.StatusCode == 404 {
+		_, createResponse, createErr := quayClient.CreateOrganization(claimOrganizationName, "")
+		if createErr.Error != nil || createResponse.StatusCode != 201 {
+			return fmt.Errorf("failed to create Quay organization %s: %v", claimOrganizationName, createErr.Error)
+		}
+	} else if organizationResponse.StatusCode != 200 {
+		return fmt.Errorf("failed to retrieve Quay organization %s: status code %d", claimOrganizationName, organizationResponse.StatusCode)
+	}
+
+	claimRobotAccount, err := getClusterClaimRobotAccount(quayClient, claimOrganizationName, instance.Spec.ClusterID)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	claim := utils.GetClusterClaim(claimRobotAccount, clusterUID, instance.Annotations[constants.ClusterClaimTakeoverAnnotation], now)
+
+	if claim.Renew {
+		// Robot accounts cannot be updated, so the claim is renewed by replacing its robot account
+		if claimRobotAccount != nil {
+			deleteResponse, deleteErr := quayClient.DeleteOrganizationRobotAccount(claimOrganizationName, constants.ClusterClaimRobotAccountName)
+			if deleteErr.Error != nil || (deleteResponse.StatusCode != 204 && deleteResponse.StatusCode != 400 && deleteResponse.StatusCode != 404) {
+				return fmt.Errorf("failed to renew the claim of ClusterID %s: %v", instance.Spec.ClusterID, deleteErr.Error)
+			}
+		}
+
+		// Creating a robot account that already exists fails, so a cluster claiming concurrently loses the race
+		_, _, claimErr := quayClient.CreateOrganizationRobotAccountWithMetadata(claimOrganizationName, constants.ClusterClaimRobotAccountName, utils.GetClusterClaimRobotAccountRequest(instance.Spec.ClusterID, clusterUID, now))
+
+		// The claim does not exist while it is replaced, so it is only held once the recorded claim names this cluster
+		recordedRobotAccount, err := getClusterClaimRobotAccount(quayClient, claimOrganizationName, instance.Spec.ClusterID)
+		if err != nil {
+			return err
+		}
+
+		if recordedRobotAccount == nil {
+			return fmt.Errorf("failed to record the claim of ClusterID %s: %v", instance.Spec.ClusterID, claimErr.Error)
+		}
+
+		recordedClaim := utils.GetClusterClaim(recordedRobotAccount, clusterUID, "", now)
+		if recordedClaim.HolderUID != clusterUID {
+			claim = qotypes.ClusterClaim{HolderUID: recordedClaim.HolderUID, RenewTime: recordedClaim.RenewTime}
+		} else if !recordedClaim.RenewTime.Equal(now.Truncate(time.Second)) {
+			return fmt.Errorf("failed to record the claim of ClusterID %s: %v", instance.Spec.ClusterID, claimErr.Error)
+		}
+	}
+
+	condition := utils.GetClusterClaimCondition(instance.Spec.ClusterID, claim)
+	condition.ObservedGeneration = instance.Generation
+
+	if claim.Takeover || claim.Expired {
+		r.GetRecorder().Event(instance, "Warning", "ClusterClaimTakenOver", condition.Message)
+	} else if !claim.Held && core.IsClusterClaimed(instance) {
+		r.GetRecorder().Event(instance, "Warning", "ClusterClaimLost", condition.Message)
+	}
+
+	claimStatus := &quayv1.ClusterClaimStatus{
+		ClusterID:  instance.Spec.ClusterID,
+		ClusterUID: clusterUID,
+		HolderUID:  claim.HolderUID,
+	}
+
+	if claim.Held {
+		claimStatus.HolderUID = clusterUID
+	}
+
+	if claim.Held && claim.Renew {
+		claimStatus.RenewTime = &metav1.Time{Time: now.Truncate(time.Second)}
+	} else if !claim.RenewTime.IsZero() {
+		claimStatus.RenewTime = &metav1.Time{Time: claim.RenewTime}
+	}
+
+	if claim.Takeover {
+		delete(instance.Annotations, constants.ClusterClaimTakeoverAnnotation)
+		if err := r.GetClient().Update(ctx, instance); err != nil {
+			return fmt.Errorf("failed to remove the takeover annotation: %w", err)
+		}
+	}
+
+	// Updating the status retriggers a reconciliation, so it is only updated when the claim changes
+	existingCondition := apimeta.FindStatusCondition(instance.Status.Conditions, condition.Type)
+	if existingCondition != nil && existingCondition.Status == condition.Status && existingCondition.Reason == condition.Reason && existingCondition.Message == condition.Message && existingCondition.ObservedGeneration == condition.ObservedGeneration && equality.Semantic.DeepEqual(instance.Status.ClusterClaim, claimStatus) {
+		return nil
+	}
+
+	apimeta.SetStatusCondition(&instance.Status.Conditions, condition)
+	instance.Status.ClusterClaim = claimStatus
+
+	instance, _ = instance.SetStatus(&instance.Status)
+	if err := r.GetClient().Status().Update(ctx, instance); err != nil {
+		return fmt.Errorf("failed to update QuayIntegration status: %w", err)
+	}
+
+	return nil
+}
+
+// recordClusterClaimFailure reports on the QuayIntegration that the claim of its ClusterID could not be synchronized
+func (r *QuayIntegrationReconciler) recordClusterClaimFailure(ctx context.Context, instance *quayv1.QuayIntegration, claimErr error) error {
+	if core.IsClusterClaimed(instance) {
+		r.GetRecorder().Event(instance, "Warning", "ClusterClaimFailed", fmt.Sprintf("Failed to renew the claim of ClusterID %s: %v", instance.Spec.ClusterID, claimErr))
+	}
+
+	condition := utils.GetClusterClaimFailedCondition(instance.Spec.ClusterID, claimErr)
+	condition.ObservedGeneration = instance.Generation
+	apimeta.SetStatusCondition(&instance.Status.Conditions, condition)
+
+	instance, _ = instance.SetStatus(&instance.Status)
+	return r.GetClient().Status().Update(ctx, instance)
+}
+
+// getClusterClaimRobotAccount returns the robot account recording the claim of a ClusterID, if any
+func getClusterClaimRobotAccount(quayClient *qclient.Client, claimOrganizationName string, clusterID string) (*qclient.RobotAccount, error) {
+	robotAccount, robotAccountResponse, robotAccountErr := quayClient.GetOrganizationRobotAccount(claimOrganizationName, constants.ClusterClaimRobotAccountName)
+	if robotAccountErr.Error != nil {
+		return nil, fmt.Errorf("failed to retrieve the claim of ClusterID %s: %w", clusterID, robotAccountErr.Error)
+	}
+
+	// Robot accounts that do not exist are reported as 400
+	switch robotAccountResponse.StatusCode {
+	case 200:
+		return &robotAccount, nil
+	case 400, 404:
+		return nil, nil
+	default:
+		return nil, fmt.Errorf("failed to retrieve the claim of ClusterID %s: status code %d", clusterID, robotAccountResponse.StatusCode)
+	}
+}
+
+// releaseClusterClaim deletes the organization recording the claim of the ClusterID when it is held by this cluster
+func (r *QuayIntegrationReconciler) releaseClusterClaim(ctx context.Context, instance *quayv1.QuayIntegration) error {
+	if !core.IsClusterClaimed(instance) {
+		return nil
+	}
+
+	coreComponents := core.NewCoreComponents(r.ReconcilerBase)
+
+	quayClient, _, err := coreComponents.NewQuayClient(ctx, instance, instance)
+	if quayClient == nil {
+		return fmt.Errorf("unable to communicate with Quay: %v", err)
+	}
+
+	claimOrganizationName := instance.GenerateClusterClaimOrganizationName()
+
+	deleteResponse, deleteErr := quayClient.DeleteOrganization(claimOrganizationName)
+	if deleteErr.Error != nil || (deleteResponse.StatusCode != 204 && deleteResponse.StatusCode != 404) {
+		return fmt.Errorf("failed to delete Quay organization %s: %v", claimOrganizationName, deleteErr.Error)
+	}
+
+	return nil
+}
+
+// getClusterUID returns the unique identifier of the cluster, which is the UID of the kube-system namespace
+func (r *QuayIntegrationReconciler) getClusterUID(ctx context.Context) (string, error) {
+	namespace := &corev1.Namespace{}
+	if err := r.GetClient().Get(ctx, types.NamespacedName{Name: constants.ClusterUIDNamespace}, namespace); err != nil {
+		return "", fmt.Errorf("failed to retrieve the cluster UID: %w", err)
+	}
+
+	return string(namespace.UID), nil
+}
+
+// validateCredentials records whether the credentials of the QuayIntegration can authenticate to Quay
+func (r *QuayIntegrationReconciler) validateCredentials(ctx context.Context, instance *quayv1.QuayIntegration) {
+	condition := metav1.Condition{
+		Type:               constants.CredentialsValidConditionType,
+		Status:             metav1.ConditionTrue,
+		Reason:             "Authenticated",
+		Message:            "Authenticated to Quay",
+		ObservedGeneration: instance.Generation,
+	}
+
+	coreComponents := core.NewCoreComponents(r.ReconcilerBase)
+
+	quayClient, _, err := coreComponents.NewQuayClient(ctx, instance, instance)
+	if quayClient == nil {
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = "InvalidCredentials"
+		condition.Message = "Unable to read the Quay credentials"
+		if err != nil {
+			condition.Message = fmt.Sprintf("%s: %v", condition.Message, err)
+		}
+	} else if _, userResponse, userErr := quayClient.GetUser(); userErr.Error != nil {
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = "AuthenticationFailed"
+		condition.Message = fmt.Sprintf("Unable to authenticate to Quay: %v", userErr.Error)
+	} else if userResponse.StatusCode != 200 {
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = "AuthenticationFailed"
+		condition.Message = fmt.Sprintf("Unable to authenticate to Quay: status code %d", userResponse.StatusCode)
+	}
+
+	apimeta.SetStatusCondition(&instance.Status.Conditions, condition)
 }
 
 // SetupWithManager sets up the controller with the Manager.
 func (r *QuayIntegrationReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	// Retriggers a reconciliation of the QuayIntegrations referencing a changed credentials Secret
+	credentialsSecretToQuayIntegrations := handler.MapFunc(
+		func(a client.Object) []reconcile.Request {
+			quayIntegrations := quayv1.QuayIntegrationList{}
+			if err := r.GetClient().List(context.TODO(), &quayIntegrations); err != nil {
+				return nil
+			}
+
+			res := []reconcile.Request{}
+			for _, quayIntegration := range quayIntegrations.Items {
+				if quayIntegration.IsCredentialsSecret(a) {
+					res = append(res, reconcile.Request{NamespacedName: types.NamespacedName{Name: quayIntegration.Name}})
+				}
+			}
+			return res
+		})
+
 	return ctrl.NewControllerManagedBy(mgr).
 		For(&quayv1.QuayIntegration{}).
+		Watches(&source.Kind{Type: &corev1.Secret{}}, handler.EnqueueRequestsFromMapFunc(credentialsSecretToQuayIntegrations)).
 		Complete(r)
 }
@@ -0,0 +1,142 @@
+package controllers
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/redhat-cop/operator-utils/pkg/util"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	quayv1 "github.com/quay/quay-bridge-operator/api/v1"
+	"github.com/quay/quay-bridge-operator/pkg/client/quay/fakequay"
+	"github.com/quay/quay-bridge-operator/pkg/constants"
+	"github.com/quay/quay-bridge-operator/pkg/core"
+)
+
+var _ = Describe("QuayIntegration controller", func() {
+
+	ctx := context.Background()
+
+	It("claims the ClusterID and validates the credentials against Quay", func() {
+		quayIntegration := createQuayIntegration(ctx, "credentials", "credentials")
+
+		Eventually(func(g Gomega) {
+			g.Expect(k8sClient.Get(ctx, types.NamespacedName{Name: quayIntegration.Name}, quayIntegration)).To(Succeed())
+			g.Expect(apimeta.IsStatusConditionTrue(quayIntegration.Status.Conditions, constants.CredentialsValidConditionType)).To(BeTrue())
+		}, timeout, interval).Should(Succeed())
+
+		_, found := quayServer.RobotAccount(quayIntegration.GenerateClusterClaimOrganizationName(), constants.ClusterClaimRobotAccountName)
+		Expect(found).To(BeTrue())
+		Expect(quayServer.CallsTo("GET", "/api/v1/user")).NotTo(BeEmpty())
+
+		By("rejecting the credentials")
+		quayServer.InjectFault(fakequay.Fault{Method: "GET", PathPrefix: "/api/v1/user", StatusCode: 401})
+		defer quayServer.ClearFaults()
+
+		credentialsSecret := &corev1.Secret{}
+		Expect(k8sClient.Get(ctx, types.NamespacedName{Name: quayIntegration.Spec.CredentialsSecret.Name, Namespace: quayIntegration.Spec.CredentialsSecret.Namespace}, credentialsSecret)).To(Succeed())
+
+		credentialsSecret.StringData = map[string]string{"rotated": "true"}
+		Expect(k8sClient.Update(ctx, credentialsSecret)).To(Succeed())
+
+		Eventually(func(g Gomega) {
+			g.Expect(k8sClient.Get(ctx, types.NamespacedName{Name: quayIntegration.Name}, quayIntegration)).To(Succeed())
+			condition := apimeta.FindStatusCondition(quayIntegration.Status.Conditions, constants.CredentialsValidConditionType)
+			g.Expect(condition).NotTo(BeNil())
+			g.Expect(condition.Reason).To(Equal("AuthenticationFailed"))
+		}, timeout, interval).Should(Succeed())
+
+		By("releasing the claim on deletion")
+		deleteQuayIntegration(ctx, quayIntegration)
+
+		_, found = quayServer.Organization(quayIntegration.GenerateClusterClaimOrganizationName())
+		Expect(found).To(BeFalse())
+	})
+
+	It("stops reconciling namespaces while the claim of the ClusterID cannot be renewed", func() {
+		quayIntegration := createQuayIntegration(ctx, "claims", "claims")
+		defer deleteQuayIntegration(ctx, quayIntegration)
+
+		quayServer.InjectFault(fakequay.Fault{Method: "GET", PathPrefix: "/api/v1/organization/" + quayIntegration.GenerateClusterClaimOrganizationName() + "/robots/" + constants.ClusterClaimRobotAccountName, StatusCode: 503})
+		defer quayServer.ClearFaults()
+
+		triggerReconcile := func(value string) {
+			Eventually(func() error {
+				if err := k8sClient.Get(ctx, types.NamespacedName{Name: quayIntegration.Name}, quayIntegration); err != nil {
+					return err
+				}
+				quayIntegration.Annotations = map[string]string{"test/trigger": value}
+				return k8sClient.Update(ctx, quayIntegration)
+			}, timeout, interval).Should(Succeed())
+		}
+
+		triggerReconcile("failing")
+
+		Eventually(func(g Gomega) {
+			g.Expect(k8sClient.Get(ctx, types.NamespacedName{Name: quayIntegration.Name}, quayIntegration)).To(Succeed())
+			condition := apimeta.FindStatusCondition(quayIntegration.Status.Conditions, constants.ClusterClaimConditionType)
+			g.Expect(condition).NotTo(BeNil())
+			g.Expect(condition.Status).To(Equal(metav1.ConditionFalse))
+			g.Expect(condition.Reason).To(Equal("ClaimFailed"))
+		}, timeout, interval).Should(Succeed())
+
+		Expect(core.IsClusterClaimed(quayIntegration)).To(BeFalse())
+
+		By("renewing the claim once Quay recovers")
+		quayServer.ClearFaults()
+		triggerReconcile("recovered")
+
+		Eventually(func(g Gomega) {
+			g.Expect(k8sClient.Get(ctx, types.NamespacedName{Name: quayIntegration.Name}, quayIntegration)).To(Succeed())
+			g.Expect(core.IsClusterClaimed(quayIntegration)).To(BeTrue())
+		}, timeout, interval).Should(Succeed())
+	})
+
+	It("completes a failing teardown once it is forced", func() {
+		quayIntegration := createQuayIntegration(ctx, "teardown", "teardown")
+
+		namespace := createNamespace(ctx, "teardown")
+		quayOrganizationName := quayIntegration.GenerateQuayOrganizationNameFromNamespace(namespace.Name)
+		waitForOnboarding(ctx, namespace.Name, quayIntegration)
+
+		quayServer.InjectFault(fakequay.Fault{Method: "DELETE", PathPrefix: "/api/v1/organization/" + quayOrganizationName, StatusCode: 503})
+		defer quayServer.ClearFaults()
+
+		Expect(k8sClient.Delete(ctx, quayIntegration)).To(Succeed())
+
+		Eventually(func(g Gomega) {
+			g.Expect(k8sClient.Get(ctx, types.NamespacedName{Name: quayIntegration.Name}, quayIntegration)).To(Succeed())
+			condition := apimeta.FindStatusCondition(quayIntegration.Status.Conditions, constants.TeardownConditionType)
+			g.Expect(condition).NotTo(BeNil())
+			g.Expect(condition.Reason).To(Equal("Failed"))
+			g.Expect(condition.Message).To(ContainSubstring(constants.ForceFinalizeAnnotation))
+		}, timeout, interval).Should(Succeed())
+
+		By("forcing the teardown")
+		Eventually(func() error {
+			if err := k8sClient.Get(ctx, types.NamespacedName{Name: quayIntegration.Name}, quayIntegration); err != nil {
+				return err
+			}
+			quayIntegration.Annotations = map[string]string{constants.ForceFinalizeAnnotation: "true"}
+			return k8sClient.Update(ctx, quayIntegration)
+		}, timeout, interval).Should(Succeed())
+
+		Eventually(func() bool {
+			return apierrors.IsNotFound(k8sClient.Get(ctx, types.NamespacedName{Name: quayIntegration.Name}, &quayv1.QuayIntegration{}))
+		}, timeout, interval).Should(BeTrue())
+
+		Expect(k8sClient.Get(ctx, types.NamespacedName{Name: namespace.Name}, namespace)).To(Succeed())
+		Expect(util.HasFinalizer(namespace, constants.NamespaceFinalizer)).To(BeFalse())
+
+		_, found := quayServer.Organization(quayOrganizationName)
+		Expect(found).To(BeTrue())
+
+		Expect(k8sClient.Delete(ctx, namespace)).To(Succeed())
+		Expect(k8sClient.Delete(ctx, &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: quayIntegration.Spec.CredentialsSecret.Name, Namespace: quayIntegration.Spec.CredentialsSecret.Namespace}})).To(Succeed())
+	})
+})
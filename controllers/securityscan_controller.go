@@ -0,0 +1,256 @@
+/*
+Copyright 2021.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-logr/logr"
+	buildv1 "github.com/openshift/api/build/v1"
+	imagev1 "github.com/openshift/api/image/v1"
+	corev1 "k8s.io/api/core/v1"
+
+	qclient "github.com/quay/quay-bridge-operator/pkg/client/quay"
+	"github.com/quay/quay-bridge-operator/pkg/constants"
+	"github.com/quay/quay-bridge-operator/pkg/core"
+	"github.com/quay/quay-bridge-operator/pkg/logging"
+	"github.com/quay/quay-bridge-operator/pkg/utils"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// SecurityScanReconciler reports the Quay security scan results of images imported from Builds
+type SecurityScanReconciler struct {
+	CoreComponents core.CoreComponents
+	Log            logr.Logger
+}
+
+//+kubebuilder:rbac:groups=build.openshift.io,resources=builds,verbs=get;list;watch;create;update;patch
+
+func (r *SecurityScanReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	logging.Log.Info("Reconciling Build Security Scan", "Request.Namespace", req.Namespace, "Request.Name", req.Name)
+
+	instance := &buildv1.Build{}
+	err := r.CoreComponents.ReconcilerBase.GetClient().Get(ctx, req.NamespacedName, instance)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return reconcile.Result{}, nil
+		}
+
+		// Error reading the object - requeue the request.
+		return reconcile.Result{}, err
+	}
+
+	if !isSecurityScanPending(instance) {
+		return reconcile.Result{}, nil
+	}
+
+	quayIntegration, result, err := r.CoreComponents.GetQuayIntegration(instance)
+	if err != nil {
+		return result, err
+	}
+
+	if quayIntegration.Spec.SecurityScan == nil {
+		return reconcile.Result{}, nil
+	}
+
+	pollInterval := constants.DefaultSecurityScanPollInterval
+	if quayIntegration.Spec.SecurityScan.PollInterval != nil {
+		pollInterval = quayIntegration.Spec.SecurityScan.PollInterval.Duration
+	}
+
+	destinations, err := utils.ParseImageStreamTagDestinations(instance.GetAnnotations()[constants.BuildDestinationImageStreamAnnotation])
+	if err != nil {
+		return r.CoreComponents.ManageError(&core.QuayIntegrationCoreError{
+			Object:       instance,
+			Message:      "Unable to parse ImageStream Annotation",
+			KeyAndValues: []interface{}{"Namespace", instance.Namespace, "Build", instance.Name},
+			Reason:       "ProcessingError",
+			Error:        err,
+		})
+	}
+
+	quayClient, result, err := r.CoreComponents.GetQuayClient(ctx, instance, &quayIntegration)
+	if err != nil || quayClient == nil {
+		return result, err
+	}
+
+	target, err := r.CoreComponents.GetOrganizationTarget(ctx, &quayIntegration, destinations[0].Namespace)
+	if err != nil {
+		return r.CoreComponents.ManageError(&core.QuayIntegrationCoreError{
+			Object:       instance,
+			Message:      "Unable to resolve Quay Organization of ImageStream",
+			KeyAndValues: []interface{}{"Namespace", destinations[0].Namespace, "ImageStream", destinations[0].Name},
+			Reason:       "ConfigurationError",
+			Error:        err,
+		})
+	}
+
+	imageStream := &imagev1.ImageStream{}
+	err = r.CoreComponents.ReconcilerBase.GetClient().Get(ctx, types.NamespacedName{Namespace: destinations[0].Namespace, Name: destinations[0].Name}, imageStream)
+	if err != nil {
+		return r.CoreComponents.ManageError(&core.QuayIntegrationCoreError{
+			Object:       instance,
+			Message:      "Unable to locate ImageStream",
+			KeyAndValues: []interface{}{"Namespace", destinations[0].Namespace, "ImageStream", destinations[0].Name},
+			Reason:       "ProcessingError",
+			Error:        err,
+		})
+	}
+
+	repositoryName, err := utils.GetRepositoryName(target, imageStream)
+	if err != nil {
+		return r.CoreComponents.ManageError(&core.QuayIntegrationCoreError{
+			Object:       instance,
+			Message:      "Unable to determine Quay Repository of ImageStream",
+			KeyAndValues: []interface{}{"Namespace", destinations[0].Namespace, "ImageStream", destinations[0].Name},
+			Reason:       "ConfigurationError",
+			Error:        err,
+		})
+	}
+
+	digest := instance.GetAnnotations()[constants.BuildImportDigestAnnotation]
+	quayOrganizationName := target.Organization
+
+	security, securityResponse, securityErr := quayClient.GetManifestSecurity(quayOrganizationName, repositoryName, digest)
+	if securityErr.Error != nil || securityResponse.StatusCode != 200 {
+		// The manifest may not have been indexed by the security scanner yet
+		if securityResponse != nil && securityResponse.StatusCode == 404 {
+			return reconcile.Result{RequeueAfter: pollInterval}, nil
+		}
+
+		return r.CoreComponents.ManageError(&core.QuayIntegrationCoreError{
+			Object:       instance,
+			Message:      "Error occurred retrieving Quay manifest security",
+			KeyAndValues: []interface{}{"Quay Repository", fmt.Sprintf("%s/%s", quayOrganizationName, repositoryName), "Digest", digest},
+			Reason:       "ProcessingError",
+			Error:        securityErr.Error,
+		})
+	}
+
+	if security.Status == qclient.SecurityScanStatusQueued || security.Status == "" {
+		logging.Log.Info("Security scan pending", "Namespace", instance.Namespace, "Build", instance.Name, "Digest", digest, "Retry In", pollInterval.String())
+
+		if instance.GetAnnotations()[constants.SecurityScanStatusAnnotation] != qclient.SecurityScanStatusQueued {
+			instance.GetAnnotations()[constants.SecurityScanStatusAnnotation] = qclient.SecurityScanStatusQueued
+			if err := r.CoreComponents.ReconcilerBase.GetClient().Update(ctx, instance); err != nil {
+				return r.CoreComponents.ManageError(&core.QuayIntegrationCoreError{
+					Object:       instance,
+					Message:      "Error occurred updating Build",
+					KeyAndValues: []interface{}{"Namespace", instance.Namespace, "Build", instance.Name},
+					Reason:       "ProcessingError",
+					Error:        err,
+				})
+			}
+		}
+
+		return reconcile.Result{RequeueAfter: pollInterval}, nil
+	}
+
+	annotations := map[string]string{
+		constants.SecurityScanStatusAnnotation: security.Status,
+	}
+
+	summary := utils.SummarizeVulnerabilities(security)
+
+	if security.Status == qclient.SecurityScanStatusScanned {
+		annotations[constants.VulnerabilitiesAnnotation] = utils.FormatVulnerabilitySummary(summary)
+		annotations[constants.HighestVulnerabilitySeverityAnnotation] = summary.Highest
+	}
+
+	// Record the results on the ImageStream tags
+	err = annotateImageStreamTags(ctx, r.CoreComponents.ReconcilerBase.GetClient(), types.NamespacedName{Namespace: destinations[0].Namespace, Name: destinations[0].Name}, destinations, annotations)
+	if err != nil {
+		return r.CoreComponents.ManageError(&core.QuayIntegrationCoreError{
+			Object:       instance,
+			Message:      "Error occurred annotating ImageStream tags",
+			KeyAndValues: []interface{}{"Namespace", destinations[0].Namespace, "ImageStream", destinations[0].Name},
+			Reason:       "ProcessingError",
+			Error:        err,
+		})
+	}
+
+	// Record the results on the Build
+	for key, value := range annotations {
+		instance.GetAnnotations()[key] = value
+	}
+
+	err = r.CoreComponents.ReconcilerBase.GetClient().Update(ctx, instance)
+	if err != nil {
+		return r.CoreComponents.ManageError(&core.QuayIntegrationCoreError{
+			Object:       instance,
+			Message:      "Error occurred updating Build",
+			KeyAndValues: []interface{}{"Namespace", instance.Namespace, "Build", instance.Name},
+			Reason:       "ProcessingError",
+			Error:        err,
+		})
+	}
+
+	switch {
+	case security.Status != qclient.SecurityScanStatusScanned:
+		r.CoreComponents.ReconcilerBase.GetRecorder().Event(instance, corev1.EventTypeWarning, "SecurityScanIncomplete", fmt.Sprintf("Security scan of %s finished with status %s", digest, security.Status))
+	case summary.Highest != "":
+		r.CoreComponents.ReconcilerBase.GetRecorder().Event(instance, corev1.EventTypeWarning, "VulnerabilitiesFound", fmt.Sprintf("Security scan of %s found vulnerabilities: %s", digest, annotations[constants.VulnerabilitiesAnnotation]))
+	default:
+		r.CoreComponents.ReconcilerBase.GetRecorder().Event(instance, corev1.EventTypeNormal, "SecurityScanCompleted", fmt.Sprintf("Security scan of %s found no vulnerabilities", digest))
+	}
+
+	return reconcile.Result{}, nil
+}
+
+// isSecurityScanPending returns whether a Build has been imported and its security scan results have not yet been recorded
+func isSecurityScanPending(build *buildv1.Build) bool {
+	annotations := build.GetAnnotations()
+
+	if _, imported := annotations[constants.BuildDestinationImageStreamTagImportedAnnotation]; !imported || annotations[constants.BuildImportDigestAnnotation] == "" {
+		return false
+	}
+
+	scanStatus, found := annotations[constants.SecurityScanStatusAnnotation]
+
+	return !found || scanStatus == qclient.SecurityScanStatusQueued
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *SecurityScanReconciler) SetupWithManager(mgr ctrl.Manager) error {
+
+	buildPredicates := predicate.Funcs{
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			newBuild, ok := e.ObjectNew.(*buildv1.Build)
+			return ok && isSecurityScanPending(newBuild)
+		},
+		CreateFunc: func(e event.CreateEvent) bool {
+			build, ok := e.Object.(*buildv1.Build)
+			return ok && isSecurityScanPending(build)
+		},
+		DeleteFunc: func(e event.DeleteEvent) bool {
+			return false
+		},
+	}
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named("securityscan").
+		For(&buildv1.Build{}, builder.WithPredicates(buildPredicates)).
+		Complete(r)
+}
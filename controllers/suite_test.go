@@ -17,19 +17,40 @@ limitations under the License.
 package controllers
 
 import (
+	"context"
+	"os"
 	"path/filepath"
 	"testing"
+	"time"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	buildv1 "github.com/openshift/api/build/v1"
+	imagev1 "github.com/openshift/api/image/v1"
+	"github.com/redhat-cop/operator-utils/pkg/util"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/kubernetes/scheme"
 	"k8s.io/client-go/rest"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/cluster"
 	"sigs.k8s.io/controller-runtime/pkg/envtest"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
 
 	quayv1 "github.com/quay/quay-bridge-operator/api/v1"
+	"github.com/quay/quay-bridge-operator/pkg/client/quay/fakequay"
+	"github.com/quay/quay-bridge-operator/pkg/constants"
+	"github.com/quay/quay-bridge-operator/pkg/core"
+	qotypes "github.com/quay/quay-bridge-operator/pkg/types"
+	"github.com/quay/quay-bridge-operator/pkg/utils"
+	quaywebhook "github.com/quay/quay-bridge-operator/pkg/webhook"
 	//+kubebuilder:scaffold:imports
 )
 
@@ -39,6 +60,14 @@ import (
 var cfg *rest.Config
 var k8sClient client.Client
 var testEnv *envtest.Environment
+var quayServer *fakequay.Server
+var cancel context.CancelFunc
+
+const (
+	quayToken = "fakequay-token"
+	timeout   = 30 * time.Second
+	interval  = 250 * time.Millisecond
+)
 
 func TestAPIs(t *testing.T) {
 	RegisterFailHandler(Fail)
@@ -48,29 +77,218 @@ func TestAPIs(t *testing.T) {
 var _ = BeforeSuite(func() {
 	logf.SetLogger(zap.New(zap.WriteTo(GinkgoWriter), zap.UseDevMode(true)))
 
+	// The control plane binaries are provided by 'make test' through KUBEBUILDER_ASSETS
+	if _, found := os.LookupEnv("KUBEBUILDER_ASSETS"); !found {
+		if _, err := os.Stat("/usr/local/kubebuilder/bin"); err != nil {
+			Fail("envtest binaries are not installed, run 'make test' or set KUBEBUILDER_ASSETS to run the controller tests")
+		}
+	}
+
 	By("bootstrapping test environment")
 	testEnv = &envtest.Environment{
-		CRDDirectoryPaths:     []string{filepath.Join("..", "config", "crd", "bases")},
+		// The OpenShift Build and Image APIs are served as CustomResourceDefinitions
+		CRDDirectoryPaths:     []string{filepath.Join("..", "config", "crd", "bases"), filepath.Join("testdata", "crds")},
 		ErrorIfCRDPathMissing: true,
+		WebhookInstallOptions: envtest.WebhookInstallOptions{
+			Paths: []string{filepath.Join("..", "config", "webhook", "manifests.yaml")},
+		},
 	}
 
-	cfg, err := testEnv.Start()
+	var err error
+	cfg, err = testEnv.Start()
 	Expect(err).NotTo(HaveOccurred())
 	Expect(cfg).NotTo(BeNil())
 
 	err = quayv1.AddToScheme(scheme.Scheme)
 	Expect(err).NotTo(HaveOccurred())
 
+	err = buildv1.AddToScheme(scheme.Scheme)
+	Expect(err).NotTo(HaveOccurred())
+
+	err = imagev1.AddToScheme(scheme.Scheme)
+	Expect(err).NotTo(HaveOccurred())
+
 	//+kubebuilder:scaffold:scheme
 
 	k8sClient, err = client.New(cfg, client.Options{Scheme: scheme.Scheme})
 	Expect(err).NotTo(HaveOccurred())
 	Expect(k8sClient).NotTo(BeNil())
 
+	By("starting the fake Quay server")
+	quayServer = fakequay.New(quayToken)
+	quayServer.Start()
+
+	By("starting the controllers")
+	mgr, err := ctrl.NewManager(cfg, ctrl.Options{
+		Scheme:             scheme.Scheme,
+		MetricsBindAddress: "0",
+		Host:               testEnv.WebhookInstallOptions.LocalServingHost,
+		Port:               testEnv.WebhookInstallOptions.LocalServingPort,
+		CertDir:            testEnv.WebhookInstallOptions.LocalServingCertDir,
+		NewClient:          newImageStreamImportClient,
+	})
+	Expect(err).NotTo(HaveOccurred())
+
+	err = (&QuayIntegrationReconciler{
+		ReconcilerBase: util.NewReconcilerBase(mgr.GetClient(), mgr.GetScheme(), mgr.GetConfig(), mgr.GetEventRecorderFor("QuayIntegration_controller"), mgr.GetAPIReader()),
+		Log:            ctrl.Log.WithName("controllers").WithName("QuayIntegration"),
+		LastSeenSpec:   map[types.NamespacedName]string{},
+	}).SetupWithManager(mgr)
+	Expect(err).NotTo(HaveOccurred())
+
+	err = (&NamespaceIntegrationReconciler{
+		CoreComponents: core.NewCoreComponents(util.NewReconcilerBase(mgr.GetClient(), mgr.GetScheme(), mgr.GetConfig(), mgr.GetEventRecorderFor("NamespaceIntegration_controller"), mgr.GetAPIReader())),
+		Log:            ctrl.Log.WithName("controllers").WithName("NamespaceIntegration"),
+	}).SetupWithManager(mgr)
+	Expect(err).NotTo(HaveOccurred())
+
+	err = (&BuildIntegrationReconciler{
+		CoreComponents: core.NewCoreComponents(util.NewReconcilerBase(mgr.GetClient(), mgr.GetScheme(), mgr.GetConfig(), mgr.GetEventRecorderFor("BuildIntegration_controller"), mgr.GetAPIReader())),
+		Log:            ctrl.Log.WithName("controllers").WithName("BuildIntegration"),
+	}).SetupWithManager(mgr)
+	Expect(err).NotTo(HaveOccurred())
+
+	err = (&InboundSyncReconciler{
+		CoreComponents: core.NewCoreComponents(util.NewReconcilerBase(mgr.GetClient(), mgr.GetScheme(), mgr.GetConfig(), mgr.GetEventRecorderFor("InboundSync_controller"), mgr.GetAPIReader())),
+		Log:            ctrl.Log.WithName("controllers").WithName("InboundSync"),
+	}).SetupWithManager(mgr)
+	Expect(err).NotTo(HaveOccurred())
+
+	webhookSvr := mgr.GetWebhookServer()
+	webhookSvr.Register("/admissionwebhook", &webhook.Admission{Handler: &quaywebhook.QuayIntegrationMutator{Client: mgr.GetClient(), Log: ctrl.Log.WithName("webhook").WithName("QuayIntegration")}})
+
+	err = mgr.GetFieldIndexer().IndexField(context.Background(), &buildv1.Build{}, constants.BuildImportDigestIndex, quaywebhook.IndexBuildImportDigest)
+	Expect(err).NotTo(HaveOccurred())
+
+	webhookSvr.Register("/validate-pods", &webhook.Admission{Handler: &quaywebhook.PodSecurityValidator{Client: mgr.GetClient(), Log: ctrl.Log.WithName("webhook").WithName("PodSecurity")}})
+
+	var ctx context.Context
+	ctx, cancel = context.WithCancel(context.TODO())
+
+	go func() {
+		defer GinkgoRecover()
+		err := mgr.Start(ctx)
+		Expect(err).NotTo(HaveOccurred())
+	}()
 })
 
 var _ = AfterSuite(func() {
+	if testEnv == nil {
+		return
+	}
+
 	By("tearing down the test environment")
+	if cancel != nil {
+		cancel()
+	}
+
+	if quayServer != nil {
+		quayServer.Close()
+	}
+
 	err := testEnv.Stop()
 	Expect(err).NotTo(HaveOccurred())
 })
+
+// imageStreamImportClient stands in for the image import API of OpenShift, which imports images synchronously when an ImageStreamImport is created and does not persist it
+type imageStreamImportClient struct {
+	client.Client
+}
+
+func newImageStreamImportClient(cache cache.Cache, config *rest.Config, options client.Options, uncachedObjects ...client.Object) (client.Client, error) {
+	c, err := cluster.DefaultNewClient(cache, config, options, uncachedObjects...)
+	if err != nil {
+		return nil, err
+	}
+
+	return &imageStreamImportClient{Client: c}, nil
+}
+
+// Create persists ImageStreamImports so they can be inspected, replacing any previous import of the ImageStream, and reports every image as imported
+func (c *imageStreamImportClient) Create(ctx context.Context, obj client.Object, opts ...client.CreateOption) error {
+	isi, ok := obj.(*imagev1.ImageStreamImport)
+	if !ok {
+		return c.Client.Create(ctx, obj, opts...)
+	}
+
+	if err := c.Client.Delete(ctx, &imagev1.ImageStreamImport{ObjectMeta: metav1.ObjectMeta{Name: isi.Name, Namespace: isi.Namespace}}); err != nil && !apierrors.IsNotFound(err) {
+		return err
+	}
+
+	// The resource version of the ImageStream is a precondition of the import rather than of the ImageStreamImport
+	isi.ResourceVersion = ""
+
+	if err := c.Client.Create(ctx, isi, opts...); err != nil {
+		return err
+	}
+
+	isi.Status.Images = []imagev1.ImageImportStatus{}
+	for _, image := range isi.Spec.Images {
+		isi.Status.Images = append(isi.Status.Images, imagev1.ImageImportStatus{
+			Status: metav1.Status{Status: metav1.StatusSuccess},
+			Tag:    image.To.Name,
+		})
+	}
+
+	return nil
+}
+
+// createQuayIntegration creates a QuayIntegration authenticating to the fake Quay server and waits until it has claimed its ClusterID
+func createQuayIntegration(ctx context.Context, name string, clusterID string) *quayv1.QuayIntegration {
+	credentialsSecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: name + "-credentials", Namespace: "default"},
+		StringData: map[string]string{constants.QuaySecretCredentialTokenKey: quayToken},
+	}
+	Expect(k8sClient.Create(ctx, credentialsSecret)).To(Succeed())
+
+	quayIntegration := &quayv1.QuayIntegration{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Spec: quayv1.QuayIntegrationSpec{
+			ClusterID:         clusterID,
+			QuayHostname:      quayServer.URL(),
+			CredentialsSecret: &quayv1.SecretRef{Name: credentialsSecret.Name, Namespace: credentialsSecret.Namespace},
+		},
+	}
+	Expect(k8sClient.Create(ctx, quayIntegration)).To(Succeed())
+
+	Eventually(func(g Gomega) {
+		g.Expect(k8sClient.Get(ctx, types.NamespacedName{Name: name}, quayIntegration)).To(Succeed())
+		g.Expect(apimeta.IsStatusConditionTrue(quayIntegration.Status.Conditions, constants.ClusterClaimConditionType)).To(BeTrue())
+	}, timeout, interval).Should(Succeed())
+
+	return quayIntegration
+}
+
+// deleteQuayIntegration deletes a QuayIntegration and waits until it has been torn down, so that another QuayIntegration can be created
+func deleteQuayIntegration(ctx context.Context, quayIntegration *quayv1.QuayIntegration) {
+	Expect(k8sClient.Delete(ctx, quayIntegration)).To(Succeed())
+
+	Eventually(func() bool {
+		return apierrors.IsNotFound(k8sClient.Get(ctx, types.NamespacedName{Name: quayIntegration.Name}, &quayv1.QuayIntegration{}))
+	}, timeout, interval).Should(BeTrue())
+
+	Expect(k8sClient.Delete(ctx, &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: quayIntegration.Spec.CredentialsSecret.Name, Namespace: quayIntegration.Spec.CredentialsSecret.Namespace}})).To(Succeed())
+}
+
+// createNamespace creates a namespace along with the service accounts OpenShift creates in every namespace, which envtest does not
+func createNamespace(ctx context.Context, name string) *corev1.Namespace {
+	namespace := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: name}}
+	Expect(k8sClient.Create(ctx, namespace)).To(Succeed())
+
+	for serviceAccountName := range QuayServiceAccountPermissionMatrix {
+		Expect(k8sClient.Create(ctx, &corev1.ServiceAccount{ObjectMeta: metav1.ObjectMeta{Name: string(serviceAccountName), Namespace: name}})).To(Succeed())
+	}
+
+	return namespace
+}
+
+// waitForOnboarding waits until the builder service account of a namespace is linked to the Secret holding the credentials of its robot account
+func waitForOnboarding(ctx context.Context, namespace string, quayIntegration *quayv1.QuayIntegration) {
+	secretName := utils.GenerateDockerJsonSecretNameForServiceAccount(string(qotypes.BuilderOpenShiftServiceAccount), quayIntegration.Spec.ClusterID)
+
+	Eventually(func(g Gomega) {
+		serviceAccount := &corev1.ServiceAccount{}
+		g.Expect(k8sClient.Get(ctx, types.NamespacedName{Namespace: namespace, Name: string(qotypes.BuilderOpenShiftServiceAccount)}, serviceAccount)).To(Succeed())
+		g.Expect(utils.ObjectReferenceNameExists(serviceAccount.Secrets, secretName)).To(BeTrue())
+	}, timeout, interval).Should(Succeed())
+}
@@ -17,6 +17,7 @@ limitations under the License.
 package main
 
 import (
+	"context"
 	"flag"
 	"os"
 
@@ -117,6 +118,22 @@ func main() {
 		os.Exit(1)
 	}
 
+	if err = (&controllers.SecurityScanReconciler{
+		CoreComponents: core.NewCoreComponents(util.NewReconcilerBase(mgr.GetClient(), mgr.GetScheme(), mgr.GetConfig(), mgr.GetEventRecorderFor("SecurityScan_controller"), mgr.GetAPIReader())),
+		Log:            ctrl.Log.WithName("controllers").WithName("SecurityScan"),
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "SecurityScan")
+		os.Exit(1)
+	}
+
+	if err = (&controllers.InboundSyncReconciler{
+		CoreComponents: core.NewCoreComponents(util.NewReconcilerBase(mgr.GetClient(), mgr.GetScheme(), mgr.GetConfig(), mgr.GetEventRecorderFor("InboundSync_controller"), mgr.GetAPIReader())),
+		Log:            ctrl.Log.WithName("controllers").WithName("InboundSync"),
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "InboundSync")
+		os.Exit(1)
+	}
+
 	// Enable Webhook support
 	_, disableWebhookEnv := os.LookupEnv(constants.DisableWebhookEnvVar)
 
@@ -129,6 +146,14 @@ func main() {
 		webhookSvr.KeyName = constants.WebhookKeyName
 		webhookSvr.Register("/admissionwebhook", &webhook.Admission{Handler: &quaywebhook.QuayIntegrationMutator{Client: mgr.GetClient(), Log: ctrl.Log.WithName("webhook").WithName("QuayIntegration")}})
 
+		// Index Builds by pushed digest to look up security scan results of Pod images
+		if err := mgr.GetFieldIndexer().IndexField(context.Background(), &buildv1.Build{}, constants.BuildImportDigestIndex, quaywebhook.IndexBuildImportDigest); err != nil {
+			setupLog.Error(err, "unable to index Builds")
+			os.Exit(1)
+		}
+
+		webhookSvr.Register("/validate-pods", &webhook.Admission{Handler: &quaywebhook.PodSecurityValidator{Client: mgr.GetClient(), Log: ctrl.Log.WithName("webhook").WithName("PodSecurity")}})
+
 	}
 
 	//+kubebuilder:scaffold:builder
@@ -53,10 +53,10 @@ func (c *Client) GetOrganizationByName(orgName string) (Organization, *http.Resp
 	return organization, resp, QuayApiError{Error: err}
 }
 
-func (c *Client) CreateOrganization(name string) (StringValue, *http.Response, QuayApiError) {
+func (c *Client) CreateOrganization(name string, email string) (StringValue, *http.Response, QuayApiError) {
 	newOrganization := OrganizationRequest{
 		Name:  name,
-		Email: fmt.Sprintf("%s@redhat.com", name),
+		Email: email,
 	}
 
 	req, err := c.NewRequest("POST", "/api/v1/organization/", newOrganization)
@@ -70,6 +70,157 @@ func (c *Client) CreateOrganization(name string) (StringValue, *http.Response, Q
 	return newOrganizationResponse, resp, QuayApiError{Error: err}
 }
 
+func (c *Client) UpdateOrganization(orgName string, organizationUpdate OrganizationUpdateRequest) (*http.Response, QuayApiError) {
+	req, err := c.NewRequest("PUT", fmt.Sprintf("/api/v1/organization/%s", orgName), organizationUpdate)
+	if err != nil {
+		return nil, QuayApiError{Error: err}
+	}
+
+	resp, err := c.do(req, nil)
+
+	return resp, QuayApiError{Error: err}
+}
+
+func (c *Client) GetOrganizationQuotas(orgName string) ([]OrganizationQuota, *http.Response, QuayApiError) {
+	req, err := c.NewRequest("GET", fmt.Sprintf("/api/v1/organization/%s/quota", orgName), nil)
+	if err != nil {
+		return nil, nil, QuayApiError{Error: err}
+	}
+
+	var quotas []OrganizationQuota
+	resp, err := c.do(req, &quotas)
+
+	return quotas, resp, QuayApiError{Error: err}
+}
+
+func (c *Client) CreateOrganizationQuota(orgName string, limitBytes int64) (*http.Response, QuayApiError) {
+	req, err := c.NewRequest("POST", fmt.Sprintf("/api/v1/organization/%s/quota", orgName), OrganizationQuotaRequest{LimitBytes: limitBytes})
+	if err != nil {
+		return nil, QuayApiError{Error: err}
+	}
+
+	resp, err := c.do(req, nil)
+
+	return resp, QuayApiError{Error: err}
+}
+
+func (c *Client) UpdateOrganizationQuota(orgName string, quotaID int, limitBytes int64) (*http.Response, QuayApiError) {
+	req, err := c.NewRequest("PUT", fmt.Sprintf("/api/v1/organization/%s/quota/%d", orgName, quotaID), OrganizationQuotaRequest{LimitBytes: limitBytes})
+	if err != nil {
+		return nil, QuayApiError{Error: err}
+	}
+
+	resp, err := c.do(req, nil)
+
+	return resp, QuayApiError{Error: err}
+}
+
+func (c *Client) DeleteOrganizationQuota(orgName string, quotaID int) (*http.Response, QuayApiError) {
+	req, err := c.NewRequest("DELETE", fmt.Sprintf("/api/v1/organization/%s/quota/%d", orgName, quotaID), nil)
+	if err != nil {
+		return nil, QuayApiError{Error: err}
+	}
+
+	resp, err := c.do(req, nil)
+
+	return resp, QuayApiError{Error: err}
+}
+
+func (c *Client) CreateOrganizationQuotaLimit(orgName string, quotaID int, limitType string, thresholdPercent int) (*http.Response, QuayApiError) {
+	req, err := c.NewRequest("POST", fmt.Sprintf("/api/v1/organization/%s/quota/%d/limit", orgName, quotaID), OrganizationQuotaLimitRequest{Type: limitType, ThresholdPercent: thresholdPercent})
+	if err != nil {
+		return nil, QuayApiError{Error: err}
+	}
+
+	resp, err := c.do(req, nil)
+
+	return resp, QuayApiError{Error: err}
+}
+
+func (c *Client) UpdateOrganizationQuotaLimit(orgName string, quotaID int, limitID string, limitType string, thresholdPercent int) (*http.Response, QuayApiError) {
+	req, err := c.NewRequest("PUT", fmt.Sprintf("/api/v1/organization/%s/quota/%d/limit/%s", orgName, quotaID, limitID), OrganizationQuotaLimitRequest{Type: limitType, ThresholdPercent: thresholdPercent})
+	if err != nil {
+		return nil, QuayApiError{Error: err}
+	}
+
+	resp, err := c.do(req, nil)
+
+	return resp, QuayApiError{Error: err}
+}
+
+func (c *Client) DeleteOrganizationQuotaLimit(orgName string, quotaID int, limitID string) (*http.Response, QuayApiError) {
+	req, err := c.NewRequest("DELETE", fmt.Sprintf("/api/v1/organization/%s/quota/%d/limit/%s", orgName, quotaID, limitID), nil)
+	if err != nil {
+		return nil, QuayApiError{Error: err}
+	}
+
+	resp, err := c.do(req, nil)
+
+	return resp, QuayApiError{Error: err}
+}
+
+func (c *Client) CreateOrUpdateTeam(orgName, teamName, role, description string) (Team, *http.Response, QuayApiError) {
+	teamRequest := TeamRequest{
+		Role:        role,
+		Description: description,
+	}
+
+	req, err := c.NewRequest("PUT", fmt.Sprintf("/api/v1/organization/%s/team/%s", orgName, teamName), teamRequest)
+	if err != nil {
+		return Team{}, nil, QuayApiError{Error: err}
+	}
+
+	var team Team
+	resp, err := c.do(req, &team)
+
+	return team, resp, QuayApiError{Error: err}
+}
+
+func (c *Client) DeleteTeam(orgName, teamName string) (*http.Response, QuayApiError) {
+	req, err := c.NewRequest("DELETE", fmt.Sprintf("/api/v1/organization/%s/team/%s", orgName, teamName), nil)
+	if err != nil {
+		return nil, QuayApiError{Error: err}
+	}
+
+	resp, err := c.do(req, nil)
+
+	return resp, QuayApiError{Error: err}
+}
+
+func (c *Client) GetTeamMembers(orgName, teamName string) (TeamMembersResponse, *http.Response, QuayApiError) {
+	req, err := c.NewRequest("GET", fmt.Sprintf("/api/v1/organization/%s/team/%s/members", orgName, teamName), nil)
+	if err != nil {
+		return TeamMembersResponse{}, nil, QuayApiError{Error: err}
+	}
+
+	var membersResponse TeamMembersResponse
+	resp, err := c.do(req, &membersResponse)
+
+	return membersResponse, resp, QuayApiError{Error: err}
+}
+
+func (c *Client) AddTeamMember(orgName, teamName, memberName string) (*http.Response, QuayApiError) {
+	req, err := c.NewRequest("PUT", fmt.Sprintf("/api/v1/organization/%s/team/%s/members/%s", orgName, teamName, memberName), nil)
+	if err != nil {
+		return nil, QuayApiError{Error: err}
+	}
+
+	resp, err := c.do(req, nil)
+
+	return resp, QuayApiError{Error: err}
+}
+
+func (c *Client) RemoveTeamMember(orgName, teamName, memberName string) (*http.Response, QuayApiError) {
+	req, err := c.NewRequest("DELETE", fmt.Sprintf("/api/v1/organization/%s/team/%s/members/%s", orgName, teamName, memberName), nil)
+	if err != nil {
+		return nil, QuayApiError{Error: err}
+	}
+
+	resp, err := c.do(req, nil)
+
+	return resp, QuayApiError{Error: err}
+}
+
 func (c *Client) GetOrganizationRobotAccount(organizationName, robotName string) (RobotAccount, *http.Response, QuayApiError) {
 	req, err := c.NewRequest("GET", fmt.Sprintf("/api/v1/organization/%s/robots/%s", organizationName, robotName), nil)
 	if err != nil {
@@ -106,6 +257,29 @@ func (c *Client) CreateOrganizationRobotAccount(organizationName, robotName stri
 	return createOrganizationRobotResponse, resp, QuayApiError{Error: err}
 }
 
+func (c *Client) CreateOrganizationRobotAccountWithMetadata(organizationName, robotName string, robotAccountRequest RobotAccountRequest) (RobotAccount, *http.Response, QuayApiError) {
+	req, err := c.NewRequest("PUT", fmt.Sprintf("/api/v1/organization/%s/robots/%s", organizationName, robotName), robotAccountRequest)
+	if err != nil {
+		return RobotAccount{}, nil, QuayApiError{Error: err}
+	}
+
+	var createOrganizationRobotResponse RobotAccount
+	resp, err := c.do(req, &createOrganizationRobotResponse)
+
+	return createOrganizationRobotResponse, resp, QuayApiError{Error: err}
+}
+
+func (c *Client) DeleteOrganizationRobotAccount(organizationName, robotName string) (*http.Response, QuayApiError) {
+	req, err := c.NewRequest("DELETE", fmt.Sprintf("/api/v1/organization/%s/robots/%s", organizationName, robotName), nil)
+	if err != nil {
+		return nil, QuayApiError{Error: err}
+	}
+
+	resp, err := c.do(req, nil)
+
+	return resp, QuayApiError{Error: err}
+}
+
 func (c *Client) DeleteOrganization(orgName string) (*http.Response, QuayApiError) {
 	req, err := c.NewRequest("DELETE", fmt.Sprintf("/api/v1/organization/%s", orgName), nil)
 	if err != nil {
@@ -151,13 +325,42 @@ func (c *Client) GetRepository(orgName, repositoryName string) (Repository, *htt
 	return repository, resp, QuayApiError{Error: err}
 }
 
-func (c *Client) CreateRepository(namespace, name string) (RepositoryRequest, *http.Response, QuayApiError) {
+func (c *Client) DeleteRepository(orgName, repositoryName string) (*http.Response, QuayApiError) {
+	req, err := c.NewRequest("DELETE", fmt.Sprintf("/api/v1/repository/%s/%s", orgName, repositoryName), nil)
+	if err != nil {
+		return nil, QuayApiError{Error: err}
+	}
+
+	resp, err := c.do(req, nil)
+
+	return resp, QuayApiError{Error: err}
+}
+
+func (c *Client) GetRepositories(orgName string, nextPage string) (RepositoriesResponse, *http.Response, QuayApiError) {
+	req, err := c.NewRequest("GET", "/api/v1/repository", nil)
+	if err != nil {
+		return RepositoriesResponse{}, nil, QuayApiError{Error: err}
+	}
+
+	params := url.Values{"namespace": {orgName}}
+	if nextPage != "" {
+		params.Set("next_page", nextPage)
+	}
+	req.URL.RawQuery = params.Encode()
+
+	var repositoriesResponse RepositoriesResponse
+	resp, err := c.do(req, &repositoriesResponse)
+
+	return repositoriesResponse, resp, QuayApiError{Error: err}
+}
+
+func (c *Client) CreateRepository(namespace, name, visibility, description, kind string) (RepositoryRequest, *http.Response, QuayApiError) {
 	newRepository := RepositoryRequest{
 		Repository:  name,
 		Namespace:   namespace,
-		Kind:        "image",
-		Visibility:  "private",
-		Description: "",
+		Kind:        kind,
+		Visibility:  visibility,
+		Description: description,
 	}
 
 	req, err := c.NewRequest("POST", "/api/v1/repository", newRepository)
@@ -171,6 +374,273 @@ func (c *Client) CreateRepository(namespace, name string) (RepositoryRequest, *h
 	return newRepositoryResponse, resp, QuayApiError{Error: err}
 }
 
+func (c *Client) UpdateRepository(orgName, repositoryName, description string) (*http.Response, QuayApiError) {
+	updateRepository := RepositoryUpdateRequest{
+		Description: description,
+	}
+
+	req, err := c.NewRequest("PUT", fmt.Sprintf("/api/v1/repository/%s/%s", orgName, repositoryName), updateRepository)
+	if err != nil {
+		return nil, QuayApiError{Error: err}
+	}
+
+	resp, err := c.do(req, nil)
+
+	return resp, QuayApiError{Error: err}
+}
+
+func (c *Client) ChangeRepositoryVisibility(orgName, repositoryName, visibility string) (*http.Response, QuayApiError) {
+	visibilityRequest := RepositoryVisibilityRequest{
+		Visibility: visibility,
+	}
+
+	req, err := c.NewRequest("POST", fmt.Sprintf("/api/v1/repository/%s/%s/changevisibility", orgName, repositoryName), visibilityRequest)
+	if err != nil {
+		return nil, QuayApiError{Error: err}
+	}
+
+	resp, err := c.do(req, nil)
+
+	return resp, QuayApiError{Error: err}
+}
+
+func (c *Client) ChangeRepositoryState(orgName, repositoryName, state string) (*http.Response, QuayApiError) {
+	stateRequest := RepositoryStateRequest{
+		State: state,
+	}
+
+	req, err := c.NewRequest("PUT", fmt.Sprintf("/api/v1/repository/%s/%s/changestate", orgName, repositoryName), stateRequest)
+	if err != nil {
+		return nil, QuayApiError{Error: err}
+	}
+
+	resp, err := c.do(req, nil)
+
+	return resp, QuayApiError{Error: err}
+}
+
+func (c *Client) GetRepositoryMirror(orgName, repositoryName string) (RepositoryMirror, *http.Response, QuayApiError) {
+	req, err := c.NewRequest("GET", fmt.Sprintf("/api/v1/repository/%s/%s/mirror", orgName, repositoryName), nil)
+	if err != nil {
+		return RepositoryMirror{}, nil, QuayApiError{Error: err}
+	}
+
+	var mirror RepositoryMirror
+	resp, err := c.do(req, &mirror)
+
+	return mirror, resp, QuayApiError{Error: err}
+}
+
+func (c *Client) CreateRepositoryMirror(orgName, repositoryName string, mirror RepositoryMirrorRequest) (*http.Response, QuayApiError) {
+	req, err := c.NewRequest("POST", fmt.Sprintf("/api/v1/repository/%s/%s/mirror", orgName, repositoryName), mirror)
+	if err != nil {
+		return nil, QuayApiError{Error: err}
+	}
+
+	resp, err := c.do(req, nil)
+
+	return resp, QuayApiError{Error: err}
+}
+
+func (c *Client) UpdateRepositoryMirror(orgName, repositoryName string, mirror RepositoryMirrorRequest) (*http.Response, QuayApiError) {
+	req, err := c.NewRequest("PUT", fmt.Sprintf("/api/v1/repository/%s/%s/mirror", orgName, repositoryName), mirror)
+	if err != nil {
+		return nil, QuayApiError{Error: err}
+	}
+
+	resp, err := c.do(req, nil)
+
+	return resp, QuayApiError{Error: err}
+}
+
+func (c *Client) GetRepositoryUserPermissions(orgName, repositoryName string) (RepositoryPermissionsResponse, *http.Response, QuayApiError) {
+	req, err := c.NewRequest("GET", fmt.Sprintf("/api/v1/repository/%s/%s/permissions/user/", orgName, repositoryName), nil)
+	if err != nil {
+		return RepositoryPermissionsResponse{}, nil, QuayApiError{Error: err}
+	}
+
+	var permissionsResponse RepositoryPermissionsResponse
+	resp, err := c.do(req, &permissionsResponse)
+
+	return permissionsResponse, resp, QuayApiError{Error: err}
+}
+
+func (c *Client) SetRepositoryUserPermission(orgName, repositoryName, username, role string) (RepositoryPermission, *http.Response, QuayApiError) {
+	permissionRequest := RepositoryPermissionRequest{
+		Role: role,
+	}
+
+	req, err := c.NewRequest("PUT", fmt.Sprintf("/api/v1/repository/%s/%s/permissions/user/%s", orgName, repositoryName, username), permissionRequest)
+	if err != nil {
+		return RepositoryPermission{}, nil, QuayApiError{Error: err}
+	}
+
+	var permissionResponse RepositoryPermission
+	resp, err := c.do(req, &permissionResponse)
+
+	return permissionResponse, resp, QuayApiError{Error: err}
+}
+
+func (c *Client) DeleteRepositoryUserPermission(orgName, repositoryName, username string) (*http.Response, QuayApiError) {
+	req, err := c.NewRequest("DELETE", fmt.Sprintf("/api/v1/repository/%s/%s/permissions/user/%s", orgName, repositoryName, username), nil)
+	if err != nil {
+		return nil, QuayApiError{Error: err}
+	}
+
+	resp, err := c.do(req, nil)
+
+	return resp, QuayApiError{Error: err}
+}
+
+func (c *Client) GetRepositoryTeamPermissions(orgName, repositoryName string) (RepositoryPermissionsResponse, *http.Response, QuayApiError) {
+	req, err := c.NewRequest("GET", fmt.Sprintf("/api/v1/repository/%s/%s/permissions/team/", orgName, repositoryName), nil)
+	if err != nil {
+		return RepositoryPermissionsResponse{}, nil, QuayApiError{Error: err}
+	}
+
+	var permissionsResponse RepositoryPermissionsResponse
+	resp, err := c.do(req, &permissionsResponse)
+
+	return permissionsResponse, resp, QuayApiError{Error: err}
+}
+
+func (c *Client) SetRepositoryTeamPermission(orgName, repositoryName, teamName, role string) (RepositoryPermission, *http.Response, QuayApiError) {
+	permissionRequest := RepositoryPermissionRequest{
+		Role: role,
+	}
+
+	req, err := c.NewRequest("PUT", fmt.Sprintf("/api/v1/repository/%s/%s/permissions/team/%s", orgName, repositoryName, teamName), permissionRequest)
+	if err != nil {
+		return RepositoryPermission{}, nil, QuayApiError{Error: err}
+	}
+
+	var permissionResponse RepositoryPermission
+	resp, err := c.do(req, &permissionResponse)
+
+	return permissionResponse, resp, QuayApiError{Error: err}
+}
+
+func (c *Client) GetRepositoryAutoPrunePolicies(orgName, repositoryName string) (AutoPrunePoliciesResponse, *http.Response, QuayApiError) {
+	req, err := c.NewRequest("GET", fmt.Sprintf("/api/v1/repository/%s/%s/autoprunepolicy/", orgName, repositoryName), nil)
+	if err != nil {
+		return AutoPrunePoliciesResponse{}, nil, QuayApiError{Error: err}
+	}
+
+	var policiesResponse AutoPrunePoliciesResponse
+	resp, err := c.do(req, &policiesResponse)
+
+	return policiesResponse, resp, QuayApiError{Error: err}
+}
+
+func (c *Client) CreateRepositoryAutoPrunePolicy(orgName, repositoryName string, policy AutoPrunePolicy) (AutoPrunePolicy, *http.Response, QuayApiError) {
+	req, err := c.NewRequest("POST", fmt.Sprintf("/api/v1/repository/%s/%s/autoprunepolicy/", orgName, repositoryName), policy)
+	if err != nil {
+		return AutoPrunePolicy{}, nil, QuayApiError{Error: err}
+	}
+
+	var policyResponse AutoPrunePolicy
+	resp, err := c.do(req, &policyResponse)
+
+	return policyResponse, resp, QuayApiError{Error: err}
+}
+
+func (c *Client) DeleteRepositoryAutoPrunePolicy(orgName, repositoryName, policyUUID string) (*http.Response, QuayApiError) {
+	req, err := c.NewRequest("DELETE", fmt.Sprintf("/api/v1/repository/%s/%s/autoprunepolicy/%s", orgName, repositoryName, policyUUID), nil)
+	if err != nil {
+		return nil, QuayApiError{Error: err}
+	}
+
+	resp, err := c.do(req, nil)
+
+	return resp, QuayApiError{Error: err}
+}
+
+func (c *Client) GetRepositoryNotifications(orgName, repositoryName string) (NotificationsResponse, *http.Response, QuayApiError) {
+	req, err := c.NewRequest("GET", fmt.Sprintf("/api/v1/repository/%s/%s/notification/", orgName, repositoryName), nil)
+	if err != nil {
+		return NotificationsResponse{}, nil, QuayApiError{Error: err}
+	}
+
+	var notificationsResponse NotificationsResponse
+	resp, err := c.do(req, &notificationsResponse)
+
+	return notificationsResponse, resp, QuayApiError{Error: err}
+}
+
+func (c *Client) CreateRepositoryNotification(orgName, repositoryName string, notification NotificationRequest) (Notification, *http.Response, QuayApiError) {
+	if notification.Config == nil {
+		notification.Config = map[string]interface{}{}
+	}
+
+	if notification.EventConfig == nil {
+		notification.EventConfig = map[string]interface{}{}
+	}
+
+	req, err := c.NewRequest("POST", fmt.Sprintf("/api/v1/repository/%s/%s/notification/", orgName, repositoryName), notification)
+	if err != nil {
+		return Notification{}, nil, QuayApiError{Error: err}
+	}
+
+	var notificationResponse Notification
+	resp, err := c.do(req, &notificationResponse)
+
+	return notificationResponse, resp, QuayApiError{Error: err}
+}
+
+func (c *Client) DeleteRepositoryNotification(orgName, repositoryName, notificationUUID string) (*http.Response, QuayApiError) {
+	req, err := c.NewRequest("DELETE", fmt.Sprintf("/api/v1/repository/%s/%s/notification/%s", orgName, repositoryName, notificationUUID), nil)
+	if err != nil {
+		return nil, QuayApiError{Error: err}
+	}
+
+	resp, err := c.do(req, nil)
+
+	return resp, QuayApiError{Error: err}
+}
+
+func (c *Client) GetManifestSecurity(orgName, repositoryName, manifestDigest string) (ManifestSecurity, *http.Response, QuayApiError) {
+	req, err := c.NewRequest("GET", fmt.Sprintf("/api/v1/repository/%s/%s/manifest/%s/security", orgName, repositoryName, manifestDigest), nil)
+	if err != nil {
+		return ManifestSecurity{}, nil, QuayApiError{Error: err}
+	}
+
+	req.URL.RawQuery = url.Values{"vulnerabilities": {"true"}}.Encode()
+
+	var securityResponse ManifestSecurity
+	resp, err := c.do(req, &securityResponse)
+
+	return securityResponse, resp, QuayApiError{Error: err}
+}
+
+func (c *Client) GetRepositoryTags(orgName, repositoryName string, params url.Values) (TagsResponse, *http.Response, QuayApiError) {
+	req, err := c.NewRequest("GET", fmt.Sprintf("/api/v1/repository/%s/%s/tag/", orgName, repositoryName), nil)
+	if err != nil {
+		return TagsResponse{}, nil, QuayApiError{Error: err}
+	}
+
+	req.URL.RawQuery = params.Encode()
+
+	var tagsResponse TagsResponse
+	resp, err := c.do(req, &tagsResponse)
+
+	return tagsResponse, resp, QuayApiError{Error: err}
+}
+
+func (c *Client) CreateOrUpdateTag(orgName, repositoryName, tag, manifestDigest string) (*http.Response, QuayApiError) {
+	tagRequest := TagRequest{
+		ManifestDigest: manifestDigest,
+	}
+
+	req, err := c.NewRequest("PUT", fmt.Sprintf("/api/v1/repository/%s/%s/tag/%s", orgName, repositoryName, tag), tagRequest)
+	if err != nil {
+		return nil, QuayApiError{Error: err}
+	}
+
+	resp, err := c.do(req, nil)
+
+	return resp, QuayApiError{Error: err}
+}
+
 func (c *Client) NewRequest(method, path string, body interface{}) (*http.Request, error) {
 	rel := &url.URL{Path: path}
 	u := c.BaseURL.ResolveReference(rel)
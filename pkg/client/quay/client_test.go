@@ -5,12 +5,14 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"net/url"
 	"testing"
 
 	"github.com/quay/quay-bridge-operator/pkg/client/quay"
 	mock_quay "github.com/quay/quay-bridge-operator/pkg/client/quay/mocks"
 	"github.com/stretchr/testify/assert"
 	gomock "go.uber.org/mock/gomock"
+	"k8s.io/apimachinery/pkg/util/intstr"
 )
 
 func TestGetUser(t *testing.T) {
@@ -148,7 +150,7 @@ func TestCreateOrganizationByName(t *testing.T) {
 			name:           "Create organization without error",
 			orgName:        "org1",
 			respStatusCode: 200,
-			body:           `{"name": "org1", "email":"org1@redhat.com"}`,
+			body:           `{"name": "org1", "email":"org1@quay.example.com"}`,
 		},
 		{
 			name:    "Create organization with error",
@@ -179,7 +181,7 @@ func TestCreateOrganizationByName(t *testing.T) {
 
 			mockClient.EXPECT().Do(gomock.Any()).Return(mockResp, e)
 
-			o, resp, err := cli.CreateOrganization(tt.name)
+			o, resp, err := cli.CreateOrganization(tt.orgName, "")
 
 			if (err.Error == nil && tt.wantErr != "") || (err.Error != nil && err.Error.Error() != tt.wantErr) {
 				t.Errorf("wanted err to be %v, but got %v", tt.wantErr, err)
@@ -267,6 +269,100 @@ func TestCreateOrganizationRobotAccount(t *testing.T) {
 	}
 }
 
+func TestCreateOrganizationRobotAccountWithMetadata(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockClient := mock_quay.NewMockHttpClient(ctrl)
+	cli := quay.NewClient(mockClient, "localhost", "my-secret-token")
+
+	mockClient.EXPECT().Do(gomock.Any()).DoAndReturn(func(req *http.Request) (*http.Response, error) {
+		assert.Equal(t, "PUT", req.Method)
+		assert.Equal(t, "/api/v1/organization/org1/robots/quaybridge", req.URL.Path)
+
+		body, _ := io.ReadAll(req.Body)
+		assert.JSONEq(t, `{"description": "Ownership marker", "unstructured_metadata": {"clusterID": "cluster1"}}`, string(body))
+
+		return &http.Response{
+			StatusCode: 201,
+			Body:       io.NopCloser(bytes.NewReader([]byte(`{"name": "org1+quaybridge", "description": "Ownership marker", "unstructured_metadata": {"clusterID": "cluster1"}}`))),
+		}, nil
+	})
+
+	robotAccount, resp, err := cli.CreateOrganizationRobotAccountWithMetadata("org1", "quaybridge", quay.RobotAccountRequest{Description: "Ownership marker", UnstructuredMetadata: map[string]string{"clusterID": "cluster1"}})
+
+	assert.Nil(t, err.Error)
+	assert.Equal(t, 201, resp.StatusCode)
+	assert.Equal(t, quay.RobotAccount{Name: "org1+quaybridge", Description: "Ownership marker", UnstructuredMetadata: map[string]interface{}{"clusterID": "cluster1"}}, robotAccount)
+}
+
+func TestDeleteOrganizationRobotAccount(t *testing.T) {
+	tests := []struct {
+		name           string
+		respStatusCode int
+		orgName        string
+		robotName      string
+		body           string
+		wantErr        string
+	}{
+		{
+			name:           "DELETE a RobotAccount without error",
+			respStatusCode: 204,
+			orgName:        "org1",
+			robotName:      "robot",
+		},
+		{
+			name:      "DELETE a RobotAccount with error",
+			orgName:   "org1",
+			robotName: "robot",
+			body:      `{"name", "buynlarge"}`,
+			wantErr:   "{invalid character ',' after object key}",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockClient := mock_quay.NewMockHttpClient(ctrl)
+			cli := quay.NewClient(mockClient, "localhost", "my-secret-token")
+
+			mockResp := &http.Response{
+				StatusCode: tt.respStatusCode,
+				Body:       io.NopCloser(bytes.NewReader([]byte(tt.body))),
+			}
+
+			var e error
+			if tt.wantErr == "" {
+				e = nil
+			} else {
+				e = fmt.Errorf(tt.wantErr)
+			}
+
+			mockClient.EXPECT().Do(gomock.Any()).DoAndReturn(func(req *http.Request) (*http.Response, error) {
+				assert.Equal(t, "DELETE", req.Method)
+				assert.Equal(t, "/api/v1/organization/org1/robots/robot", req.URL.Path)
+				return mockResp, e
+			})
+
+			resp, err := cli.DeleteOrganizationRobotAccount(tt.orgName, tt.robotName)
+
+			if (err.Error == nil && tt.wantErr != "") || (err.Error != nil && err.Error.Error() != tt.wantErr) {
+				t.Errorf("wanted err to be %v, but got %v", tt.wantErr, err)
+			}
+
+			if tt.wantErr != "" {
+				assert.Equal(t, err.Error.Error(), tt.wantErr)
+				return
+			}
+
+			assert.NotNil(t, resp)
+			assert.Equal(t, tt.respStatusCode, resp.StatusCode)
+		})
+	}
+}
+
 func TestGetOrganizationRobotAccount(t *testing.T) {
 	tests := []struct {
 		name           string
@@ -698,7 +794,7 @@ func TestCreateRepository(t *testing.T) {
 
 			mockClient.EXPECT().Do(gomock.Any()).Return(mockResp, e)
 
-			r, resp, err := cli.CreateRepository(tt.orgName, tt.repoName)
+			r, resp, err := cli.CreateRepository(tt.orgName, tt.repoName, quay.RepositoryVisibilityPrivate, "", quay.RepositoryKindImage)
 
 			if (err.Error == nil && tt.wantErr != "") || (err.Error != nil && err.Error.Error() != tt.wantErr) {
 				t.Errorf("wanted err to be %v, but got %v", tt.wantErr, err)
@@ -716,6 +812,954 @@ func TestCreateRepository(t *testing.T) {
 	}
 }
 
+func TestUpdateRepositorySettings(t *testing.T) {
+	tests := []struct {
+		name           string
+		method         string
+		path           string
+		wantBody       string
+		respStatusCode int
+		call           func(cli *quay.Client) (*http.Response, quay.QuayApiError)
+	}{
+		{
+			name:           "PUT repository description",
+			method:         "PUT",
+			path:           "/api/v1/repository/org1/repo1",
+			wantBody:       `{"description":"ImageStream project/repo1"}`,
+			respStatusCode: 200,
+			call: func(cli *quay.Client) (*http.Response, quay.QuayApiError) {
+				return cli.UpdateRepository("org1", "repo1", "ImageStream project/repo1")
+			},
+		},
+		{
+			name:           "POST repository visibility",
+			method:         "POST",
+			path:           "/api/v1/repository/org1/repo1/changevisibility",
+			wantBody:       `{"visibility":"public"}`,
+			respStatusCode: 200,
+			call: func(cli *quay.Client) (*http.Response, quay.QuayApiError) {
+				return cli.ChangeRepositoryVisibility("org1", "repo1", quay.RepositoryVisibilityPublic)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockClient := mock_quay.NewMockHttpClient(ctrl)
+			cli := quay.NewClient(mockClient, "http://localhost", "my-secret-token")
+
+			mockResp := &http.Response{
+				StatusCode: tt.respStatusCode,
+				Body:       io.NopCloser(bytes.NewReader([]byte(`{}`))),
+			}
+
+			mockClient.EXPECT().Do(gomock.Any()).DoAndReturn(func(req *http.Request) (*http.Response, error) {
+				body, _ := io.ReadAll(req.Body)
+				assert.Equal(t, tt.method, req.Method)
+				assert.Equal(t, tt.path, req.URL.Path)
+				assert.JSONEq(t, tt.wantBody, string(body))
+				return mockResp, nil
+			})
+
+			resp, err := tt.call(cli)
+
+			assert.Nil(t, err.Error)
+			assert.Equal(t, tt.respStatusCode, resp.StatusCode)
+		})
+	}
+}
+
+func TestRepositoryUserPermissions(t *testing.T) {
+	tests := []struct {
+		name           string
+		method         string
+		path           string
+		respStatusCode int
+		body           string
+		call           func(cli *quay.Client) (*http.Response, quay.QuayApiError)
+	}{
+		{
+			name:           "GET repository user permissions",
+			method:         "GET",
+			path:           "/api/v1/repository/org1/repo1/permissions/user/",
+			respStatusCode: 200,
+			body:           `{"permissions": {"org2+builder": {"role": "write", "name": "org2+builder", "is_robot": true}}}`,
+			call: func(cli *quay.Client) (*http.Response, quay.QuayApiError) {
+				permissions, resp, err := cli.GetRepositoryUserPermissions("org1", "repo1")
+				assert.Equal(t, quay.RepositoryPermission{Role: "write", Name: "org2+builder", IsRobot: true}, permissions.Permissions["org2+builder"])
+				return resp, err
+			},
+		},
+		{
+			name:           "PUT repository user permission",
+			method:         "PUT",
+			path:           "/api/v1/repository/org1/repo1/permissions/user/org2+builder",
+			respStatusCode: 200,
+			body:           `{"role": "write", "name": "org2+builder", "is_robot": true}`,
+			call: func(cli *quay.Client) (*http.Response, quay.QuayApiError) {
+				permission, resp, err := cli.SetRepositoryUserPermission("org1", "repo1", "org2+builder", "write")
+				assert.Equal(t, "write", permission.Role)
+				return resp, err
+			},
+		},
+		{
+			name:           "DELETE repository user permission",
+			method:         "DELETE",
+			path:           "/api/v1/repository/org1/repo1/permissions/user/org2+builder",
+			respStatusCode: 204,
+			call: func(cli *quay.Client) (*http.Response, quay.QuayApiError) {
+				return cli.DeleteRepositoryUserPermission("org1", "repo1", "org2+builder")
+			},
+		},
+		{
+			name:           "DELETE repository",
+			method:         "DELETE",
+			path:           "/api/v1/repository/org1/project_repo1",
+			respStatusCode: 204,
+			call: func(cli *quay.Client) (*http.Response, quay.QuayApiError) {
+				return cli.DeleteRepository("org1", "project_repo1")
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockClient := mock_quay.NewMockHttpClient(ctrl)
+			cli := quay.NewClient(mockClient, "http://localhost", "my-secret-token")
+
+			mockResp := &http.Response{
+				StatusCode: tt.respStatusCode,
+				Body:       io.NopCloser(bytes.NewReader([]byte(tt.body))),
+			}
+
+			mockClient.EXPECT().Do(gomock.Any()).DoAndReturn(func(req *http.Request) (*http.Response, error) {
+				assert.Equal(t, tt.method, req.Method)
+				assert.Equal(t, tt.path, req.URL.Path)
+				return mockResp, nil
+			})
+
+			resp, err := tt.call(cli)
+
+			assert.Nil(t, err.Error)
+			assert.Equal(t, tt.respStatusCode, resp.StatusCode)
+		})
+	}
+}
+
+func TestRepositoryAutoPrunePolicies(t *testing.T) {
+	tests := []struct {
+		name           string
+		method         string
+		path           string
+		wantBody       string
+		respStatusCode int
+		body           string
+		call           func(cli *quay.Client) (*http.Response, quay.QuayApiError)
+	}{
+		{
+			name:           "GET repository auto prune policies",
+			method:         "GET",
+			path:           "/api/v1/repository/org1/repo1/autoprunepolicy/",
+			respStatusCode: 200,
+			body:           `{"policies": [{"uuid": "1234", "method": "number_of_tags", "value": 10}, {"uuid": "5678", "method": "creation_date", "value": "30d"}]}`,
+			call: func(cli *quay.Client) (*http.Response, quay.QuayApiError) {
+				policies, resp, err := cli.GetRepositoryAutoPrunePolicies("org1", "repo1")
+				assert.Equal(t, []quay.AutoPrunePolicy{
+					{UUID: "1234", Method: quay.AutoPruneMethodNumberOfTags, Value: intstr.FromInt(10)},
+					{UUID: "5678", Method: quay.AutoPruneMethodCreationDate, Value: intstr.FromString("30d")},
+				}, policies.Policies)
+				return resp, err
+			},
+		},
+		{
+			name:           "POST repository auto prune policy",
+			method:         "POST",
+			path:           "/api/v1/repository/org1/repo1/autoprunepolicy/",
+			wantBody:       `{"method": "number_of_tags", "value": 10}`,
+			respStatusCode: 201,
+			body:           `{"uuid": "1234"}`,
+			call: func(cli *quay.Client) (*http.Response, quay.QuayApiError) {
+				policy, resp, err := cli.CreateRepositoryAutoPrunePolicy("org1", "repo1", quay.AutoPrunePolicy{Method: quay.AutoPruneMethodNumberOfTags, Value: intstr.FromInt(10)})
+				assert.Equal(t, "1234", policy.UUID)
+				return resp, err
+			},
+		},
+		{
+			name:           "DELETE repository auto prune policy",
+			method:         "DELETE",
+			path:           "/api/v1/repository/org1/repo1/autoprunepolicy/1234",
+			respStatusCode: 200,
+			call: func(cli *quay.Client) (*http.Response, quay.QuayApiError) {
+				return cli.DeleteRepositoryAutoPrunePolicy("org1", "repo1", "1234")
+			},
+		},
+		{
+			name:           "PUT organization tag expiration",
+			method:         "PUT",
+			path:           "/api/v1/organization/org1",
+			wantBody:       `{"tag_expiration_s": 1209600}`,
+			respStatusCode: 200,
+			call: func(cli *quay.Client) (*http.Response, quay.QuayApiError) {
+				tagExpiration := 1209600
+				return cli.UpdateOrganization("org1", quay.OrganizationUpdateRequest{TagExpirationS: &tagExpiration})
+			},
+		},
+		{
+			name:           "PUT organization contact",
+			method:         "PUT",
+			path:           "/api/v1/organization/org1",
+			wantBody:       `{"email": "org1@quay.example.com", "invoice_email": true, "invoice_email_address": "billing@quay.example.com"}`,
+			respStatusCode: 200,
+			call: func(cli *quay.Client) (*http.Response, quay.QuayApiError) {
+				email := "org1@quay.example.com"
+				invoiceEmail := true
+				invoiceEmailAddress := "billing@quay.example.com"
+				return cli.UpdateOrganization("org1", quay.OrganizationUpdateRequest{Email: &email, InvoiceEmail: &invoiceEmail, InvoiceEmailAddress: &invoiceEmailAddress})
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockClient := mock_quay.NewMockHttpClient(ctrl)
+			cli := quay.NewClient(mockClient, "http://localhost", "my-secret-token")
+
+			mockResp := &http.Response{
+				StatusCode: tt.respStatusCode,
+				Body:       io.NopCloser(bytes.NewReader([]byte(tt.body))),
+			}
+
+			mockClient.EXPECT().Do(gomock.Any()).DoAndReturn(func(req *http.Request) (*http.Response, error) {
+				assert.Equal(t, tt.method, req.Method)
+				assert.Equal(t, tt.path, req.URL.Path)
+				if tt.wantBody != "" {
+					body, _ := io.ReadAll(req.Body)
+					assert.JSONEq(t, tt.wantBody, string(body))
+				}
+				return mockResp, nil
+			})
+
+			resp, err := tt.call(cli)
+
+			assert.Nil(t, err.Error)
+			assert.Equal(t, tt.respStatusCode, resp.StatusCode)
+		})
+	}
+}
+
+func TestOrganizationQuotas(t *testing.T) {
+	tests := []struct {
+		name           string
+		method         string
+		path           string
+		wantBody       string
+		respStatusCode int
+		body           string
+		call           func(cli *quay.Client) (*http.Response, quay.QuayApiError)
+	}{
+		{
+			name:           "GET organization quotas",
+			method:         "GET",
+			path:           "/api/v1/organization/org1/quota",
+			respStatusCode: 200,
+			body:           `[{"id": 1, "limit_bytes": 10737418240, "limits": [{"id": "2", "type": "Warning", "limit_percent": 80}]}]`,
+			call: func(cli *quay.Client) (*http.Response, quay.QuayApiError) {
+				quotas, resp, err := cli.GetOrganizationQuotas("org1")
+				assert.Equal(t, []quay.OrganizationQuota{
+					{ID: 1, LimitBytes: 10737418240, Limits: []quay.OrganizationQuotaLimit{{ID: "2", Type: quay.QuotaLimitTypeWarning, LimitPercent: 80}}},
+				}, quotas)
+				return resp, err
+			},
+		},
+		{
+			name:           "POST organization quota",
+			method:         "POST",
+			path:           "/api/v1/organization/org1/quota",
+			wantBody:       `{"limit_bytes": 10737418240}`,
+			respStatusCode: 201,
+			call: func(cli *quay.Client) (*http.Response, quay.QuayApiError) {
+				return cli.CreateOrganizationQuota("org1", 10737418240)
+			},
+		},
+		{
+			name:           "PUT organization quota",
+			method:         "PUT",
+			path:           "/api/v1/organization/org1/quota/1",
+			wantBody:       `{"limit_bytes": 5368709120}`,
+			respStatusCode: 200,
+			call: func(cli *quay.Client) (*http.Response, quay.QuayApiError) {
+				return cli.UpdateOrganizationQuota("org1", 1, 5368709120)
+			},
+		},
+		{
+			name:           "DELETE organization quota",
+			method:         "DELETE",
+			path:           "/api/v1/organization/org1/quota/1",
+			respStatusCode: 204,
+			call: func(cli *quay.Client) (*http.Response, quay.QuayApiError) {
+				return cli.DeleteOrganizationQuota("org1", 1)
+			},
+		},
+		{
+			name:           "POST organization quota limit",
+			method:         "POST",
+			path:           "/api/v1/organization/org1/quota/1/limit",
+			wantBody:       `{"type": "Reject", "threshold_percent": 100}`,
+			respStatusCode: 201,
+			call: func(cli *quay.Client) (*http.Response, quay.QuayApiError) {
+				return cli.CreateOrganizationQuotaLimit("org1", 1, quay.QuotaLimitTypeReject, 100)
+			},
+		},
+		{
+			name:           "PUT organization quota limit",
+			method:         "PUT",
+			path:           "/api/v1/organization/org1/quota/1/limit/2",
+			wantBody:       `{"type": "Warning", "threshold_percent": 90}`,
+			respStatusCode: 200,
+			call: func(cli *quay.Client) (*http.Response, quay.QuayApiError) {
+				return cli.UpdateOrganizationQuotaLimit("org1", 1, "2", quay.QuotaLimitTypeWarning, 90)
+			},
+		},
+		{
+			name:           "DELETE organization quota limit",
+			method:         "DELETE",
+			path:           "/api/v1/organization/org1/quota/1/limit/2",
+			respStatusCode: 204,
+			call: func(cli *quay.Client) (*http.Response, quay.QuayApiError) {
+				return cli.DeleteOrganizationQuotaLimit("org1", 1, "2")
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockClient := mock_quay.NewMockHttpClient(ctrl)
+			cli := quay.NewClient(mockClient, "http://localhost", "my-secret-token")
+
+			mockResp := &http.Response{
+				StatusCode: tt.respStatusCode,
+				Body:       io.NopCloser(bytes.NewReader([]byte(tt.body))),
+			}
+
+			mockClient.EXPECT().Do(gomock.Any()).DoAndReturn(func(req *http.Request) (*http.Response, error) {
+				assert.Equal(t, tt.method, req.Method)
+				assert.Equal(t, tt.path, req.URL.Path)
+				if tt.wantBody != "" {
+					body, _ := io.ReadAll(req.Body)
+					assert.JSONEq(t, tt.wantBody, string(body))
+				}
+				return mockResp, nil
+			})
+
+			resp, err := tt.call(cli)
+
+			assert.Nil(t, err.Error)
+			assert.Equal(t, tt.respStatusCode, resp.StatusCode)
+		})
+	}
+}
+
+func TestTeams(t *testing.T) {
+	tests := []struct {
+		name           string
+		method         string
+		path           string
+		wantBody       string
+		respStatusCode int
+		body           string
+		call           func(cli *quay.Client) (*http.Response, quay.QuayApiError)
+	}{
+		{
+			name:           "PUT team",
+			method:         "PUT",
+			path:           "/api/v1/organization/org1/team/editors",
+			wantBody:       `{"role": "member", "description": "Editors"}`,
+			respStatusCode: 200,
+			body:           `{"name": "editors", "role": "member", "description": "Editors"}`,
+			call: func(cli *quay.Client) (*http.Response, quay.QuayApiError) {
+				team, resp, err := cli.CreateOrUpdateTeam("org1", "editors", quay.TeamRoleMember, "Editors")
+				assert.Equal(t, quay.Team{Name: "editors", Role: quay.TeamRoleMember, Description: "Editors"}, team)
+				return resp, err
+			},
+		},
+		{
+			name:           "DELETE team",
+			method:         "DELETE",
+			path:           "/api/v1/organization/org1/team/editors",
+			respStatusCode: 204,
+			call: func(cli *quay.Client) (*http.Response, quay.QuayApiError) {
+				return cli.DeleteTeam("org1", "editors")
+			},
+		},
+		{
+			name:           "GET team members",
+			method:         "GET",
+			path:           "/api/v1/organization/org1/team/editors/members",
+			respStatusCode: 200,
+			body:           `{"members": [{"name": "alice", "kind": "user", "is_robot": false}, {"name": "org1+builder", "kind": "user", "is_robot": true}]}`,
+			call: func(cli *quay.Client) (*http.Response, quay.QuayApiError) {
+				members, resp, err := cli.GetTeamMembers("org1", "editors")
+				assert.Equal(t, []quay.TeamMember{{Name: "alice", Kind: "user"}, {Name: "org1+builder", Kind: "user", IsRobot: true}}, members.Members)
+				return resp, err
+			},
+		},
+		{
+			name:           "PUT team member",
+			method:         "PUT",
+			path:           "/api/v1/organization/org1/team/editors/members/alice",
+			respStatusCode: 200,
+			call: func(cli *quay.Client) (*http.Response, quay.QuayApiError) {
+				return cli.AddTeamMember("org1", "editors", "alice")
+			},
+		},
+		{
+			name:           "DELETE team member",
+			method:         "DELETE",
+			path:           "/api/v1/organization/org1/team/editors/members/alice",
+			respStatusCode: 204,
+			call: func(cli *quay.Client) (*http.Response, quay.QuayApiError) {
+				return cli.RemoveTeamMember("org1", "editors", "alice")
+			},
+		},
+		{
+			name:           "GET repository team permissions",
+			method:         "GET",
+			path:           "/api/v1/repository/org1/repo1/permissions/team/",
+			respStatusCode: 200,
+			body:           `{"permissions": {"editors": {"role": "write", "name": "editors"}}}`,
+			call: func(cli *quay.Client) (*http.Response, quay.QuayApiError) {
+				permissions, resp, err := cli.GetRepositoryTeamPermissions("org1", "repo1")
+				assert.Equal(t, "write", permissions.Permissions["editors"].Role)
+				return resp, err
+			},
+		},
+		{
+			name:           "PUT repository team permission",
+			method:         "PUT",
+			path:           "/api/v1/repository/org1/repo1/permissions/team/viewers",
+			wantBody:       `{"role": "read"}`,
+			respStatusCode: 200,
+			body:           `{"role": "read", "name": "viewers"}`,
+			call: func(cli *quay.Client) (*http.Response, quay.QuayApiError) {
+				_, resp, err := cli.SetRepositoryTeamPermission("org1", "repo1", "viewers", "read")
+				return resp, err
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockClient := mock_quay.NewMockHttpClient(ctrl)
+			cli := quay.NewClient(mockClient, "http://localhost", "my-secret-token")
+
+			mockResp := &http.Response{
+				StatusCode: tt.respStatusCode,
+				Body:       io.NopCloser(bytes.NewReader([]byte(tt.body))),
+			}
+
+			mockClient.EXPECT().Do(gomock.Any()).DoAndReturn(func(req *http.Request) (*http.Response, error) {
+				assert.Equal(t, tt.method, req.Method)
+				assert.Equal(t, tt.path, req.URL.Path)
+				if tt.wantBody != "" {
+					body, _ := io.ReadAll(req.Body)
+					assert.JSONEq(t, tt.wantBody, string(body))
+				}
+				return mockResp, nil
+			})
+
+			resp, err := tt.call(cli)
+
+			assert.Nil(t, err.Error)
+			assert.Equal(t, tt.respStatusCode, resp.StatusCode)
+		})
+	}
+}
+
+func TestRepositoryNotifications(t *testing.T) {
+	tests := []struct {
+		name           string
+		method         string
+		path           string
+		wantBody       string
+		respStatusCode int
+		body           string
+		call           func(cli *quay.Client) (*http.Response, quay.QuayApiError)
+	}{
+		{
+			name:           "GET repository notifications",
+			method:         "GET",
+			path:           "/api/v1/repository/org1/repo1/notification/",
+			respStatusCode: 200,
+			body:           `{"notifications": [{"uuid": "1234", "title": "pushes", "event": "repo_push", "method": "slack", "config": {"url": "https://hooks.slack.com/services/x"}, "event_config": {}}]}`,
+			call: func(cli *quay.Client) (*http.Response, quay.QuayApiError) {
+				notifications, resp, err := cli.GetRepositoryNotifications("org1", "repo1")
+				assert.Equal(t, []quay.Notification{
+					{UUID: "1234", Title: "pushes", Event: "repo_push", Method: "slack", Config: map[string]interface{}{"url": "https://hooks.slack.com/services/x"}, EventConfig: map[string]interface{}{}},
+				}, notifications.Notifications)
+				return resp, err
+			},
+		},
+		{
+			name:           "POST repository notification",
+			method:         "POST",
+			path:           "/api/v1/repository/org1/repo1/notification/",
+			wantBody:       `{"title": "vulnerabilities", "event": "vulnerability_found", "method": "webhook", "config": {"url": "https://example.com"}, "eventConfig": {"level": 3}}`,
+			respStatusCode: 201,
+			body:           `{"uuid": "5678"}`,
+			call: func(cli *quay.Client) (*http.Response, quay.QuayApiError) {
+				notification, resp, err := cli.CreateRepositoryNotification("org1", "repo1", quay.NotificationRequest{
+					Title:       "vulnerabilities",
+					Event:       "vulnerability_found",
+					Method:      "webhook",
+					Config:      map[string]interface{}{"url": "https://example.com"},
+					EventConfig: map[string]interface{}{"level": 3},
+				})
+				assert.Equal(t, "5678", notification.UUID)
+				return resp, err
+			},
+		},
+		{
+			name:           "POST repository notification without configuration",
+			method:         "POST",
+			path:           "/api/v1/repository/org1/repo1/notification/",
+			wantBody:       `{"title": "pushes", "event": "repo_push", "method": "quay_notification", "config": {}, "eventConfig": {}}`,
+			respStatusCode: 201,
+			body:           `{"uuid": "5678"}`,
+			call: func(cli *quay.Client) (*http.Response, quay.QuayApiError) {
+				_, resp, err := cli.CreateRepositoryNotification("org1", "repo1", quay.NotificationRequest{Title: "pushes", Event: "repo_push", Method: "quay_notification"})
+				return resp, err
+			},
+		},
+		{
+			name:           "DELETE repository notification",
+			method:         "DELETE",
+			path:           "/api/v1/repository/org1/repo1/notification/1234",
+			respStatusCode: 204,
+			call: func(cli *quay.Client) (*http.Response, quay.QuayApiError) {
+				return cli.DeleteRepositoryNotification("org1", "repo1", "1234")
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockClient := mock_quay.NewMockHttpClient(ctrl)
+			cli := quay.NewClient(mockClient, "http://localhost", "my-secret-token")
+
+			mockResp := &http.Response{
+				StatusCode: tt.respStatusCode,
+				Body:       io.NopCloser(bytes.NewReader([]byte(tt.body))),
+			}
+
+			mockClient.EXPECT().Do(gomock.Any()).DoAndReturn(func(req *http.Request) (*http.Response, error) {
+				assert.Equal(t, tt.method, req.Method)
+				assert.Equal(t, tt.path, req.URL.Path)
+				if tt.wantBody != "" {
+					body, _ := io.ReadAll(req.Body)
+					assert.JSONEq(t, tt.wantBody, string(body))
+				}
+				return mockResp, nil
+			})
+
+			resp, err := tt.call(cli)
+
+			assert.Nil(t, err.Error)
+			assert.Equal(t, tt.respStatusCode, resp.StatusCode)
+		})
+	}
+}
+
+func TestRepositoryMirror(t *testing.T) {
+	tests := []struct {
+		name           string
+		method         string
+		path           string
+		wantBody       string
+		respStatusCode int
+		body           string
+		call           func(cli *quay.Client) (*http.Response, quay.QuayApiError)
+	}{
+		{
+			name:           "PUT repository state",
+			method:         "PUT",
+			path:           "/api/v1/repository/org1/repo1/changestate",
+			wantBody:       `{"state": "MIRROR"}`,
+			respStatusCode: 200,
+			call: func(cli *quay.Client) (*http.Response, quay.QuayApiError) {
+				return cli.ChangeRepositoryState("org1", "repo1", quay.RepositoryStateMirror)
+			},
+		},
+		{
+			name:           "GET repository mirror",
+			method:         "GET",
+			path:           "/api/v1/repository/org1/repo1/mirror",
+			respStatusCode: 200,
+			body:           `{"is_enabled": true, "external_reference": "registry.access.redhat.com/ubi9/ubi", "external_registry_username": null, "external_registry_config": {"verify_tls": true}, "sync_interval": 86400, "sync_status": "SUCCESS", "root_rule": {"rule_kind": "tag_glob_csv", "rule_value": ["latest", "9.*"]}, "robot_username": "org1+builder"}`,
+			call: func(cli *quay.Client) (*http.Response, quay.QuayApiError) {
+				mirror, resp, err := cli.GetRepositoryMirror("org1", "repo1")
+				assert.Equal(t, quay.RepositoryMirror{
+					IsEnabled:              true,
+					ExternalReference:      "registry.access.redhat.com/ubi9/ubi",
+					ExternalRegistryConfig: quay.RepositoryMirrorRegistryConfig{VerifyTLS: true},
+					SyncInterval:           86400,
+					SyncStatus:             quay.MirrorSyncStatusSuccess,
+					RootRule:               quay.RepositoryMirrorRule{RuleKind: quay.MirrorRuleKindTagGlobCSV, RuleValue: []string{"latest", "9.*"}},
+					RobotUsername:          "org1+builder",
+				}, mirror)
+				return resp, err
+			},
+		},
+		{
+			name:           "POST repository mirror",
+			method:         "POST",
+			path:           "/api/v1/repository/org1/repo1/mirror",
+			wantBody:       `{"is_enabled": true, "external_reference": "registry.example.com/base/image", "external_registry_username": "user", "external_registry_password": "secret", "external_registry_config": {"verify_tls": false}, "sync_interval": 3600, "sync_start_date": "2024-01-01T00:00:00Z", "root_rule": {"rule_kind": "tag_glob_csv", "rule_value": ["latest"]}, "robot_username": "org1+builder"}`,
+			respStatusCode: 201,
+			call: func(cli *quay.Client) (*http.Response, quay.QuayApiError) {
+				return cli.CreateRepositoryMirror("org1", "repo1", quay.RepositoryMirrorRequest{
+					IsEnabled:                true,
+					ExternalReference:        "registry.example.com/base/image",
+					ExternalRegistryUsername: "user",
+					ExternalRegistryPassword: "secret",
+					SyncInterval:             3600,
+					SyncStartDate:            "2024-01-01T00:00:00Z",
+					RootRule:                 quay.RepositoryMirrorRule{RuleKind: quay.MirrorRuleKindTagGlobCSV, RuleValue: []string{"latest"}},
+					RobotUsername:            "org1+builder",
+				})
+			},
+		},
+		{
+			name:           "PUT repository mirror",
+			method:         "PUT",
+			path:           "/api/v1/repository/org1/repo1/mirror",
+			wantBody:       `{"is_enabled": true, "external_reference": "registry.example.com/base/image", "external_registry_config": {"verify_tls": true}, "sync_interval": 3600, "root_rule": {"rule_kind": "tag_glob_csv", "rule_value": ["latest"]}, "robot_username": "org1+builder"}`,
+			respStatusCode: 201,
+			call: func(cli *quay.Client) (*http.Response, quay.QuayApiError) {
+				return cli.UpdateRepositoryMirror("org1", "repo1", quay.RepositoryMirrorRequest{
+					IsEnabled:              true,
+					ExternalReference:      "registry.example.com/base/image",
+					ExternalRegistryConfig: quay.RepositoryMirrorRegistryConfig{VerifyTLS: true},
+					SyncInterval:           3600,
+					RootRule:               quay.RepositoryMirrorRule{RuleKind: quay.MirrorRuleKindTagGlobCSV, RuleValue: []string{"latest"}},
+					RobotUsername:          "org1+builder",
+				})
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockClient := mock_quay.NewMockHttpClient(ctrl)
+			cli := quay.NewClient(mockClient, "http://localhost", "my-secret-token")
+
+			mockResp := &http.Response{
+				StatusCode: tt.respStatusCode,
+				Body:       io.NopCloser(bytes.NewReader([]byte(tt.body))),
+			}
+
+			mockClient.EXPECT().Do(gomock.Any()).DoAndReturn(func(req *http.Request) (*http.Response, error) {
+				assert.Equal(t, tt.method, req.Method)
+				assert.Equal(t, tt.path, req.URL.Path)
+				if tt.wantBody != "" {
+					body, _ := io.ReadAll(req.Body)
+					assert.JSONEq(t, tt.wantBody, string(body))
+				}
+				return mockResp, nil
+			})
+
+			resp, err := tt.call(cli)
+
+			assert.Nil(t, err.Error)
+			assert.Equal(t, tt.respStatusCode, resp.StatusCode)
+		})
+	}
+}
+
+func TestGetRepositoryTags(t *testing.T) {
+	tests := []struct {
+		name           string
+		respStatusCode int
+		body           string
+		wantTags       quay.TagsResponse
+		wantErr        string
+	}{
+		{
+			name:           "GET repository tags without error",
+			respStatusCode: 200,
+			body:           `{"tags": [{"name": "latest", "manifest_digest": "sha256:abc"}], "page": 1, "has_additional": false}`,
+			wantTags: quay.TagsResponse{
+				Tags: []quay.Tag{{Name: "latest", ManifestDigest: "sha256:abc"}},
+				Page: 1,
+			},
+		},
+		{
+			name:    "GET repository tags with error",
+			body:    `{"tags", []}`,
+			wantErr: "{invalid character ',' after object key}",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockClient := mock_quay.NewMockHttpClient(ctrl)
+			cli := quay.NewClient(mockClient, "http://localhost", "my-secret-token")
+
+			mockResp := &http.Response{
+				StatusCode: tt.respStatusCode,
+				Body:       io.NopCloser(bytes.NewReader([]byte(tt.body))),
+			}
+
+			var e error
+			if tt.wantErr != "" {
+				e = fmt.Errorf(tt.wantErr)
+			}
+
+			mockClient.EXPECT().Do(gomock.Any()).DoAndReturn(func(req *http.Request) (*http.Response, error) {
+				assert.Equal(t, "/api/v1/repository/org1/repo1/tag/", req.URL.Path)
+				assert.Equal(t, "latest", req.URL.Query().Get("specificTag"))
+				return mockResp, e
+			})
+
+			tags, resp, err := cli.GetRepositoryTags("org1", "repo1", url.Values{"specificTag": {"latest"}})
+
+			if tt.wantErr != "" {
+				assert.Equal(t, tt.wantErr, err.Error.Error())
+				return
+			}
+
+			assert.Nil(t, err.Error)
+			assert.Equal(t, tt.respStatusCode, resp.StatusCode)
+			assert.Equal(t, tt.wantTags, tags)
+		})
+	}
+}
+
+func TestGetRepositories(t *testing.T) {
+	tests := []struct {
+		name             string
+		nextPage         string
+		respStatusCode   int
+		body             string
+		wantRepositories quay.RepositoriesResponse
+		wantErr          string
+	}{
+		{
+			name:           "GET repositories without error",
+			respStatusCode: 200,
+			body:           `{"repositories": [{"namespace": "org1", "name": "repo1", "is_public": false}], "next_page": "abc"}`,
+			wantRepositories: quay.RepositoriesResponse{
+				Repositories: []quay.Repository{{Namespace: "org1", Name: "repo1"}},
+				NextPage:     "abc",
+			},
+		},
+		{
+			name:           "GET next page of repositories",
+			nextPage:       "abc",
+			respStatusCode: 200,
+			body:           `{"repositories": [{"namespace": "org1", "name": "repo2", "is_public": true}]}`,
+			wantRepositories: quay.RepositoriesResponse{
+				Repositories: []quay.Repository{{Namespace: "org1", Name: "repo2", IsPublic: true}},
+			},
+		},
+		{
+			name:    "GET repositories with error",
+			body:    `{"repositories", []}`,
+			wantErr: "{invalid character ',' after object key}",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockClient := mock_quay.NewMockHttpClient(ctrl)
+			cli := quay.NewClient(mockClient, "http://localhost", "my-secret-token")
+
+			mockResp := &http.Response{
+				StatusCode: tt.respStatusCode,
+				Body:       io.NopCloser(bytes.NewReader([]byte(tt.body))),
+			}
+
+			var e error
+			if tt.wantErr != "" {
+				e = fmt.Errorf(tt.wantErr)
+			}
+
+			mockClient.EXPECT().Do(gomock.Any()).DoAndReturn(func(req *http.Request) (*http.Response, error) {
+				assert.Equal(t, "/api/v1/repository", req.URL.Path)
+				assert.Equal(t, "org1", req.URL.Query().Get("namespace"))
+				assert.Equal(t, tt.nextPage, req.URL.Query().Get("next_page"))
+				return mockResp, e
+			})
+
+			repositories, resp, err := cli.GetRepositories("org1", tt.nextPage)
+
+			if tt.wantErr != "" {
+				assert.Equal(t, tt.wantErr, err.Error.Error())
+				return
+			}
+
+			assert.Nil(t, err.Error)
+			assert.Equal(t, tt.respStatusCode, resp.StatusCode)
+			assert.Equal(t, tt.wantRepositories, repositories)
+		})
+	}
+}
+
+func TestGetManifestSecurity(t *testing.T) {
+	tests := []struct {
+		name           string
+		respStatusCode int
+		body           string
+		wantSecurity   quay.ManifestSecurity
+		wantErr        string
+	}{
+		{
+			name:           "GET manifest security of a scanned manifest",
+			respStatusCode: 200,
+			body:           `{"status": "scanned", "data": {"Layer": {"Features": [{"Name": "openssl", "Version": "1.1.1k", "Vulnerabilities": [{"Name": "CVE-2023-0286", "Severity": "High", "FixedBy": "1.1.1t"}]}]}}}`,
+			wantSecurity: quay.ManifestSecurity{
+				Status: quay.SecurityScanStatusScanned,
+				Data: &quay.ManifestSecurityData{
+					Layer: quay.SecurityLayer{
+						Features: []quay.SecurityFeature{
+							{Name: "openssl", Version: "1.1.1k", Vulnerabilities: []quay.Vulnerability{{Name: "CVE-2023-0286", Severity: "High", FixedBy: "1.1.1t"}}},
+						},
+					},
+				},
+			},
+		},
+		{
+			name:           "GET manifest security of a queued manifest",
+			respStatusCode: 200,
+			body:           `{"status": "queued", "data": null}`,
+			wantSecurity:   quay.ManifestSecurity{Status: quay.SecurityScanStatusQueued},
+		},
+		{
+			name:    "GET manifest security with error",
+			body:    `{"status", []}`,
+			wantErr: "{invalid character ',' after object key}",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockClient := mock_quay.NewMockHttpClient(ctrl)
+			cli := quay.NewClient(mockClient, "http://localhost", "my-secret-token")
+
+			mockResp := &http.Response{
+				StatusCode: tt.respStatusCode,
+				Body:       io.NopCloser(bytes.NewReader([]byte(tt.body))),
+			}
+
+			var e error
+			if tt.wantErr != "" {
+				e = fmt.Errorf(tt.wantErr)
+			}
+
+			mockClient.EXPECT().Do(gomock.Any()).DoAndReturn(func(req *http.Request) (*http.Response, error) {
+				assert.Equal(t, "/api/v1/repository/org1/repo1/manifest/sha256:abc/security", req.URL.Path)
+				assert.Equal(t, "true", req.URL.Query().Get("vulnerabilities"))
+				return mockResp, e
+			})
+
+			security, resp, err := cli.GetManifestSecurity("org1", "repo1", "sha256:abc")
+
+			if tt.wantErr != "" {
+				assert.Equal(t, tt.wantErr, err.Error.Error())
+				return
+			}
+
+			assert.Nil(t, err.Error)
+			assert.Equal(t, tt.respStatusCode, resp.StatusCode)
+			assert.Equal(t, tt.wantSecurity, security)
+		})
+	}
+}
+
+func TestCreateOrUpdateTag(t *testing.T) {
+	tests := []struct {
+		name           string
+		respStatusCode int
+		body           string
+		wantErr        string
+	}{
+		{
+			name:           "PUT a Tag without error",
+			respStatusCode: 201,
+			body:           `"Updated"`,
+		},
+		{
+			name:    "PUT a Tag with error",
+			wantErr: "http error",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockClient := mock_quay.NewMockHttpClient(ctrl)
+			cli := quay.NewClient(mockClient, "http://localhost", "my-secret-token")
+
+			mockResp := &http.Response{
+				StatusCode: tt.respStatusCode,
+				Body:       io.NopCloser(bytes.NewReader([]byte(tt.body))),
+			}
+
+			var e error
+			if tt.wantErr != "" {
+				e = fmt.Errorf(tt.wantErr)
+			}
+
+			mockClient.EXPECT().Do(gomock.Any()).DoAndReturn(func(req *http.Request) (*http.Response, error) {
+				assert.Equal(t, "PUT", req.Method)
+				assert.Equal(t, "/api/v1/repository/org1/repo1/tag/v1.0", req.URL.Path)
+				return mockResp, e
+			})
+
+			resp, err := cli.CreateOrUpdateTag("org1", "repo1", "v1.0", "sha256:abc")
+
+			if tt.wantErr != "" {
+				assert.Equal(t, tt.wantErr, err.Error.Error())
+				return
+			}
+
+			assert.Nil(t, err.Error)
+			assert.Equal(t, tt.respStatusCode, resp.StatusCode)
+		})
+	}
+}
+
 func TestNewRequest(t *testing.T) {
 	tests := []struct {
 		name        string
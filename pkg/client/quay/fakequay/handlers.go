@@ -0,0 +1,780 @@
+package fakequay
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+
+	qclient "github.com/quay/quay-bridge-operator/pkg/client/quay"
+)
+
+const (
+	defaultTagsPageSize = 50
+)
+
+func (s *Server) registerHandlers() {
+	s.mux.HandleFunc("GET /api/v1/user", s.getUser)
+
+	s.mux.HandleFunc("POST /api/v1/organization/{$}", s.createOrganizationHandler)
+	s.mux.HandleFunc("GET /api/v1/organization/{org}", s.withOrganization(s.getOrganization))
+	s.mux.HandleFunc("PUT /api/v1/organization/{org}", s.withOrganization(s.updateOrganization))
+	s.mux.HandleFunc("DELETE /api/v1/organization/{org}", s.withOrganization(s.deleteOrganization))
+
+	s.mux.HandleFunc("GET /api/v1/organization/{org}/quota", s.withOrganization(s.getQuotas))
+	s.mux.HandleFunc("POST /api/v1/organization/{org}/quota", s.withOrganization(s.createQuota))
+	s.mux.HandleFunc("PUT /api/v1/organization/{org}/quota/{quota}", s.withOrganization(s.updateQuota))
+	s.mux.HandleFunc("DELETE /api/v1/organization/{org}/quota/{quota}", s.withOrganization(s.deleteQuota))
+	s.mux.HandleFunc("POST /api/v1/organization/{org}/quota/{quota}/limit", s.withOrganization(s.createQuotaLimit))
+	s.mux.HandleFunc("PUT /api/v1/organization/{org}/quota/{quota}/limit/{limit}", s.withOrganization(s.updateQuotaLimit))
+	s.mux.HandleFunc("DELETE /api/v1/organization/{org}/quota/{quota}/limit/{limit}", s.withOrganization(s.deleteQuotaLimit))
+
+	s.mux.HandleFunc("PUT /api/v1/organization/{org}/team/{team}", s.withOrganization(s.createOrUpdateTeam))
+	s.mux.HandleFunc("DELETE /api/v1/organization/{org}/team/{team}", s.withOrganization(s.deleteTeam))
+	s.mux.HandleFunc("GET /api/v1/organization/{org}/team/{team}/members", s.withOrganization(s.getTeamMembers))
+	s.mux.HandleFunc("PUT /api/v1/organization/{org}/team/{team}/members/{member}", s.withOrganization(s.addTeamMember))
+	s.mux.HandleFunc("DELETE /api/v1/organization/{org}/team/{team}/members/{member}", s.withOrganization(s.removeTeamMember))
+
+	s.mux.HandleFunc("GET /api/v1/organization/{org}/robots/{robot}", s.withOrganization(s.getRobotAccount))
+	s.mux.HandleFunc("PUT /api/v1/organization/{org}/robots/{robot}", s.withOrganization(s.createRobotAccount))
+	s.mux.HandleFunc("DELETE /api/v1/organization/{org}/robots/{robot}", s.withOrganization(s.deleteRobotAccount))
+
+	s.mux.HandleFunc("GET /api/v1/organization/{org}/prototypes", s.withOrganization(s.getPrototypes))
+	s.mux.HandleFunc("POST /api/v1/organization/{org}/prototypes", s.withOrganization(s.createPrototype))
+
+	s.mux.HandleFunc("GET /api/v1/repository", s.getRepositories)
+	s.mux.HandleFunc("POST /api/v1/repository", s.createRepositoryHandler)
+	s.mux.HandleFunc("GET /api/v1/repository/{org}/{repo}", s.withRepository(s.getRepository))
+	s.mux.HandleFunc("PUT /api/v1/repository/{org}/{repo}", s.withRepository(s.updateRepository))
+	s.mux.HandleFunc("DELETE /api/v1/repository/{org}/{repo}", s.withRepository(s.deleteRepository))
+	s.mux.HandleFunc("POST /api/v1/repository/{org}/{repo}/changevisibility", s.withRepository(s.changeRepositoryVisibility))
+	s.mux.HandleFunc("PUT /api/v1/repository/{org}/{repo}/changestate", s.withRepository(s.changeRepositoryState))
+
+	s.mux.HandleFunc("GET /api/v1/repository/{org}/{repo}/mirror", s.withRepository(s.getRepositoryMirror))
+	s.mux.HandleFunc("POST /api/v1/repository/{org}/{repo}/mirror", s.withRepository(s.setRepositoryMirror))
+	s.mux.HandleFunc("PUT /api/v1/repository/{org}/{repo}/mirror", s.withRepository(s.setRepositoryMirror))
+
+	s.mux.HandleFunc("GET /api/v1/repository/{org}/{repo}/permissions/user/{$}", s.withRepository(s.getUserPermissions))
+	s.mux.HandleFunc("PUT /api/v1/repository/{org}/{repo}/permissions/user/{user}", s.withRepository(s.setUserPermission))
+	s.mux.HandleFunc("DELETE /api/v1/repository/{org}/{repo}/permissions/user/{user}", s.withRepository(s.deleteUserPermission))
+	s.mux.HandleFunc("GET /api/v1/repository/{org}/{repo}/permissions/team/{$}", s.withRepository(s.getTeamPermissions))
+	s.mux.HandleFunc("PUT /api/v1/repository/{org}/{repo}/permissions/team/{team}", s.withRepository(s.setTeamPermission))
+
+	s.mux.HandleFunc("GET /api/v1/repository/{org}/{repo}/autoprunepolicy/{$}", s.withRepository(s.getAutoPrunePolicies))
+	s.mux.HandleFunc("POST /api/v1/repository/{org}/{repo}/autoprunepolicy/{$}", s.withRepository(s.createAutoPrunePolicy))
+	s.mux.HandleFunc("DELETE /api/v1/repository/{org}/{repo}/autoprunepolicy/{policy}", s.withRepository(s.deleteAutoPrunePolicy))
+
+	s.mux.HandleFunc("GET /api/v1/repository/{org}/{repo}/notification/{$}", s.withRepository(s.getNotifications))
+	s.mux.HandleFunc("POST /api/v1/repository/{org}/{repo}/notification/{$}", s.withRepository(s.createNotification))
+	s.mux.HandleFunc("DELETE /api/v1/repository/{org}/{repo}/notification/{notification}", s.withRepository(s.deleteNotification))
+
+	s.mux.HandleFunc("GET /api/v1/repository/{org}/{repo}/manifest/{digest}/security", s.withRepository(s.getManifestSecurity))
+
+	s.mux.HandleFunc("GET /api/v1/repository/{org}/{repo}/tag/{$}", s.withRepository(s.getTags))
+	s.mux.HandleFunc("PUT /api/v1/repository/{org}/{repo}/tag/{tag}", s.withRepository(s.setTag))
+}
+
+// withOrganization resolves the organization of a request, responding 404 when it does not exist
+func (s *Server) withOrganization(handler func(http.ResponseWriter, *http.Request, *organization)) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		org, found := s.organizations[r.PathValue("org")]
+		if !found {
+			writeError(w, http.StatusNotFound, "Not Found")
+			return
+		}
+
+		handler(w, r, org)
+	}
+}
+
+// withRepository resolves the repository of a request, responding 404 when it does not exist
+func (s *Server) withRepository(handler func(http.ResponseWriter, *http.Request, *repository)) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		repo, found := s.repositories[repositoryKey(r.PathValue("org"), r.PathValue("repo"))]
+		if !found {
+			writeError(w, http.StatusNotFound, "Not Found")
+			return
+		}
+
+		handler(w, r, repo)
+	}
+}
+
+func decodeBody(w http.ResponseWriter, r *http.Request, v interface{}) bool {
+	if err := json.NewDecoder(r.Body).Decode(v); err != nil {
+		writeError(w, http.StatusBadRequest, fmt.Sprintf("Invalid request body: %v", err))
+		return false
+	}
+
+	return true
+}
+
+func writeNoContent(w http.ResponseWriter) {
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) getUser(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, qclient.User{Username: Username})
+}
+
+func (s *Server) createOrganizationHandler(w http.ResponseWriter, r *http.Request) {
+	request := qclient.OrganizationRequest{}
+	if !decodeBody(w, r, &request) {
+		return
+	}
+
+	if _, found := s.organizations[request.Name]; found || s.users[request.Name] {
+		writeError(w, http.StatusBadRequest, "A user or organization with this name already exists")
+		return
+	}
+
+	if request.Email != "" && s.emailInUse(request.Email, "") {
+		writeError(w, http.StatusBadRequest, "Email address is already in use")
+		return
+	}
+
+	org := s.createOrganization(request.Name)
+	org.email = request.Email
+	writeJSON(w, http.StatusCreated, "Created")
+}
+
+func (s *Server) getOrganization(w http.ResponseWriter, r *http.Request, org *organization) {
+	writeJSON(w, http.StatusOK, org.toOrganization())
+}
+
+func (s *Server) updateOrganization(w http.ResponseWriter, r *http.Request, org *organization) {
+	request := qclient.OrganizationUpdateRequest{}
+	if !decodeBody(w, r, &request) {
+		return
+	}
+
+	if request.Email != nil {
+		if s.emailInUse(*request.Email, org.name) {
+			writeError(w, http.StatusBadRequest, "Email address is already in use")
+			return
+		}
+
+		org.email = *request.Email
+	}
+
+	if request.InvoiceEmail != nil {
+		org.invoiceEmail = *request.InvoiceEmail
+	}
+
+	if request.InvoiceEmailAddress != nil {
+		org.invoiceEmailAddress = *request.InvoiceEmailAddress
+	}
+
+	if request.TagExpirationS != nil {
+		org.tagExpirationS = *request.TagExpirationS
+	}
+
+	writeJSON(w, http.StatusOK, org.toOrganization())
+}
+
+func (s *Server) deleteOrganization(w http.ResponseWriter, r *http.Request, org *organization) {
+	delete(s.organizations, org.name)
+
+	for key, repo := range s.repositories {
+		if repo.repository.Namespace == org.name {
+			delete(s.repositories, key)
+		}
+	}
+
+	writeNoContent(w)
+}
+
+func (s *Server) getQuotas(w http.ResponseWriter, r *http.Request, org *organization) {
+	quotas := []qclient.OrganizationQuota{}
+	for _, quota := range org.quotas {
+		quotas = append(quotas, *quota)
+	}
+
+	writeJSON(w, http.StatusOK, quotas)
+}
+
+func (s *Server) createQuota(w http.ResponseWriter, r *http.Request, org *organization) {
+	request := qclient.OrganizationQuotaRequest{}
+	if !decodeBody(w, r, &request) {
+		return
+	}
+
+	if len(org.quotas) > 0 {
+		writeError(w, http.StatusBadRequest, "Organization quota for '"+org.name+"' already exists")
+		return
+	}
+
+	org.quotas = append(org.quotas, &qclient.OrganizationQuota{ID: s.newID(), LimitBytes: request.LimitBytes, Limits: []qclient.OrganizationQuotaLimit{}})
+	writeJSON(w, http.StatusCreated, "Created")
+}
+
+// findQuota resolves the quota of a request, responding 404 when it does not exist
+func findQuota(w http.ResponseWriter, r *http.Request, org *organization) (int, bool) {
+	quotaID, _ := strconv.Atoi(r.PathValue("quota"))
+	for i, quota := range org.quotas {
+		if quota.ID == quotaID {
+			return i, true
+		}
+	}
+
+	writeError(w, http.StatusNotFound, "Not Found")
+	return 0, false
+}
+
+func (s *Server) updateQuota(w http.ResponseWriter, r *http.Request, org *organization) {
+	i, found := findQuota(w, r, org)
+	if !found {
+		return
+	}
+
+	request := qclient.OrganizationQuotaRequest{}
+	if !decodeBody(w, r, &request) {
+		return
+	}
+
+	org.quotas[i].LimitBytes = request.LimitBytes
+	writeJSON(w, http.StatusOK, org.quotas[i])
+}
+
+func (s *Server) deleteQuota(w http.ResponseWriter, r *http.Request, org *organization) {
+	i, found := findQuota(w, r, org)
+	if !found {
+		return
+	}
+
+	org.quotas = append(org.quotas[:i], org.quotas[i+1:]...)
+	writeNoContent(w)
+}
+
+func (s *Server) createQuotaLimit(w http.ResponseWriter, r *http.Request, org *organization) {
+	i, found := findQuota(w, r, org)
+	if !found {
+		return
+	}
+
+	request := qclient.OrganizationQuotaLimitRequest{}
+	if !decodeBody(w, r, &request) {
+		return
+	}
+
+	org.quotas[i].Limits = append(org.quotas[i].Limits, qclient.OrganizationQuotaLimit{ID: strconv.Itoa(s.newID()), Type: request.Type, LimitPercent: request.ThresholdPercent})
+	writeJSON(w, http.StatusCreated, "Created")
+}
+
+func (s *Server) updateQuotaLimit(w http.ResponseWriter, r *http.Request, org *organization) {
+	i, found := findQuota(w, r, org)
+	if !found {
+		return
+	}
+
+	request := qclient.OrganizationQuotaLimitRequest{}
+	if !decodeBody(w, r, &request) {
+		return
+	}
+
+	for j, limit := range org.quotas[i].Limits {
+		if limit.ID == r.PathValue("limit") {
+			org.quotas[i].Limits[j].Type = request.Type
+			org.quotas[i].Limits[j].LimitPercent = request.ThresholdPercent
+			writeJSON(w, http.StatusOK, org.quotas[i].Limits[j])
+			return
+		}
+	}
+
+	writeError(w, http.StatusNotFound, "Not Found")
+}
+
+func (s *Server) deleteQuotaLimit(w http.ResponseWriter, r *http.Request, org *organization) {
+	i, found := findQuota(w, r, org)
+	if !found {
+		return
+	}
+
+	for j, limit := range org.quotas[i].Limits {
+		if limit.ID == r.PathValue("limit") {
+			org.quotas[i].Limits = append(org.quotas[i].Limits[:j], org.quotas[i].Limits[j+1:]...)
+			writeNoContent(w)
+			return
+		}
+	}
+
+	writeError(w, http.StatusNotFound, "Not Found")
+}
+
+func (s *Server) createOrUpdateTeam(w http.ResponseWriter, r *http.Request, org *organization) {
+	request := qclient.TeamRequest{}
+	if !decodeBody(w, r, &request) {
+		return
+	}
+
+	name := r.PathValue("team")
+
+	existingTeam, found := org.teams[name]
+	if !found {
+		existingTeam = &team{}
+		org.teams[name] = existingTeam
+	}
+	existingTeam.team = qclient.Team{Name: name, Role: request.Role, Description: request.Description}
+
+	writeJSON(w, http.StatusOK, existingTeam.team)
+}
+
+func (s *Server) deleteTeam(w http.ResponseWriter, r *http.Request, org *organization) {
+	if _, found := org.teams[r.PathValue("team")]; !found {
+		writeError(w, http.StatusNotFound, "Not Found")
+		return
+	}
+
+	delete(org.teams, r.PathValue("team"))
+	writeNoContent(w)
+}
+
+func (s *Server) getTeamMembers(w http.ResponseWriter, r *http.Request, org *organization) {
+	existingTeam, found := org.teams[r.PathValue("team")]
+	if !found {
+		writeError(w, http.StatusNotFound, "Not Found")
+		return
+	}
+
+	members := []qclient.TeamMember{}
+	for _, member := range existingTeam.members {
+		members = append(members, qclient.TeamMember{Name: member, Kind: "user", IsRobot: strings.Contains(member, "+")})
+	}
+
+	writeJSON(w, http.StatusOK, qclient.TeamMembersResponse{Members: members})
+}
+
+func (s *Server) addTeamMember(w http.ResponseWriter, r *http.Request, org *organization) {
+	existingTeam, found := org.teams[r.PathValue("team")]
+	if !found {
+		writeError(w, http.StatusNotFound, "Not Found")
+		return
+	}
+
+	member := r.PathValue("member")
+	if !s.users[member] {
+		writeError(w, http.StatusBadRequest, "Unknown user, robot or team")
+		return
+	}
+
+	if !slices.Contains(existingTeam.members, member) {
+		existingTeam.members = append(existingTeam.members, member)
+	}
+
+	writeJSON(w, http.StatusOK, qclient.TeamMember{Name: member, Kind: "user"})
+}
+
+func (s *Server) removeTeamMember(w http.ResponseWriter, r *http.Request, org *organization) {
+	existingTeam, found := org.teams[r.PathValue("team")]
+	if !found {
+		writeError(w, http.StatusNotFound, "Not Found")
+		return
+	}
+
+	members := []string{}
+	for _, member := range existingTeam.members {
+		if member != r.PathValue("member") {
+			members = append(members, member)
+		}
+	}
+	existingTeam.members = members
+
+	writeNoContent(w)
+}
+
+// Robot accounts that do not exist are reported as 400, as Quay does
+func (s *Server) getRobotAccount(w http.ResponseWriter, r *http.Request, org *organization) {
+	robot, found := org.robots[r.PathValue("robot")]
+	if !found {
+		writeError(w, http.StatusBadRequest, "Could not find robot with specified username")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, robot)
+}
+
+func (s *Server) createRobotAccount(w http.ResponseWriter, r *http.Request, org *organization) {
+	request := qclient.RobotAccountRequest{}
+	if r.ContentLength != 0 {
+		if !decodeBody(w, r, &request) {
+			return
+		}
+	}
+
+	name := r.PathValue("robot")
+	if _, found := org.robots[name]; found {
+		writeError(w, http.StatusBadRequest, "Existing robot with name: "+org.name+"+"+name)
+		return
+	}
+
+	metadata := map[string]interface{}{}
+	for key, value := range request.UnstructuredMetadata {
+		metadata[key] = value
+	}
+
+	robot := &qclient.RobotAccount{
+		Name:                 fmt.Sprintf("%s+%s", org.name, name),
+		Description:          request.Description,
+		UnstructuredMetadata: metadata,
+		Token:                fmt.Sprintf("fake-robot-token-%d", s.newID()),
+	}
+	org.robots[name] = robot
+
+	writeJSON(w, http.StatusCreated, robot)
+}
+
+func (s *Server) deleteRobotAccount(w http.ResponseWriter, r *http.Request, org *organization) {
+	robot, found := org.robots[r.PathValue("robot")]
+	if !found {
+		writeError(w, http.StatusBadRequest, "Could not find robot with specified username")
+		return
+	}
+
+	delete(org.robots, r.PathValue("robot"))
+
+	// Deleted robot accounts lose their permissions
+	for _, repo := range s.repositories {
+		delete(repo.userPermissions, robot.Name)
+	}
+
+	prototypes := []qclient.Prototype{}
+	for _, prototype := range org.prototypes {
+		if prototype.Delegate.Name != robot.Name {
+			prototypes = append(prototypes, prototype)
+		}
+	}
+	org.prototypes = prototypes
+
+	writeNoContent(w)
+}
+
+func (s *Server) getPrototypes(w http.ResponseWriter, r *http.Request, org *organization) {
+	writeJSON(w, http.StatusOK, qclient.PrototypesResponse{Prototypes: append([]qclient.Prototype{}, org.prototypes...)})
+}
+
+func (s *Server) createPrototype(w http.ResponseWriter, r *http.Request, org *organization) {
+	prototype := qclient.Prototype{}
+	if !decodeBody(w, r, &prototype) {
+		return
+	}
+
+	prototype.ID = strconv.Itoa(s.newID())
+	org.prototypes = append(org.prototypes, prototype)
+
+	writeJSON(w, http.StatusOK, prototype)
+}
+
+func (s *Server) getRepositories(w http.ResponseWriter, r *http.Request) {
+	namespace := r.URL.Query().Get("namespace")
+
+	repositories := []qclient.Repository{}
+	for _, repo := range s.repositories {
+		if namespace == "" || repo.repository.Namespace == namespace {
+			repositories = append(repositories, repo.toRepository())
+		}
+	}
+
+	sort.Slice(repositories, func(i, j int) bool {
+		return repositoryKey(repositories[i].Namespace, repositories[i].Name) < repositoryKey(repositories[j].Namespace, repositories[j].Name)
+	})
+
+	writeJSON(w, http.StatusOK, qclient.RepositoriesResponse{Repositories: repositories})
+}
+
+func (s *Server) createRepositoryHandler(w http.ResponseWriter, r *http.Request) {
+	request := qclient.RepositoryRequest{}
+	if !decodeBody(w, r, &request) {
+		return
+	}
+
+	if _, found := s.organizations[request.Namespace]; !found {
+		writeError(w, http.StatusNotFound, "Not Found")
+		return
+	}
+
+	if _, found := s.repositories[repositoryKey(request.Namespace, request.Repository)]; found {
+		writeError(w, http.StatusBadRequest, "Repository already exists")
+		return
+	}
+
+	s.createRepository(request)
+	writeJSON(w, http.StatusCreated, request)
+}
+
+func (s *Server) getRepository(w http.ResponseWriter, r *http.Request, repo *repository) {
+	writeJSON(w, http.StatusOK, repo.toRepository())
+}
+
+func (s *Server) updateRepository(w http.ResponseWriter, r *http.Request, repo *repository) {
+	request := qclient.RepositoryUpdateRequest{}
+	if !decodeBody(w, r, &request) {
+		return
+	}
+
+	repo.repository.Description = request.Description
+	writeJSON(w, http.StatusOK, map[string]bool{"success": true})
+}
+
+func (s *Server) deleteRepository(w http.ResponseWriter, r *http.Request, repo *repository) {
+	delete(s.repositories, repositoryKey(repo.repository.Namespace, repo.repository.Name))
+	writeNoContent(w)
+}
+
+func (s *Server) changeRepositoryVisibility(w http.ResponseWriter, r *http.Request, repo *repository) {
+	request := qclient.RepositoryVisibilityRequest{}
+	if !decodeBody(w, r, &request) {
+		return
+	}
+
+	repo.repository.IsPublic = request.Visibility == qclient.RepositoryVisibilityPublic
+	writeJSON(w, http.StatusOK, map[string]bool{"success": true})
+}
+
+func (s *Server) changeRepositoryState(w http.ResponseWriter, r *http.Request, repo *repository) {
+	request := qclient.RepositoryStateRequest{}
+	if !decodeBody(w, r, &request) {
+		return
+	}
+
+	repo.repository.State = request.State
+	writeJSON(w, http.StatusOK, map[string]bool{"success": true})
+}
+
+func (s *Server) getRepositoryMirror(w http.ResponseWriter, r *http.Request, repo *repository) {
+	if repo.mirror == nil {
+		writeError(w, http.StatusNotFound, "Not Found")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, repo.mirror)
+}
+
+func (s *Server) setRepositoryMirror(w http.ResponseWriter, r *http.Request, repo *repository) {
+	request := qclient.RepositoryMirrorRequest{}
+	if !decodeBody(w, r, &request) {
+		return
+	}
+
+	syncStatus := qclient.MirrorSyncStatusNeverRun
+	if repo.mirror != nil {
+		syncStatus = repo.mirror.SyncStatus
+	}
+
+	repo.mirror = &qclient.RepositoryMirror{
+		IsEnabled:                request.IsEnabled,
+		ExternalReference:        request.ExternalReference,
+		ExternalRegistryUsername: request.ExternalRegistryUsername,
+		ExternalRegistryConfig:   request.ExternalRegistryConfig,
+		SyncInterval:             request.SyncInterval,
+		SyncStartDate:            request.SyncStartDate,
+		SyncStatus:               syncStatus,
+		RootRule:                 request.RootRule,
+		RobotUsername:            request.RobotUsername,
+	}
+
+	writeJSON(w, http.StatusCreated, repo.mirror)
+}
+
+func (s *Server) getUserPermissions(w http.ResponseWriter, r *http.Request, repo *repository) {
+	writeJSON(w, http.StatusOK, qclient.RepositoryPermissionsResponse{Permissions: repo.userPermissions})
+}
+
+func (s *Server) setUserPermission(w http.ResponseWriter, r *http.Request, repo *repository) {
+	request := qclient.RepositoryPermissionRequest{}
+	if !decodeBody(w, r, &request) {
+		return
+	}
+
+	name := r.PathValue("user")
+	if !s.isUserOrRobot(name) {
+		writeError(w, http.StatusBadRequest, "Invalid username: "+name)
+		return
+	}
+
+	permission := qclient.RepositoryPermission{Role: request.Role, Name: name, IsRobot: strings.Contains(name, "+")}
+	repo.userPermissions[name] = permission
+
+	writeJSON(w, http.StatusOK, permission)
+}
+
+func (s *Server) deleteUserPermission(w http.ResponseWriter, r *http.Request, repo *repository) {
+	if _, found := repo.userPermissions[r.PathValue("user")]; !found {
+		writeError(w, http.StatusBadRequest, "User does not have permission for repo.")
+		return
+	}
+
+	delete(repo.userPermissions, r.PathValue("user"))
+	writeNoContent(w)
+}
+
+func (s *Server) getTeamPermissions(w http.ResponseWriter, r *http.Request, repo *repository) {
+	writeJSON(w, http.StatusOK, qclient.RepositoryPermissionsResponse{Permissions: repo.teamPermissions})
+}
+
+func (s *Server) setTeamPermission(w http.ResponseWriter, r *http.Request, repo *repository) {
+	request := qclient.RepositoryPermissionRequest{}
+	if !decodeBody(w, r, &request) {
+		return
+	}
+
+	name := r.PathValue("team")
+	if org, found := s.organizations[repo.repository.Namespace]; !found || org.teams[name] == nil {
+		writeError(w, http.StatusBadRequest, "Invalid team: "+name)
+		return
+	}
+
+	permission := qclient.RepositoryPermission{Role: request.Role, Name: name}
+	repo.teamPermissions[name] = permission
+
+	writeJSON(w, http.StatusOK, permission)
+}
+
+// isUserOrRobot determines whether a name is a known user or an existing robot account
+func (s *Server) isUserOrRobot(name string) bool {
+	if s.users[name] {
+		return true
+	}
+
+	organizationName, robotName, found := strings.Cut(name, "+")
+	if !found {
+		return false
+	}
+
+	org, found := s.organizations[organizationName]
+	return found && org.robots[robotName] != nil
+}
+
+func (s *Server) getAutoPrunePolicies(w http.ResponseWriter, r *http.Request, repo *repository) {
+	writeJSON(w, http.StatusOK, qclient.AutoPrunePoliciesResponse{Policies: append([]qclient.AutoPrunePolicy{}, repo.policies...)})
+}
+
+func (s *Server) createAutoPrunePolicy(w http.ResponseWriter, r *http.Request, repo *repository) {
+	policy := qclient.AutoPrunePolicy{}
+	if !decodeBody(w, r, &policy) {
+		return
+	}
+
+	policy.UUID = fmt.Sprintf("policy-%d", s.newID())
+	repo.policies = append(repo.policies, policy)
+
+	writeJSON(w, http.StatusCreated, policy)
+}
+
+func (s *Server) deleteAutoPrunePolicy(w http.ResponseWriter, r *http.Request, repo *repository) {
+	for i, policy := range repo.policies {
+		if policy.UUID == r.PathValue("policy") {
+			repo.policies = append(repo.policies[:i], repo.policies[i+1:]...)
+			writeJSON(w, http.StatusOK, map[string]string{"uuid": policy.UUID})
+			return
+		}
+	}
+
+	writeError(w, http.StatusNotFound, "Not Found")
+}
+
+func (s *Server) getNotifications(w http.ResponseWriter, r *http.Request, repo *repository) {
+	writeJSON(w, http.StatusOK, qclient.NotificationsResponse{Notifications: append([]qclient.Notification{}, repo.notifications...)})
+}
+
+func (s *Server) createNotification(w http.ResponseWriter, r *http.Request, repo *repository) {
+	request := qclient.NotificationRequest{}
+	if !decodeBody(w, r, &request) {
+		return
+	}
+
+	notification := qclient.Notification{
+		UUID:        fmt.Sprintf("notification-%d", s.newID()),
+		Title:       request.Title,
+		Event:       request.Event,
+		Method:      request.Method,
+		Config:      request.Config,
+		EventConfig: request.EventConfig,
+	}
+	repo.notifications = append(repo.notifications, notification)
+
+	writeJSON(w, http.StatusCreated, notification)
+}
+
+func (s *Server) deleteNotification(w http.ResponseWriter, r *http.Request, repo *repository) {
+	for i, notification := range repo.notifications {
+		if notification.UUID == r.PathValue("notification") {
+			repo.notifications = append(repo.notifications[:i], repo.notifications[i+1:]...)
+			writeNoContent(w)
+			return
+		}
+	}
+
+	writeError(w, http.StatusNotFound, "Not Found")
+}
+
+func (s *Server) getManifestSecurity(w http.ResponseWriter, r *http.Request, repo *repository) {
+	security, found := repo.security[r.PathValue("digest")]
+	if !found {
+		writeError(w, http.StatusNotFound, "Not Found")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, security)
+}
+
+// getTags lists the tags of a repository by name, filtered by the specificTag parameter and paginated by the page and limit parameters
+func (s *Server) getTags(w http.ResponseWriter, r *http.Request, repo *repository) {
+	query := r.URL.Query()
+
+	page, _ := strconv.Atoi(query.Get("page"))
+	if page < 1 {
+		page = 1
+	}
+
+	limit, _ := strconv.Atoi(query.Get("limit"))
+	if limit < 1 {
+		limit = defaultTagsPageSize
+	}
+
+	tags := []qclient.Tag{}
+	for name, tag := range repo.tags {
+		if specificTag := query.Get("specificTag"); specificTag == "" || specificTag == name {
+			tags = append(tags, tag)
+		}
+	}
+
+	sort.Slice(tags, func(i, j int) bool {
+		return tags[i].Name < tags[j].Name
+	})
+
+	start := min((page-1)*limit, len(tags))
+	end := min(start+limit, len(tags))
+
+	writeJSON(w, http.StatusOK, qclient.TagsResponse{Tags: tags[start:end], Page: page, HasAdditional: end < len(tags)})
+}
+
+func (s *Server) setTag(w http.ResponseWriter, r *http.Request, repo *repository) {
+	request := qclient.TagRequest{}
+	if !decodeBody(w, r, &request) {
+		return
+	}
+
+	if !repo.hasManifest(request.ManifestDigest) {
+		writeError(w, http.StatusNotFound, "Not Found")
+		return
+	}
+
+	name := r.PathValue("tag")
+	repo.tags[name] = qclient.Tag{Name: name, ManifestDigest: request.ManifestDigest}
+
+	writeJSON(w, http.StatusCreated, "Updated")
+}
+
+// hasManifest determines whether a manifest was pushed to the repository
+func (r *repository) hasManifest(manifestDigest string) bool {
+	for _, tag := range r.tags {
+		if tag.ManifestDigest == manifestDigest {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,558 @@
+// Package fakequay provides an in-memory implementation of the subset of the Quay API used by the operator, for tests and local development. Faults such as latency, rate limiting, server errors and authentication failures can be injected, and every call is recorded.
+package fakequay
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	qclient "github.com/quay/quay-bridge-operator/pkg/client/quay"
+)
+
+const (
+	// Username is the user the API token of the server belongs to
+	Username = "quaybridge"
+)
+
+// Call represents a request received by the server
+type Call struct {
+	Method     string
+	Path       string
+	Query      string
+	Body       string
+	StatusCode int
+}
+
+// Fault represents an error injected into the responses of the server. Requests are matched by method and path prefix, and empty values match every request
+type Fault struct {
+	// Method is the HTTP method of the matched requests
+	Method string
+	// PathPrefix is the prefix of the path of the matched requests
+	PathPrefix string
+	// Latency delays the response to the matched requests
+	Latency time.Duration
+	// StatusCode is returned instead of processing the matched requests, such as 401, 429 or 503. No status code is returned when zero, so that only the latency applies
+	StatusCode int
+	// Times is the number of requests the fault applies to. The fault applies to every request when zero
+	Times int
+}
+
+// Server is an in-memory Quay API. It implements http.Handler so it can be served on any listener, or on a local address through Start
+type Server struct {
+	mu            sync.Mutex
+	token         string
+	mux           *http.ServeMux
+	httpServer    *httptest.Server
+	users         map[string]bool
+	organizations map[string]*organization
+	repositories  map[string]*repository
+	faults        []*Fault
+	calls         []Call
+	nextID        int
+}
+
+type organization struct {
+	name                string
+	email               string
+	invoiceEmail        bool
+	invoiceEmailAddress string
+	tagExpirationS      int
+	robots              map[string]*qclient.RobotAccount
+	prototypes          []qclient.Prototype
+	teams               map[string]*team
+	quotas              []*qclient.OrganizationQuota
+}
+
+type team struct {
+	team    qclient.Team
+	members []string
+}
+
+type repository struct {
+	repository      qclient.Repository
+	kind            string
+	userPermissions map[string]qclient.RepositoryPermission
+	teamPermissions map[string]qclient.RepositoryPermission
+	tags            map[string]qclient.Tag
+	mirror          *qclient.RepositoryMirror
+	policies        []qclient.AutoPrunePolicy
+	notifications   []qclient.Notification
+	security        map[string]qclient.ManifestSecurity
+}
+
+// New returns an empty server accepting the given API token
+func New(token string) *Server {
+	s := &Server{
+		token:         token,
+		users:         map[string]bool{Username: true},
+		organizations: map[string]*organization{},
+		repositories:  map[string]*repository{},
+	}
+
+	s.mux = http.NewServeMux()
+	s.registerHandlers()
+
+	return s
+}
+
+// Start serves the server on a local address and returns its URL
+func (s *Server) Start() string {
+	s.httpServer = httptest.NewServer(s)
+	return s.httpServer.URL
+}
+
+// Close stops serving the server started by Start
+func (s *Server) Close() {
+	if s.httpServer != nil {
+		s.httpServer.Close()
+	}
+}
+
+// URL returns the URL of the server started by Start
+func (s *Server) URL() string {
+	if s.httpServer == nil {
+		return ""
+	}
+
+	return s.httpServer.URL
+}
+
+// Client returns a Quay client authenticated to the server started by Start
+func (s *Server) Client() *qclient.Client {
+	return qclient.NewClient(s.httpServer.Client(), s.URL(), s.token)
+}
+
+// ServeHTTP applies the injected faults, authenticates and processes a request, recording it in the call log
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	body, _ := io.ReadAll(r.Body)
+	r.Body = io.NopCloser(strings.NewReader(string(body)))
+
+	recorder := &statusRecorder{ResponseWriter: w, statusCode: http.StatusOK}
+
+	defer func() {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		s.calls = append(s.calls, Call{Method: r.Method, Path: r.URL.Path, Query: r.URL.RawQuery, Body: string(body), StatusCode: recorder.statusCode})
+	}()
+
+	latency, statusCode := s.matchFaults(r)
+	if latency > 0 {
+		time.Sleep(latency)
+	}
+
+	switch {
+	case statusCode == http.StatusTooManyRequests:
+		recorder.Header().Set("Retry-After", "1")
+		writeError(recorder, statusCode, "Too Many Requests")
+		return
+	case statusCode != 0:
+		writeError(recorder, statusCode, http.StatusText(statusCode))
+		return
+	}
+
+	if r.Header.Get("Authorization") != "Bearer "+s.token {
+		writeError(recorder, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.mux.ServeHTTP(recorder, r)
+}
+
+// matchFaults returns the latency and status code of the faults matching a request, consuming them
+func (s *Server) matchFaults(r *http.Request) (time.Duration, int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var latency time.Duration
+	statusCode := 0
+
+	remainingFaults := []*Fault{}
+	for _, fault := range s.faults {
+		if (fault.Method != "" && fault.Method != r.Method) || !strings.HasPrefix(r.URL.Path, fault.PathPrefix) {
+			remainingFaults = append(remainingFaults, fault)
+			continue
+		}
+
+		latency += fault.Latency
+		if statusCode == 0 {
+			statusCode = fault.StatusCode
+		}
+
+		if fault.Times > 0 {
+			fault.Times--
+			if fault.Times == 0 {
+				continue
+			}
+		}
+
+		remainingFaults = append(remainingFaults, fault)
+	}
+	s.faults = remainingFaults
+
+	return latency, statusCode
+}
+
+// InjectFault adds a fault to the responses of the server
+func (s *Server) InjectFault(fault Fault) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.faults = append(s.faults, &fault)
+}
+
+// ClearFaults removes every injected fault
+func (s *Server) ClearFaults() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.faults = nil
+}
+
+// Calls returns the requests received by the server in order
+func (s *Server) Calls() []Call {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return append([]Call{}, s.calls...)
+}
+
+// CallsTo returns the requests received by the server matching a method and path prefix
+func (s *Server) CallsTo(method string, pathPrefix string) []Call {
+	calls := []Call{}
+	for _, call := range s.Calls() {
+		if (method == "" || call.Method == method) && strings.HasPrefix(call.Path, pathPrefix) {
+			calls = append(calls, call)
+		}
+	}
+
+	return calls
+}
+
+// ResetCalls clears the call log
+func (s *Server) ResetCalls() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.calls = nil
+}
+
+// AddUser registers a Quay user that can be added to teams
+func (s *Server) AddUser(name string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.users[name] = true
+}
+
+// AddOrganization creates an organization as if it was created outside of the operator
+func (s *Server) AddOrganization(name string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.createOrganization(name)
+}
+
+// Organizations returns the names of the existing organizations
+func (s *Server) Organizations() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	names := []string{}
+	for name := range s.organizations {
+		names = append(names, name)
+	}
+
+	return names
+}
+
+// Organization returns an organization
+func (s *Server) Organization(name string) (qclient.Organization, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	org, found := s.organizations[name]
+	if !found {
+		return qclient.Organization{}, false
+	}
+
+	return org.toOrganization(), true
+}
+
+// RobotAccount returns a robot account of an organization
+func (s *Server) RobotAccount(organizationName string, robotName string) (qclient.RobotAccount, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	org, found := s.organizations[organizationName]
+	if !found {
+		return qclient.RobotAccount{}, false
+	}
+
+	robot, found := org.robots[robotName]
+	if !found {
+		return qclient.RobotAccount{}, false
+	}
+
+	return *robot, true
+}
+
+// Prototypes returns the default permissions of an organization
+func (s *Server) Prototypes(organizationName string) []qclient.Prototype {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	org, found := s.organizations[organizationName]
+	if !found {
+		return nil
+	}
+
+	return append([]qclient.Prototype{}, org.prototypes...)
+}
+
+// AddTeamMember adds a member to an existing team as if it was added outside of the operator
+func (s *Server) AddTeamMember(organizationName string, teamName string, member string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	org, found := s.organizations[organizationName]
+	if !found {
+		return
+	}
+
+	existingTeam, found := org.teams[teamName]
+	if !found || slices.Contains(existingTeam.members, member) {
+		return
+	}
+
+	existingTeam.members = append(existingTeam.members, member)
+}
+
+// TeamMembers returns the members of a team
+func (s *Server) TeamMembers(organizationName string, teamName string) []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	org, found := s.organizations[organizationName]
+	if !found || org.teams[teamName] == nil {
+		return nil
+	}
+
+	return append([]string{}, org.teams[teamName].members...)
+}
+
+// AddRepository creates a repository as if it was created outside of the operator
+func (s *Server) AddRepository(organizationName string, repositoryName string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.createRepository(qclient.RepositoryRequest{Namespace: organizationName, Repository: repositoryName, Visibility: qclient.RepositoryVisibilityPrivate, Kind: qclient.RepositoryKindImage})
+}
+
+// Repository returns a repository
+func (s *Server) Repository(organizationName string, repositoryName string) (qclient.Repository, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	repo, found := s.repositories[repositoryKey(organizationName, repositoryName)]
+	if !found {
+		return qclient.Repository{}, false
+	}
+
+	return repo.toRepository(), true
+}
+
+// RepositoryPermissions returns the user and robot account permissions of a repository
+func (s *Server) RepositoryPermissions(organizationName string, repositoryName string) map[string]qclient.RepositoryPermission {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	repo, found := s.repositories[repositoryKey(organizationName, repositoryName)]
+	if !found {
+		return nil
+	}
+
+	permissions := map[string]qclient.RepositoryPermission{}
+	for name, permission := range repo.userPermissions {
+		permissions[name] = permission
+	}
+
+	return permissions
+}
+
+// RepositoryMirror returns the mirror configuration of a repository
+func (s *Server) RepositoryMirror(organizationName string, repositoryName string) (qclient.RepositoryMirror, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	repo, found := s.repositories[repositoryKey(organizationName, repositoryName)]
+	if !found || repo.mirror == nil {
+		return qclient.RepositoryMirror{}, false
+	}
+
+	return *repo.mirror, true
+}
+
+// AddAutoPrunePolicy creates an auto-prune policy of a repository as if it was created outside of the operator, returning its UUID
+func (s *Server) AddAutoPrunePolicy(organizationName string, repositoryName string, policy qclient.AutoPrunePolicy) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	repo, found := s.repositories[repositoryKey(organizationName, repositoryName)]
+	if !found {
+		return ""
+	}
+
+	policy.UUID = fmt.Sprintf("policy-%d", s.newID())
+	repo.policies = append(repo.policies, policy)
+
+	return policy.UUID
+}
+
+// AutoPrunePolicies returns the auto-prune policies of a repository
+func (s *Server) AutoPrunePolicies(organizationName string, repositoryName string) []qclient.AutoPrunePolicy {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	repo, found := s.repositories[repositoryKey(organizationName, repositoryName)]
+	if !found {
+		return nil
+	}
+
+	return append([]qclient.AutoPrunePolicy{}, repo.policies...)
+}
+
+// SetTag points a tag of a repository at a manifest, as if an image was pushed
+func (s *Server) SetTag(organizationName string, repositoryName string, tag string, manifestDigest string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if repo, found := s.repositories[repositoryKey(organizationName, repositoryName)]; found {
+		repo.tags[tag] = qclient.Tag{Name: tag, ManifestDigest: manifestDigest}
+	}
+}
+
+// SetManifestSecurity records the security scan result of a manifest of a repository
+func (s *Server) SetManifestSecurity(organizationName string, repositoryName string, manifestDigest string, security qclient.ManifestSecurity) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if repo, found := s.repositories[repositoryKey(organizationName, repositoryName)]; found {
+		repo.security[manifestDigest] = security
+	}
+}
+
+func (s *Server) createOrganization(name string) *organization {
+	org := &organization{
+		name:   name,
+		robots: map[string]*qclient.RobotAccount{},
+		teams:  map[string]*team{},
+	}
+	s.organizations[name] = org
+
+	return org
+}
+
+func (s *Server) createRepository(request qclient.RepositoryRequest) *repository {
+	repo := &repository{
+		repository: qclient.Repository{
+			Namespace:      request.Namespace,
+			Name:           request.Repository,
+			Description:    request.Description,
+			IsPublic:       request.Visibility == qclient.RepositoryVisibilityPublic,
+			IsOrganization: true,
+			CanAdmin:       true,
+			CanWrite:       true,
+			State:          qclient.RepositoryStateNormal,
+		},
+		kind:            request.Kind,
+		userPermissions: map[string]qclient.RepositoryPermission{},
+		teamPermissions: map[string]qclient.RepositoryPermission{},
+		tags:            map[string]qclient.Tag{},
+		security:        map[string]qclient.ManifestSecurity{},
+	}
+	s.repositories[repositoryKey(request.Namespace, request.Repository)] = repo
+
+	return repo
+}
+
+// newID returns a unique identifier for objects created by the server
+func (s *Server) newID() int {
+	s.nextID++
+	return s.nextID
+}
+
+// emailInUse reports whether an organization other than the given one uses the email address, which Quay requires to be unique
+func (s *Server) emailInUse(email string, except string) bool {
+	for name, org := range s.organizations {
+		if name != except && org.email == email {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (o *organization) toOrganization() qclient.Organization {
+	organization := qclient.Organization{
+		Name:                o.name,
+		Email:               o.email,
+		InvoiceEmail:        o.invoiceEmail,
+		InvoiceEmailAddress: o.invoiceEmailAddress,
+		TagExpirationS:      o.tagExpirationS,
+	}
+	if len(o.quotas) > 0 {
+		organization.QuotaReport = &qclient.OrganizationQuotaReport{ConfiguredQuota: o.quotas[0].LimitBytes}
+	}
+
+	return organization
+}
+
+func (r *repository) toRepository() qclient.Repository {
+	repository := r.repository
+	repository.Tags = map[string]qclient.Tag{}
+	for name, tag := range r.tags {
+		repository.Tags[name] = tag
+	}
+
+	return repository
+}
+
+func repositoryKey(organizationName string, repositoryName string) string {
+	return fmt.Sprintf("%s/%s", organizationName, repositoryName)
+}
+
+// statusRecorder records the status code written to a response
+type statusRecorder struct {
+	http.ResponseWriter
+	statusCode int
+}
+
+func (r *statusRecorder) WriteHeader(statusCode int) {
+	r.statusCode = statusCode
+	r.ResponseWriter.WriteHeader(statusCode)
+}
+
+func writeJSON(w http.ResponseWriter, statusCode int, body interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+	json.NewEncoder(w).Encode(body)
+}
+
+// writeError writes an error in the format of the Quay API
+func writeError(w http.ResponseWriter, statusCode int, message string) {
+	writeJSON(w, statusCode, map[string]interface{}{
+		"status":        statusCode,
+		"error_message": message,
+		"title":         strings.ToLower(strings.ReplaceAll(http.StatusText(statusCode), " ", "_")),
+	})
+}
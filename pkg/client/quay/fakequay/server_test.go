@@ -0,0 +1,471 @@
+package fakequay_test
+
+import (
+	"net/http"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/quay/quay-bridge-operator/pkg/client/quay"
+	"github.com/quay/quay-bridge-operator/pkg/client/quay/fakequay"
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+func newServer(t *testing.T) (*fakequay.Server, *quay.Client) {
+	server := fakequay.New("my-secret-token")
+	server.Start()
+	t.Cleanup(server.Close)
+
+	return server, server.Client()
+}
+
+func TestOrganizationLifecycle(t *testing.T) {
+	server, cli := newServer(t)
+
+	_, resp, quayErr := cli.GetOrganizationByName("cluster_project")
+	assert.NoError(t, quayErr.Error)
+	assert.Equal(t, 404, resp.StatusCode)
+
+	_, resp, quayErr = cli.CreateOrganization("cluster_project", "project@quay.example.com")
+	assert.NoError(t, quayErr.Error)
+	assert.Equal(t, 201, resp.StatusCode)
+
+	_, resp, _ = cli.CreateOrganization("cluster_project", "")
+	assert.Equal(t, 400, resp.StatusCode)
+
+	_, resp, _ = cli.CreateOrganization("cluster_other", "project@quay.example.com")
+	assert.Equal(t, 400, resp.StatusCode)
+
+	tagExpirationS := 3600
+	invoiceEmail := true
+	invoiceEmailAddress := "billing@quay.example.com"
+	resp, quayErr = cli.UpdateOrganization("cluster_project", quay.OrganizationUpdateRequest{TagExpirationS: &tagExpirationS, InvoiceEmail: &invoiceEmail, InvoiceEmailAddress: &invoiceEmailAddress})
+	assert.NoError(t, quayErr.Error)
+	assert.Equal(t, 200, resp.StatusCode)
+
+	organization, found := server.Organization("cluster_project")
+	assert.True(t, found)
+	assert.Equal(t, quay.Organization{Name: "cluster_project", Email: "project@quay.example.com", InvoiceEmail: true, InvoiceEmailAddress: "billing@quay.example.com", TagExpirationS: 3600}, organization)
+
+	resp, quayErr = cli.DeleteOrganization("cluster_project")
+	assert.NoError(t, quayErr.Error)
+	assert.Equal(t, 204, resp.StatusCode)
+	assert.Empty(t, server.Organizations())
+}
+
+func TestRobotAccountsAndPrototypes(t *testing.T) {
+	server, cli := newServer(t)
+	server.AddOrganization("cluster_project")
+
+	_, resp, quayErr := cli.GetOrganizationRobotAccount("cluster_project", "builder")
+	assert.NoError(t, quayErr.Error)
+	assert.Equal(t, 400, resp.StatusCode)
+
+	robot, resp, quayErr := cli.CreateOrganizationRobotAccountWithMetadata("cluster_project", "builder", quay.RobotAccountRequest{Description: "Builder", UnstructuredMetadata: map[string]string{"clusterID": "cluster"}})
+	assert.NoError(t, quayErr.Error)
+	assert.Equal(t, 201, resp.StatusCode)
+	assert.Equal(t, "cluster_project+builder", robot.Name)
+	assert.NotEmpty(t, robot.Token)
+
+	robot, resp, _ = cli.GetOrganizationRobotAccount("cluster_project", "builder")
+	assert.Equal(t, 200, resp.StatusCode)
+	assert.Equal(t, map[string]interface{}{"clusterID": "cluster"}, robot.UnstructuredMetadata)
+
+	_, resp, _ = cli.CreateOrganizationRobotAccount("cluster_project", "builder")
+	assert.Equal(t, 400, resp.StatusCode)
+
+	_, resp, quayErr = cli.CreateRobotPermissionForOrganization("cluster_project", "cluster_project+builder", string(quay.QuayRoleWrite))
+	assert.NoError(t, quayErr.Error)
+	assert.Equal(t, 200, resp.StatusCode)
+
+	prototypes, _, _ := cli.GetPrototypesByOrganization("cluster_project")
+	assert.True(t, quay.IsRobotAccountInPrototypeByRole(prototypes.Prototypes, "cluster_project+builder", string(quay.QuayRoleWrite)))
+
+	resp, _ = cli.DeleteOrganizationRobotAccount("cluster_project", "builder")
+	assert.Equal(t, 204, resp.StatusCode)
+	assert.Empty(t, server.Prototypes("cluster_project"))
+
+	resp, _ = cli.DeleteOrganizationRobotAccount("cluster_project", "builder")
+	assert.Equal(t, 400, resp.StatusCode)
+}
+
+func TestRepositoriesPermissionsAndTags(t *testing.T) {
+	server, cli := newServer(t)
+	server.AddOrganization("cluster_project")
+
+	_, resp, _ := cli.GetRepository("cluster_project", "app")
+	assert.Equal(t, 404, resp.StatusCode)
+
+	_, resp, quayErr := cli.CreateRepository("cluster_project", "app", quay.RepositoryVisibilityPrivate, "My app", quay.RepositoryKindImage)
+	assert.NoError(t, quayErr.Error)
+	assert.Equal(t, 201, resp.StatusCode)
+
+	resp, _ = cli.ChangeRepositoryVisibility("cluster_project", "app", quay.RepositoryVisibilityPublic)
+	assert.Equal(t, 200, resp.StatusCode)
+
+	repository, resp, _ := cli.GetRepository("cluster_project", "app")
+	assert.Equal(t, 200, resp.StatusCode)
+	assert.True(t, repository.IsPublic)
+	assert.Equal(t, "My app", repository.Description)
+
+	_, resp, _ = cli.SetRepositoryUserPermission("cluster_project", "app", "cluster_other+builder", string(quay.QuayRoleWrite))
+	assert.Equal(t, 400, resp.StatusCode)
+
+	server.AddOrganization("cluster_other")
+	cli.CreateOrganizationRobotAccount("cluster_other", "builder")
+
+	_, resp, _ = cli.SetRepositoryUserPermission("cluster_project", "app", "cluster_other+builder", string(quay.QuayRoleWrite))
+	assert.Equal(t, 200, resp.StatusCode)
+	assert.Equal(t, map[string]quay.RepositoryPermission{"cluster_other+builder": {Role: "write", Name: "cluster_other+builder", IsRobot: true}}, server.RepositoryPermissions("cluster_project", "app"))
+
+	server.SetTag("cluster_project", "app", "latest", "sha256:abc")
+	server.SetTag("cluster_project", "app", "v1", "sha256:abc")
+
+	resp, _ = cli.CreateOrUpdateTag("cluster_project", "app", "v2", "sha256:abc")
+	assert.Equal(t, 201, resp.StatusCode)
+
+	resp, _ = cli.CreateOrUpdateTag("cluster_project", "app", "v3", "sha256:unknown")
+	assert.Equal(t, 404, resp.StatusCode)
+
+	tags, resp, _ := cli.GetRepositoryTags("cluster_project", "app", url.Values{"limit": {"2"}})
+	assert.Equal(t, 200, resp.StatusCode)
+	assert.Equal(t, []string{"latest", "v1"}, tagNames(tags.Tags))
+	assert.True(t, tags.HasAdditional)
+
+	tags, _, _ = cli.GetRepositoryTags("cluster_project", "app", url.Values{"specificTag": {"v2"}})
+	assert.Equal(t, []quay.Tag{{Name: "v2", ManifestDigest: "sha256:abc"}}, tags.Tags)
+
+	resp, _ = cli.DeleteOrganization("cluster_project")
+	assert.Equal(t, 204, resp.StatusCode)
+
+	_, found := server.Repository("cluster_project", "app")
+	assert.False(t, found)
+}
+
+func TestRepositoryDeletionAndUserPermissions(t *testing.T) {
+	server, cli := newServer(t)
+	server.AddOrganization("cluster_project")
+	server.AddRepository("cluster_project", "app")
+	cli.CreateOrganizationRobotAccount("cluster_project", "builder")
+
+	cli.SetRepositoryUserPermission("cluster_project", "app", "cluster_project+builder", string(quay.QuayRoleWrite))
+
+	permissions, resp, _ := cli.GetRepositoryUserPermissions("cluster_project", "app")
+	assert.Equal(t, 200, resp.StatusCode)
+	assert.Contains(t, permissions.Permissions, "cluster_project+builder")
+
+	resp, quayErr := cli.DeleteRepositoryUserPermission("cluster_project", "app", "cluster_project+builder")
+	assert.NoError(t, quayErr.Error)
+	assert.Equal(t, 204, resp.StatusCode)
+	assert.Empty(t, server.RepositoryPermissions("cluster_project", "app"))
+
+	resp, _ = cli.DeleteRepositoryUserPermission("cluster_project", "app", "cluster_project+builder")
+	assert.Equal(t, 400, resp.StatusCode)
+
+	resp, quayErr = cli.DeleteRepository("cluster_project", "app")
+	assert.NoError(t, quayErr.Error)
+	assert.Equal(t, 204, resp.StatusCode)
+
+	_, found := server.Repository("cluster_project", "app")
+	assert.False(t, found)
+
+	resp, _ = cli.DeleteRepository("cluster_project", "app")
+	assert.Equal(t, 404, resp.StatusCode)
+}
+
+func TestQuotas(t *testing.T) {
+	server, cli := newServer(t)
+	server.AddOrganization("cluster_project")
+
+	resp, quayErr := cli.CreateOrganizationQuota("cluster_project", 1024)
+	assert.NoError(t, quayErr.Error)
+	assert.Equal(t, 201, resp.StatusCode)
+
+	resp, _ = cli.CreateOrganizationQuota("cluster_project", 2048)
+	assert.Equal(t, 400, resp.StatusCode)
+
+	quotas, _, _ := cli.GetOrganizationQuotas("cluster_project")
+	assert.Len(t, quotas, 1)
+	quotaID := quotas[0].ID
+
+	resp, _ = cli.UpdateOrganizationQuota("cluster_project", quotaID, 4096)
+	assert.Equal(t, 200, resp.StatusCode)
+
+	resp, _ = cli.CreateOrganizationQuotaLimit("cluster_project", quotaID, "Warning", 80)
+	assert.Equal(t, 201, resp.StatusCode)
+
+	quotas, _, _ = cli.GetOrganizationQuotas("cluster_project")
+	assert.Equal(t, int64(4096), quotas[0].LimitBytes)
+	assert.Len(t, quotas[0].Limits, 1)
+	limitID := quotas[0].Limits[0].ID
+
+	resp, _ = cli.UpdateOrganizationQuotaLimit("cluster_project", quotaID, limitID, "Reject", 90)
+	assert.Equal(t, 200, resp.StatusCode)
+
+	quotas, _, _ = cli.GetOrganizationQuotas("cluster_project")
+	assert.Equal(t, []quay.OrganizationQuotaLimit{{ID: limitID, Type: "Reject", LimitPercent: 90}}, quotas[0].Limits)
+
+	resp, _ = cli.DeleteOrganizationQuotaLimit("cluster_project", quotaID, limitID)
+	assert.Equal(t, 204, resp.StatusCode)
+
+	resp, _ = cli.DeleteOrganizationQuotaLimit("cluster_project", quotaID, limitID)
+	assert.Equal(t, 404, resp.StatusCode)
+
+	resp, _ = cli.DeleteOrganizationQuota("cluster_project", quotaID)
+	assert.Equal(t, 204, resp.StatusCode)
+
+	resp, _ = cli.UpdateOrganizationQuota("cluster_project", quotaID, 4096)
+	assert.Equal(t, 404, resp.StatusCode)
+
+	quotas, _, _ = cli.GetOrganizationQuotas("cluster_project")
+	assert.Empty(t, quotas)
+}
+
+func TestTeams(t *testing.T) {
+	server, cli := newServer(t)
+	server.AddOrganization("cluster_project")
+	server.AddRepository("cluster_project", "app")
+	server.AddUser("alice")
+
+	_, resp, _ := cli.GetTeamMembers("cluster_project", "developers")
+	assert.Equal(t, 404, resp.StatusCode)
+
+	team, resp, quayErr := cli.CreateOrUpdateTeam("cluster_project", "developers", "member", "Developers")
+	assert.NoError(t, quayErr.Error)
+	assert.Equal(t, 200, resp.StatusCode)
+	assert.Equal(t, quay.Team{Name: "developers", Role: "member", Description: "Developers"}, team)
+
+	resp, _ = cli.AddTeamMember("cluster_project", "developers", "alice")
+	assert.Equal(t, 200, resp.StatusCode)
+
+	resp, _ = cli.AddTeamMember("cluster_project", "developers", "bob")
+	assert.Equal(t, 400, resp.StatusCode)
+
+	members, _, _ := cli.GetTeamMembers("cluster_project", "developers")
+	assert.Equal(t, []quay.TeamMember{{Name: "alice", Kind: "user"}}, members.Members)
+
+	_, resp, _ = cli.SetRepositoryTeamPermission("cluster_project", "app", "developers", string(quay.QuayRoleRead))
+	assert.Equal(t, 200, resp.StatusCode)
+
+	_, resp, _ = cli.SetRepositoryTeamPermission("cluster_project", "app", "unknown", string(quay.QuayRoleRead))
+	assert.Equal(t, 400, resp.StatusCode)
+
+	permissions, _, _ := cli.GetRepositoryTeamPermissions("cluster_project", "app")
+	assert.Equal(t, map[string]quay.RepositoryPermission{"developers": {Role: "read", Name: "developers"}}, permissions.Permissions)
+
+	resp, _ = cli.RemoveTeamMember("cluster_project", "developers", "alice")
+	assert.Equal(t, 204, resp.StatusCode)
+
+	members, _, _ = cli.GetTeamMembers("cluster_project", "developers")
+	assert.Empty(t, members.Members)
+
+	resp, _ = cli.DeleteTeam("cluster_project", "developers")
+	assert.Equal(t, 204, resp.StatusCode)
+
+	resp, _ = cli.DeleteTeam("cluster_project", "developers")
+	assert.Equal(t, 404, resp.StatusCode)
+}
+
+func TestRepositoryMirror(t *testing.T) {
+	server, cli := newServer(t)
+	server.AddOrganization("cluster_project")
+	server.AddRepository("cluster_project", "app")
+
+	_, resp, _ := cli.GetRepositoryMirror("cluster_project", "app")
+	assert.Equal(t, 404, resp.StatusCode)
+
+	request := quay.RepositoryMirrorRequest{
+		IsEnabled:         true,
+		ExternalReference: "registry.example.com/upstream/app",
+		SyncInterval:      3600,
+		RootRule:          quay.RepositoryMirrorRule{RuleKind: "tag_glob_csv", RuleValue: []string{"latest"}},
+		RobotUsername:     "cluster_project+builder",
+	}
+
+	resp, quayErr := cli.CreateRepositoryMirror("cluster_project", "app", request)
+	assert.NoError(t, quayErr.Error)
+	assert.Equal(t, 201, resp.StatusCode)
+
+	request.SyncInterval = 7200
+	resp, _ = cli.UpdateRepositoryMirror("cluster_project", "app", request)
+	assert.Equal(t, 201, resp.StatusCode)
+
+	mirror, resp, _ := cli.GetRepositoryMirror("cluster_project", "app")
+	assert.Equal(t, 200, resp.StatusCode)
+	assert.Equal(t, quay.RepositoryMirror{
+		IsEnabled:         true,
+		ExternalReference: "registry.example.com/upstream/app",
+		SyncInterval:      7200,
+		SyncStatus:        quay.MirrorSyncStatusNeverRun,
+		RootRule:          quay.RepositoryMirrorRule{RuleKind: "tag_glob_csv", RuleValue: []string{"latest"}},
+		RobotUsername:     "cluster_project+builder",
+	}, mirror)
+
+	resp, _ = cli.ChangeRepositoryState("cluster_project", "app", "MIRROR")
+	assert.Equal(t, 200, resp.StatusCode)
+
+	repository, _ := server.Repository("cluster_project", "app")
+	assert.Equal(t, "MIRROR", repository.State)
+}
+
+func TestAutoPrunePolicies(t *testing.T) {
+	server, cli := newServer(t)
+	server.AddOrganization("cluster_project")
+	server.AddRepository("cluster_project", "app")
+
+	existingUUID := server.AddAutoPrunePolicy("cluster_project", "app", quay.AutoPrunePolicy{Method: "number_of_tags", Value: intstr.FromInt(5)})
+
+	policy, resp, quayErr := cli.CreateRepositoryAutoPrunePolicy("cluster_project", "app", quay.AutoPrunePolicy{Method: "creation_date", Value: intstr.FromString("7d")})
+	assert.NoError(t, quayErr.Error)
+	assert.Equal(t, 201, resp.StatusCode)
+	assert.NotEmpty(t, policy.UUID)
+	assert.NotEqual(t, existingUUID, policy.UUID)
+
+	policies, resp, _ := cli.GetRepositoryAutoPrunePolicies("cluster_project", "app")
+	assert.Equal(t, 200, resp.StatusCode)
+	assert.Equal(t, []quay.AutoPrunePolicy{
+		{UUID: existingUUID, Method: "number_of_tags", Value: intstr.FromInt(5)},
+		{UUID: policy.UUID, Method: "creation_date", Value: intstr.FromString("7d")},
+	}, policies.Policies)
+
+	resp, _ = cli.DeleteRepositoryAutoPrunePolicy("cluster_project", "app", policy.UUID)
+	assert.Equal(t, 200, resp.StatusCode)
+
+	resp, _ = cli.DeleteRepositoryAutoPrunePolicy("cluster_project", "app", policy.UUID)
+	assert.Equal(t, 404, resp.StatusCode)
+
+	assert.Equal(t, []quay.AutoPrunePolicy{{UUID: existingUUID, Method: "number_of_tags", Value: intstr.FromInt(5)}}, server.AutoPrunePolicies("cluster_project", "app"))
+}
+
+func TestNotificationsAndSecurity(t *testing.T) {
+	server, cli := newServer(t)
+	server.AddOrganization("cluster_project")
+	server.AddRepository("cluster_project", "app")
+
+	notification, resp, quayErr := cli.CreateRepositoryNotification("cluster_project", "app", quay.NotificationRequest{
+		Title:  "Build",
+		Event:  "repo_push",
+		Method: "webhook",
+		Config: map[string]interface{}{"url": "https://hooks.example.com"},
+	})
+	assert.NoError(t, quayErr.Error)
+	assert.Equal(t, 201, resp.StatusCode)
+	assert.NotEmpty(t, notification.UUID)
+
+	notifications, _, _ := cli.GetRepositoryNotifications("cluster_project", "app")
+	assert.Len(t, notifications.Notifications, 1)
+	assert.Equal(t, "repo_push", notifications.Notifications[0].Event)
+
+	resp, _ = cli.DeleteRepositoryNotification("cluster_project", "app", notification.UUID)
+	assert.Equal(t, 204, resp.StatusCode)
+
+	resp, _ = cli.DeleteRepositoryNotification("cluster_project", "app", notification.UUID)
+	assert.Equal(t, 404, resp.StatusCode)
+
+	_, resp, _ = cli.GetManifestSecurity("cluster_project", "app", "sha256:abc")
+	assert.Equal(t, 404, resp.StatusCode)
+
+	server.SetManifestSecurity("cluster_project", "app", "sha256:abc", quay.ManifestSecurity{Status: "scanned"})
+
+	security, resp, _ := cli.GetManifestSecurity("cluster_project", "app", "sha256:abc")
+	assert.Equal(t, 200, resp.StatusCode)
+	assert.Equal(t, "scanned", security.Status)
+}
+
+func TestFaults(t *testing.T) {
+	tests := []struct {
+		name               string
+		fault              fakequay.Fault
+		wantStatusCodes    []int
+		wantRetryAfter     string
+		wantMinimumLatency time.Duration
+	}{
+		{
+			name:            "rate limited once",
+			fault:           fakequay.Fault{Method: "GET", PathPrefix: "/api/v1/organization/", StatusCode: 429, Times: 1},
+			wantStatusCodes: []int{429, 200},
+			wantRetryAfter:  "1",
+		},
+		{
+			name:            "server errors until cleared",
+			fault:           fakequay.Fault{StatusCode: 503},
+			wantStatusCodes: []int{503, 503},
+		},
+		{
+			name:            "authentication failure",
+			fault:           fakequay.Fault{PathPrefix: "/api/v1/organization/cluster_project", StatusCode: 401, Times: 2},
+			wantStatusCodes: []int{401, 401, 200},
+		},
+		{
+			name:            "other paths unaffected",
+			fault:           fakequay.Fault{PathPrefix: "/api/v1/repository", StatusCode: 500},
+			wantStatusCodes: []int{200},
+		},
+		{
+			name:               "latency",
+			fault:              fakequay.Fault{Latency: 50 * time.Millisecond, Times: 1},
+			wantStatusCodes:    []int{200},
+			wantMinimumLatency: 50 * time.Millisecond,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server, cli := newServer(t)
+			server.AddOrganization("cluster_project")
+			server.InjectFault(tt.fault)
+
+			for i, wantStatusCode := range tt.wantStatusCodes {
+				start := time.Now()
+
+				_, resp, _ := cli.GetOrganizationByName("cluster_project")
+				assert.Equal(t, wantStatusCode, resp.StatusCode)
+
+				if i == 0 {
+					assert.Equal(t, tt.wantRetryAfter, resp.Header.Get("Retry-After"))
+					assert.GreaterOrEqual(t, time.Since(start), tt.wantMinimumLatency)
+				}
+			}
+
+			server.ClearFaults()
+
+			_, resp, _ := cli.GetOrganizationByName("cluster_project")
+			assert.Equal(t, 200, resp.StatusCode)
+		})
+	}
+}
+
+func TestAuthenticationAndCallLog(t *testing.T) {
+	server, cli := newServer(t)
+
+	unauthenticated := quay.NewClient(http.DefaultClient, server.URL(), "wrong-token")
+	_, resp, _ := unauthenticated.GetUser()
+	assert.Equal(t, 401, resp.StatusCode)
+
+	user, resp, _ := cli.GetUser()
+	assert.Equal(t, 200, resp.StatusCode)
+	assert.Equal(t, fakequay.Username, user.Username)
+
+	cli.CreateOrganization("cluster_project", "")
+
+	assert.Equal(t, []fakequay.Call{
+		{Method: "GET", Path: "/api/v1/user", StatusCode: 401},
+		{Method: "GET", Path: "/api/v1/user", StatusCode: 200},
+		{Method: "POST", Path: "/api/v1/organization/", Body: "{\"name\":\"cluster_project\"}\n", StatusCode: 201},
+	}, server.Calls())
+
+	assert.Len(t, server.CallsTo("GET", "/api/v1/user"), 2)
+
+	server.ResetCalls()
+	assert.Empty(t, server.Calls())
+}
+
+func tagNames(tags []quay.Tag) []string {
+	names := []string{}
+	for _, tag := range tags {
+		names = append(names, tag.Name)
+	}
+
+	return names
+}
@@ -1,7 +1,42 @@
 package quay
 
+import "k8s.io/apimachinery/pkg/util/intstr"
+
 type QuayRole string
 
+const (
+	RepositoryVisibilityPrivate   = "private"
+	RepositoryVisibilityPublic    = "public"
+	RepositoryKindImage           = "image"
+	RepositoryKindApplication     = "application"
+	AutoPruneMethodNumberOfTags   = "number_of_tags"
+	AutoPruneMethodCreationDate   = "creation_date"
+	QuotaLimitTypeWarning         = "Warning"
+	QuotaLimitTypeReject          = "Reject"
+	SecurityScanStatusScanned     = "scanned"
+	SecurityScanStatusQueued      = "queued"
+	SecurityScanStatusFailed      = "failed"
+	SecurityScanStatusUnsupported = "unsupported"
+	RepositoryStateNormal         = "NORMAL"
+	RepositoryStateMirror         = "MIRROR"
+	MirrorRuleKindTagGlobCSV      = "tag_glob_csv"
+	MirrorSyncStatusNeverRun      = "NEVER_RUN"
+	MirrorSyncStatusSyncing       = "SYNCING"
+	MirrorSyncStatusSuccess       = "SUCCESS"
+	MirrorSyncStatusFail          = "FAIL"
+)
+
+var (
+	// VulnerabilitySeverities are the severities reported by the Quay security scanner from least to most severe
+	VulnerabilitySeverities = []string{"Unknown", "Negligible", "Low", "Medium", "High", "Critical", "Defcon1"}
+)
+
+const (
+	TeamRoleMember  = "member"
+	TeamRoleCreator = "creator"
+	TeamRoleAdmin   = "admin"
+)
+
 const (
 	QuayRoleAdmin QuayRole = "admin"
 	QuayRoleRead  QuayRole = "read"
@@ -16,6 +51,67 @@ type User struct {
 
 // Organization
 type Organization struct {
+	Name                string                   `json:"name"`
+	Email               string                   `json:"email,omitempty"`
+	InvoiceEmail        bool                     `json:"invoice_email,omitempty"`
+	InvoiceEmailAddress string                   `json:"invoice_email_address,omitempty"`
+	TagExpirationS      int                      `json:"tag_expiration_s"`
+	QuotaReport         *OrganizationQuotaReport `json:"quota_report,omitempty"`
+}
+
+type OrganizationQuotaReport struct {
+	QuotaBytes      int64 `json:"quota_bytes"`
+	ConfiguredQuota int64 `json:"configured_quota"`
+}
+
+type OrganizationQuota struct {
+	ID         int                      `json:"id"`
+	LimitBytes int64                    `json:"limit_bytes"`
+	Limits     []OrganizationQuotaLimit `json:"limits"`
+}
+
+type OrganizationQuotaRequest struct {
+	LimitBytes int64 `json:"limit_bytes"`
+}
+
+type OrganizationQuotaLimit struct {
+	ID           string `json:"id,omitempty"`
+	Type         string `json:"type"`
+	LimitPercent int    `json:"limit_percent"`
+}
+
+type OrganizationQuotaLimitRequest struct {
+	Type             string `json:"type"`
+	ThresholdPercent int    `json:"threshold_percent"`
+}
+
+type OrganizationUpdateRequest struct {
+	Email               *string `json:"email,omitempty"`
+	InvoiceEmail        *bool   `json:"invoice_email,omitempty"`
+	InvoiceEmailAddress *string `json:"invoice_email_address,omitempty"`
+	TagExpirationS      *int    `json:"tag_expiration_s,omitempty"`
+}
+
+type Team struct {
+	Name        string `json:"name"`
+	Role        string `json:"role"`
+	Description string `json:"description"`
+}
+
+type TeamRequest struct {
+	Role        string `json:"role"`
+	Description string `json:"description,omitempty"`
+}
+
+type TeamMembersResponse struct {
+	Members []TeamMember `json:"members"`
+}
+
+type TeamMember struct {
+	Name    string `json:"name"`
+	Kind    string `json:"kind"`
+	IsRobot bool   `json:"is_robot"`
+	Invited bool   `json:"invited"`
 }
 
 type OrganizationRequest struct {
@@ -28,12 +124,17 @@ type PrototypesResponse struct {
 }
 
 type RobotAccount struct {
-	Description string `json:"description"`
-	Created     string `json:"created"`
-	// UnstructuredData []byte  `json:"unstructured_metadata"`
-	LastAccessed string `json:"last_accessed"`
-	Token        string `json:"token"`
-	Name         string `json:"name"`
+	Description          string                 `json:"description"`
+	Created              string                 `json:"created"`
+	UnstructuredMetadata map[string]interface{} `json:"unstructured_metadata,omitempty"`
+	LastAccessed         string                 `json:"last_accessed"`
+	Token                string                 `json:"token"`
+	Name                 string                 `json:"name"`
+}
+
+type RobotAccountRequest struct {
+	Description          string            `json:"description,omitempty"`
+	UnstructuredMetadata map[string]string `json:"unstructured_metadata,omitempty"`
 }
 
 type Prototype struct {
@@ -63,6 +164,7 @@ type Repository struct {
 	TagExpirationS int            `json:"tag_expiration_s"`
 	Tags           map[string]Tag `json:"tags"`
 	StatusToken    string         `json:"status_token"`
+	State          string         `json:"state,omitempty"`
 }
 
 type Tag struct {
@@ -73,6 +175,93 @@ type Tag struct {
 	Size           int    `json:"int"`
 }
 
+type RepositoryPermissionsResponse struct {
+	Permissions map[string]RepositoryPermission `json:"permissions"`
+}
+
+type RepositoryPermission struct {
+	Role        string `json:"role"`
+	Name        string `json:"name"`
+	IsRobot     bool   `json:"is_robot"`
+	IsOrgMember bool   `json:"is_org_member"`
+}
+
+type RepositoryPermissionRequest struct {
+	Role string `json:"role"`
+}
+
+type AutoPrunePoliciesResponse struct {
+	Policies []AutoPrunePolicy `json:"policies"`
+}
+
+type AutoPrunePolicy struct {
+	UUID   string             `json:"uuid,omitempty"`
+	Method string             `json:"method"`
+	Value  intstr.IntOrString `json:"value"`
+}
+
+type NotificationsResponse struct {
+	Notifications []Notification `json:"notifications"`
+}
+
+type Notification struct {
+	UUID        string                 `json:"uuid"`
+	Title       string                 `json:"title"`
+	Event       string                 `json:"event"`
+	Method      string                 `json:"method"`
+	Config      map[string]interface{} `json:"config"`
+	EventConfig map[string]interface{} `json:"event_config"`
+}
+
+type NotificationRequest struct {
+	Title       string                 `json:"title"`
+	Event       string                 `json:"event"`
+	Method      string                 `json:"method"`
+	Config      map[string]interface{} `json:"config"`
+	EventConfig map[string]interface{} `json:"eventConfig"`
+}
+
+type ManifestSecurity struct {
+	Status string                `json:"status"`
+	Data   *ManifestSecurityData `json:"data"`
+}
+
+type ManifestSecurityData struct {
+	Layer SecurityLayer `json:"Layer"`
+}
+
+type SecurityLayer struct {
+	Features []SecurityFeature `json:"Features"`
+}
+
+type SecurityFeature struct {
+	Name            string          `json:"Name"`
+	Version         string          `json:"Version"`
+	Vulnerabilities []Vulnerability `json:"Vulnerabilities"`
+}
+
+type Vulnerability struct {
+	Name     string `json:"Name"`
+	Severity string `json:"Severity"`
+	FixedBy  string `json:"FixedBy"`
+	Link     string `json:"Link"`
+}
+
+type RepositoriesResponse struct {
+	Repositories []Repository `json:"repositories"`
+	NextPage     string       `json:"next_page,omitempty"`
+}
+
+type TagsResponse struct {
+	Tags          []Tag `json:"tags"`
+	Page          int   `json:"page"`
+	HasAdditional bool  `json:"has_additional"`
+}
+
+type TagRequest struct {
+	ManifestDigest string `json:"manifest_digest"`
+}
+
 type RepositoryRequest struct {
 	Namespace   string `json:"namespace"`
 	Visibility  string `json:"visibility"`
@@ -81,6 +270,53 @@ type RepositoryRequest struct {
 	Kind        string `json:"repo_kind"`
 }
 
+type RepositoryUpdateRequest struct {
+	Description string `json:"description"`
+}
+
+type RepositoryVisibilityRequest struct {
+	Visibility string `json:"visibility"`
+}
+
+type RepositoryStateRequest struct {
+	State string `json:"state"`
+}
+
+type RepositoryMirror struct {
+	IsEnabled                bool                           `json:"is_enabled"`
+	ExternalReference        string                         `json:"external_reference"`
+	ExternalRegistryUsername string                         `json:"external_registry_username"`
+	ExternalRegistryConfig   RepositoryMirrorRegistryConfig `json:"external_registry_config"`
+	SyncInterval             int                            `json:"sync_interval"`
+	SyncStartDate            string                         `json:"sync_start_date"`
+	SyncExpirationDate       string                         `json:"sync_expiration_date"`
+	SyncRetriesRemaining     int                            `json:"sync_retries_remaining"`
+	SyncStatus               string                         `json:"sync_status"`
+	RootRule                 RepositoryMirrorRule           `json:"root_rule"`
+	RobotUsername            string                         `json:"robot_username"`
+}
+
+type RepositoryMirrorRequest struct {
+	IsEnabled                bool                           `json:"is_enabled"`
+	ExternalReference        string                         `json:"external_reference"`
+	ExternalRegistryUsername string                         `json:"external_registry_username,omitempty"`
+	ExternalRegistryPassword string                         `json:"external_registry_password,omitempty"`
+	ExternalRegistryConfig   RepositoryMirrorRegistryConfig `json:"external_registry_config"`
+	SyncInterval             int                            `json:"sync_interval"`
+	SyncStartDate            string                         `json:"sync_start_date,omitempty"`
+	RootRule                 RepositoryMirrorRule           `json:"root_rule"`
+	RobotUsername            string                         `json:"robot_username"`
+}
+
+type RepositoryMirrorRule struct {
+	RuleKind  string   `json:"rule_kind"`
+	RuleValue []string `json:"rule_value"`
+}
+
+type RepositoryMirrorRegistryConfig struct {
+	VerifyTLS bool `json:"verify_tls"`
+}
+
 // StringValue represents an object containing a single string
 type StringValue struct {
 	Value string
@@ -7,6 +7,13 @@ const (
 	OrganizationPrefix                               = "openshift"
 	QuaySecretCredentialTokenKey                     = "token"
 	NamespaceFinalizer                               = "quay.redhat.com/quayintegrations"
+	QuayIntegrationFinalizer                         = "quay.redhat.com/teardown"
+	OrganizationDeletionPolicyDelete                 = "Delete"
+	OrganizationDeletionPolicyRetain                 = "Retain"
+	TeardownConditionType                            = "TearingDown"
+	TeardownBatchSize                                = 20
+	ForceFinalizeAnnotation                          = AnnotationBase + "/force-finalize"
+	FinalizationBlockedConditionType                 = "QuayFinalizationBlocked"
 	OpenShiftDisplayNameAnnotation                   = "openshift.io/display-name"
 	OpenShiftDescriptionAnnotation                   = "openshift.io/description"
 	OpenShiftSccMcsAnnotation                        = "openshift.io/sa.scc.mcs"
@@ -18,5 +25,87 @@ const (
 	BuildOperatorManagedAnnotation                   = AnnotationBase + "/quay-registry-operator-managed"
 	BuildDestinationImageStreamAnnotation            = AnnotationBase + "/destination-imagestream"
 	BuildDestinationImageStreamTagImportedAnnotation = AnnotationBase + "/destination-imagestreamtag-imported"
+	BuildAdditionalTagsAnnotation                    = AnnotationBase + "/additional-tags"
+	BuildImportFailureAnnotation                     = AnnotationBase + "/import-failure"
+	BuildImportAttemptsAnnotation                    = AnnotationBase + "/import-attempts"
+	BuildImportLastAttemptAnnotation                 = AnnotationBase + "/import-last-attempt"
+	BuildImportAbandonedAnnotation                   = AnnotationBase + "/import-abandoned"
+	BuildImportDigestAnnotation                      = AnnotationBase + "/import-digest"
+	RepositoryVisibilityAnnotation                   = AnnotationBase + "/repository-visibility"
+	RepositoryDescriptionAnnotation                  = AnnotationBase + "/repository-description"
+	RepositoryKindAnnotation                         = AnnotationBase + "/repository-kind"
+	ImageStreamTagDigestAnnotation                   = AnnotationBase + "/digest"
+	TagRetentionKeepLastTagsAnnotation               = AnnotationBase + "/tag-retention-keep-last-tags"
+	TagRetentionMaxTagAgeAnnotation                  = AnnotationBase + "/tag-retention-max-tag-age"
+	UntaggedExpirationAnnotation                     = AnnotationBase + "/untagged-tag-expiration"
+	EffectiveTagRetentionAnnotation                  = AnnotationBase + "/effective-tag-retention"
+	TagRetentionPolicyAnnotation                     = AnnotationBase + "/tag-retention-policy"
+	OrganizationQuotaAnnotation                      = AnnotationBase + "/organization-quota"
+	OrganizationEmailAnnotation                      = AnnotationBase + "/organization-email"
+	OrganizationInvoiceEmailAnnotation               = AnnotationBase + "/organization-invoice-email"
+	RepositoryNotificationsAnnotation                = AnnotationBase + "/repository-notifications"
+	RepositoryNotificationTitlePrefix                = "quay-bridge-operator/"
+	SecurityScanStatusAnnotation                     = AnnotationBase + "/security-scan-status"
+	VulnerabilitiesAnnotation                        = AnnotationBase + "/vulnerabilities"
+	HighestVulnerabilitySeverityAnnotation           = AnnotationBase + "/highest-vulnerability-severity"
+	BuildImportDigestIndex                           = "metadata.annotations.import-digest"
+	DefaultSecurityScanPollInterval                  = time.Minute
+	InboundSyncAnnotation                            = AnnotationBase + "/inbound-sync"
+	InboundSyncTagFilterAnnotation                   = AnnotationBase + "/inbound-sync-tag-filter"
+	InboundSyncCreateImageStreamsAnnotation          = AnnotationBase + "/inbound-sync-create-imagestreams"
+	DefaultInboundSyncPollInterval                   = time.Minute * 5
+	MirrorSourceAnnotation                           = AnnotationBase + "/mirror-source"
+	MirrorTagsAnnotation                             = AnnotationBase + "/mirror-tags"
+	MirrorSyncIntervalAnnotation                     = AnnotationBase + "/mirror-sync-interval"
+	MirrorCredentialsSecretAnnotation                = AnnotationBase + "/mirror-credentials-secret"
+	MirrorVerifyTLSAnnotation                        = AnnotationBase + "/mirror-verify-tls"
+	MirrorSyncStatusAnnotation                       = AnnotationBase + "/mirror-sync-status"
+	MirrorCredentialsVersionAnnotation               = AnnotationBase + "/mirror-credentials-version"
+	DefaultImageTag                                  = "latest"
+	DefaultMirrorTags                                = DefaultImageTag
+	DefaultMirrorSyncInterval                        = time.Hour * 24
+	MirrorStatusPollInterval                         = time.Minute * 5
+	RobotAccountAnnotation                           = AnnotationBase + "/robot-account"
+	RobotCredentialsRemoteKeyAnnotation              = AnnotationBase + "/robot-credentials-remote-key"
+	DefaultRobotCredentialsRemoteKeyTemplate         = "quay-bridge-operator/{{.Namespace}}/{{.ServiceAccount}}"
+	DefaultRobotCredentialsRefreshInterval           = time.Hour
+	LinkedSecretsAnnotation                          = AnnotationBase + "/linked-secrets"
+	TeamMembersAnnotationPrefix                      = AnnotationBase + "/team-members-"
+	ManagedByLabel                                   = "app.kubernetes.io/managed-by"
+	ManagedByLabelValue                              = "quay-bridge-operator"
+	QuayIntegrationLabel                             = AnnotationBase + "/quay-integration"
+	ServiceAccountLabel                              = AnnotationBase + "/service-account"
+	AdoptAnnotation                                  = AnnotationBase + "/adopt"
+	TargetOrganizationAnnotation                     = AnnotationBase + "/target-organization"
+	RepositoryNamingAnnotation                       = AnnotationBase + "/repository-naming"
+	RepositoryPathTemplateAnnotation                 = AnnotationBase + "/repository-path-template"
+	RepositoryNamingPrefixed                         = "Prefixed"
+	RepositoryNamingUnprefixed                       = "Unprefixed"
+	RepositoryOwnerAnnotation                        = AnnotationBase + "/repository-owner"
+	OwnershipRobotAccountName                        = "quaybridge"
+	OwnershipMetadataManagedByKey                    = "managedBy"
+	OwnershipMetadataClusterIDKey                    = "clusterID"
+	OwnershipMetadataNamespaceKey                    = "namespace"
+	OwnershipMetadataOriginKey                       = "origin"
+	OwnershipOriginCreated                           = "Created"
+	OwnershipOriginAdopted                           = "Adopted"
+	OwnershipOriginShared                            = "Shared"
+	OwnershipConflictConditionType                   = "QuayOwnershipConflict"
+	ClusterClaimRobotAccountName                     = "clusterclaim"
+	ClusterClaimMetadataClusterUIDKey                = "clusterUID"
+	ClusterClaimMetadataRenewTimeKey                 = "renewTime"
+	ClusterClaimTakeoverAnnotation                   = AnnotationBase + "/takeover-cluster-claim"
+	ClusterClaimConditionType                        = "ClusterClaimed"
+	ClusterClaimLeaseDuration                        = time.Minute * 15
+	ClusterClaimRenewPeriod                          = time.Minute * 5
+	ClusterUIDNamespace                              = "kube-system"
+	OrganizationStorageResourceName                  = "quay.redhat.com/organization-storage"
+	OrganizationQuotaConditionType                   = "QuayOrganizationQuotaExceeded"
+	CredentialsValidConditionType                    = "CredentialsValid"
+	CredentialsRevalidationPeriod                    = time.Minute * 5
+	DefaultQuotaRejectThresholdPercent               = 100
+	ImagePusherRole                                  = "system:image-pusher"
 	RequeuePeriod                                    = time.Second * 5
+	MaxImportRequeuePeriod                           = time.Minute * 5
+	MaxImportAttempts                                = 10
 )
@@ -2,23 +2,41 @@ package core
 
 import (
 	"context"
+	"crypto/tls"
 	"fmt"
+	"io"
+	"net/http"
+	"os"
 	"strconv"
+	"sync"
 	"time"
 
 	quayv1 "github.com/quay/quay-bridge-operator/api/v1"
+	qclient "github.com/quay/quay-bridge-operator/pkg/client/quay"
 
 	"github.com/redhat-cop/operator-utils/pkg/util"
+	corev1 "k8s.io/api/core/v1"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	"github.com/quay/quay-bridge-operator/pkg/constants"
+	"github.com/quay/quay-bridge-operator/pkg/credentials"
 	"github.com/quay/quay-bridge-operator/pkg/logging"
+	qotypes "github.com/quay/quay-bridge-operator/pkg/types"
+	"github.com/quay/quay-bridge-operator/pkg/utils"
 )
 
 const (
-	defaultReason = "Warning"
+	defaultReason       = "Warning"
+	vaultRequestTimeout = 30 * time.Second
+)
+
+var (
+	quayTokenCache       = credentials.NewTokenCache()
+	credentialsProviders = &providerCache{providers: map[string]cachedProvider{}}
 )
 
 type CoreComponents struct {
@@ -93,6 +111,219 @@ func (c *CoreComponents) GetQuayIntegration(object runtime.Object) (quayv1.QuayI
 	return *&quayIntegrations.Items[0], reconcile.Result{}, err
 }
 
+// GetOrganizationTarget resolves the Quay organization the repositories and robot accounts of a namespace are created in
+func (c *CoreComponents) GetOrganizationTarget(ctx context.Context, quayIntegration *quayv1.QuayIntegration, namespaceName string) (qotypes.OrganizationTarget, error) {
+
+	namespace := &corev1.Namespace{}
+	if err := c.ReconcilerBase.GetClient().Get(ctx, types.NamespacedName{Name: namespaceName}, namespace); err != nil {
+		return qotypes.OrganizationTarget{}, err
+	}
+
+	return utils.GetOrganizationTarget(quayIntegration, namespace)
+}
+
+// GetQuayClient creates a Quay client using the credentials referenced by the QuayIntegration, returning none while the ClusterID is not claimed
+func (c *CoreComponents) GetQuayClient(ctx context.Context, object runtime.Object, quayIntegration *quayv1.QuayIntegration) (*qclient.Client, reconcile.Result, error) {
+
+	if !IsClusterClaimed(quayIntegration) {
+		logging.Log.Info("ClusterID is not claimed by this cluster, skipping reconciliation", "ClusterID", quayIntegration.Spec.ClusterID)
+		return nil, reconcile.Result{RequeueAfter: constants.ClusterClaimRenewPeriod}, nil
+	}
+
+	return c.NewQuayClient(ctx, object, quayIntegration)
+}
+
+// NewQuayClient creates a Quay client using the credentials referenced by the QuayIntegration regardless of the claim of its ClusterID
+func (c *CoreComponents) NewQuayClient(ctx context.Context, object runtime.Object, quayIntegration *quayv1.QuayIntegration) (*qclient.Client, reconcile.Result, error) {
+
+	credentialsSourceType := getCredentialsSourceType(quayIntegration)
+
+	quayCredentials, err := c.GetQuayCredentials(ctx, quayIntegration)
+	if err != nil {
+		result, err := c.ManageError(&QuayIntegrationCoreError{
+			Object:       object,
+			Message:      "Error Retrieving Quay Integration Credentials",
+			Reason:       "ConfigurationError",
+			KeyAndValues: []interface{}{"Source", credentialsSourceType},
+			Error:        err,
+		})
+
+		return nil, result, err
+	}
+
+	httpClient := &http.Client{
+		Transport: &http.Transport{
+			TLSClientConfig: &tls.Config{InsecureSkipVerify: true},
+		},
+	}
+
+	authenticationMethod := credentials.AuthenticationMethodToken
+	if quayIntegration.Spec.Authentication != nil && quayIntegration.Spec.Authentication.Method != "" {
+		authenticationMethod = quayIntegration.Spec.Authentication.Method
+	}
+
+	var authToken string
+
+	if authenticationMethod == credentials.AuthenticationMethodToken {
+		quaySecretCredentialTokenKey := constants.QuaySecretCredentialTokenKey
+
+		if credentialsSourceType == credentials.CredentialsSourceSecret && quayIntegration.Spec.CredentialsSecret.Key != "" {
+			quaySecretCredentialTokenKey = quayIntegration.Spec.CredentialsSecret.Key
+		}
+
+		if _, ok := quayCredentials[quaySecretCredentialTokenKey]; !ok {
+			result, err := c.ManageError(&QuayIntegrationCoreError{
+				Object:       object,
+				Message:      fmt.Sprintf("Credentials do not contain key '%s'", quaySecretCredentialTokenKey),
+				Reason:       "ConfigurationError",
+				KeyAndValues: []interface{}{"Source", credentialsSourceType},
+			})
+
+			return nil, result, err
+		}
+
+		authToken = string(quayCredentials[quaySecretCredentialTokenKey])
+	} else {
+		// Short-lived tokens are shared between reconciliations and replaced shortly before they expire
+		authToken, err = quayTokenCache.Token(httpClient, quayIntegration.Name, credentials.TokenExchange{
+			Method:      authenticationMethod,
+			TokenURL:    quayIntegration.Spec.Authentication.TokenURL,
+			Scopes:      quayIntegration.Spec.Authentication.Scopes,
+			Credentials: quayCredentials,
+		})
+		if err != nil {
+			result, err := c.ManageError(&QuayIntegrationCoreError{
+				Object:       object,
+				Message:      "Error obtaining Quay API token",
+				Reason:       "ConfigurationError",
+				KeyAndValues: []interface{}{"Method", authenticationMethod, "Source", credentialsSourceType},
+				Error:        err,
+			})
+
+			return nil, result, err
+		}
+	}
+
+	// Setup Quay Client
+	quayClient := qclient.NewClient(httpClient, quayIntegration.Spec.QuayHostname, authToken)
+
+	return quayClient, reconcile.Result{}, nil
+}
+
+// GetQuayCredentials reads the credentials to communicate with Quay from the credentials source of the QuayIntegration
+func (c *CoreComponents) GetQuayCredentials(ctx context.Context, quayIntegration *quayv1.QuayIntegration) (map[string][]byte, error) {
+	provider, err := c.getCredentialsProvider(quayIntegration)
+	if err != nil {
+		return nil, err
+	}
+
+	return provider.Credentials(ctx)
+}
+
+// getCredentialsProvider returns the credentials provider of the QuayIntegration. File and Vault providers are reused across reconciliations so that watches and Vault logins persist
+func (c *CoreComponents) getCredentialsProvider(quayIntegration *quayv1.QuayIntegration) (credentials.Provider, error) {
+	switch getCredentialsSourceType(quayIntegration) {
+	case credentials.CredentialsSourceSecret:
+		if quayIntegration.Spec.CredentialsSecret == nil {
+			return nil, fmt.Errorf("required parameter 'CredentialsSecret' not found")
+		}
+
+		return &credentials.SecretProvider{
+			Client: c.ReconcilerBase.GetClient(),
+			Name:   types.NamespacedName{Namespace: quayIntegration.Spec.CredentialsSecret.Namespace, Name: quayIntegration.Spec.CredentialsSecret.Name},
+		}, nil
+	case credentials.CredentialsSourceFile:
+		fileSource := quayIntegration.Spec.CredentialsSource.File
+		if fileSource == nil || fileSource.Path == "" {
+			return nil, fmt.Errorf("required parameter 'CredentialsSource.File.Path' not found")
+		}
+
+		return credentialsProviders.get(quayIntegration.Name, fmt.Sprintf("file:%s", fileSource.Path), func() (credentials.Provider, error) {
+			return credentials.NewFileProvider(fileSource.Path)
+		})
+	case credentials.CredentialsSourceVault:
+		vaultSource := quayIntegration.Spec.CredentialsSource.Vault
+		if vaultSource == nil || vaultSource.Address == "" || vaultSource.Path == "" {
+			return nil, fmt.Errorf("required parameters 'CredentialsSource.Vault.Address' and 'CredentialsSource.Vault.Path' not found")
+		}
+
+		return credentialsProviders.get(quayIntegration.Name, fmt.Sprintf("vault:%#v", *vaultSource), func() (credentials.Provider, error) {
+			return &credentials.VaultProvider{
+				HTTPClient: &http.Client{Timeout: vaultRequestTimeout},
+				Address:    vaultSource.Address,
+				Mount:      vaultSource.Mount,
+				Path:       vaultSource.Path,
+				KVVersion:  vaultSource.KVVersion,
+				AuthPath:   vaultSource.AuthPath,
+				Role:       vaultSource.Role,
+				Token:      os.Getenv(credentials.VaultTokenEnvVar),
+			}, nil
+		})
+	default:
+		return nil, fmt.Errorf("unsupported credentials source '%s'", quayIntegration.Spec.CredentialsSource.Type)
+	}
+}
+
+func getCredentialsSourceType(quayIntegration *quayv1.QuayIntegration) string {
+	if quayIntegration.Spec.CredentialsSource == nil || quayIntegration.Spec.CredentialsSource.Type == "" {
+		return credentials.CredentialsSourceSecret
+	}
+
+	return quayIntegration.Spec.CredentialsSource.Type
+}
+
+// IsClusterClaimed returns whether this cluster holds an unexpired claim of the current ClusterID of the QuayIntegration
+func IsClusterClaimed(quayIntegration *quayv1.QuayIntegration) bool {
+	claim := quayIntegration.Status.ClusterClaim
+	if claim == nil || claim.ClusterID != quayIntegration.Spec.ClusterID || !apimeta.IsStatusConditionTrue(quayIntegration.Status.Conditions, constants.ClusterClaimConditionType) {
+		return false
+	}
+
+	// Another cluster may take over a claim which was not renewed within its lease
+	return claim.RenewTime != nil && !utils.IsClusterClaimExpired(claim.RenewTime.Time, time.Now())
+}
+
+// IsCredentialsSourceWatched returns whether changes to the credentials of the QuayIntegration trigger a reconciliation. Only the Secret source is watched through the API server
+func IsCredentialsSourceWatched(quayIntegration *quayv1.QuayIntegration) bool {
+	return getCredentialsSourceType(quayIntegration) == credentials.CredentialsSourceSecret
+}
+
+// providerCache retains the credentials provider of each QuayIntegration, replacing it when its configuration changes
+type providerCache struct {
+	mu        sync.Mutex
+	providers map[string]cachedProvider
+}
+
+type cachedProvider struct {
+	key      string
+	provider credentials.Provider
+}
+
+func (p *providerCache) get(name string, key string, newProvider func() (credentials.Provider, error)) (credentials.Provider, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if cached, found := p.providers[name]; found {
+		if cached.key == key {
+			return cached.provider, nil
+		}
+
+		if closer, ok := cached.provider.(io.Closer); ok {
+			closer.Close()
+		}
+		delete(p.providers, name)
+	}
+
+	provider, err := newProvider()
+	if err != nil {
+		return nil, err
+	}
+
+	p.providers[name] = cachedProvider{key: key, provider: provider}
+
+	return provider, nil
+}
+
 func buildKeyAndValueMessage(keyAndValues []interface{}) string {
 
 	output := ""
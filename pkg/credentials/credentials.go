@@ -3,12 +3,18 @@ package credentials
 import (
 	"encoding/base64"
 	"encoding/json"
+	"fmt"
 
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 func GenerateDockerJsonSecret(name string, server string, username string, password string, email string) (*corev1.Secret, error) {
+	return GenerateDockerJsonSecretForServers(name, []string{server}, username, password, email)
+}
+
+// GenerateDockerJsonSecretForServers generates a dockerconfigjson Secret containing the same credentials for several registries
+func GenerateDockerJsonSecretForServers(name string, servers []string, username string, password string, email string) (*corev1.Secret, error) {
 
 	secret := &corev1.Secret{
 		TypeMeta: metav1.TypeMeta{
@@ -20,7 +26,7 @@ func GenerateDockerJsonSecret(name string, server string, username string, passw
 	secret.Type = corev1.SecretTypeDockerConfigJson
 	secret.Data = map[string][]byte{}
 
-	dockercfgJSONContent, err := handleDockerCfgJSONContent(username, password, email, server)
+	dockercfgJSONContent, err := handleDockerCfgJSONContent(username, password, email, servers...)
 	if err != nil {
 		return nil, err
 	}
@@ -29,14 +35,50 @@ func GenerateDockerJsonSecret(name string, server string, username string, passw
 	return secret, err
 }
 
-func handleDockerCfgJSONContent(username, password, email, server string) ([]byte, error) {
+// MergeDockerConfigJSON adds the registries of another dockerconfigjson to a dockerconfigjson Secret. Registries already present in the Secret are kept
+func MergeDockerConfigJSON(secret *corev1.Secret, other []byte) error {
+
+	var merged DockerConfigJSON
+	if err := json.Unmarshal(secret.Data[corev1.DockerConfigJsonKey], &merged); err != nil {
+		return fmt.Errorf("failed to decode dockerconfigjson of Secret %s: %w", secret.Name, err)
+	}
+
+	var otherDockerCfgJSON DockerConfigJSON
+	if err := json.Unmarshal(other, &otherDockerCfgJSON); err != nil {
+		return fmt.Errorf("failed to decode dockerconfigjson: %w", err)
+	}
+
+	if merged.Auths == nil {
+		merged.Auths = DockerConfig{}
+	}
+
+	for server, entry := range otherDockerCfgJSON.Auths {
+		if _, found := merged.Auths[server]; !found {
+			merged.Auths[server] = entry
+		}
+	}
+
+	dockercfgJSONContent, err := json.Marshal(merged)
+	if err != nil {
+		return err
+	}
+	secret.Data[corev1.DockerConfigJsonKey] = dockercfgJSONContent
+
+	return nil
+}
+
+func handleDockerCfgJSONContent(username, password, email string, servers ...string) ([]byte, error) {
 	dockercfgAuth := DockerConfigEntry{
 		Email: email,
 		Auth:  encodeDockerConfigFieldAuth(username, password),
 	}
 
 	dockerCfgJSON := DockerConfigJSON{
-		Auths: map[string]DockerConfigEntry{server: dockercfgAuth},
+		Auths: DockerConfig{},
+	}
+
+	for _, server := range servers {
+		dockerCfgJSON.Auths[server] = dockercfgAuth
 	}
 
 	return json.Marshal(dockerCfgJSON)
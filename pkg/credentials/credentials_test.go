@@ -1,6 +1,7 @@
 package credentials
 
 import (
+	"encoding/json"
 	"reflect"
 	"testing"
 
@@ -58,3 +59,82 @@ func TestSecretForDockerRegistryGenerate(t *testing.T) {
 	}
 
 }
+
+func TestSecretForDockerRegistryGenerateForServers(t *testing.T) {
+
+	result, err := GenerateDockerJsonSecretForServers("test-secret", []string{"quay.io", "mirror.example.com"}, "testuser", "testPassword", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var dockerCfgJSON DockerConfigJSON
+	if err := json.Unmarshal(result.Data[corev1.DockerConfigJsonKey], &dockerCfgJSON); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := DockerConfig{
+		"quay.io":            {Auth: encodeDockerConfigFieldAuth("testuser", "testPassword")},
+		"mirror.example.com": {Auth: encodeDockerConfigFieldAuth("testuser", "testPassword")},
+	}
+
+	if !reflect.DeepEqual(dockerCfgJSON.Auths, expected) {
+		t.Errorf("Registries did not match\nExpected: %#v\nActual: %#v", expected, dockerCfgJSON.Auths)
+	}
+}
+
+func TestMergeDockerConfigJSON(t *testing.T) {
+
+	cases := []struct {
+		name        string
+		other       string
+		expected    DockerConfig
+		expectedErr bool
+	}{
+		{
+			name:  "test-merge-additional-registry",
+			other: `{"auths": {"registry.example.com": {"auth": "b3RoZXI6b3RoZXI="}}}`,
+			expected: DockerConfig{
+				"quay.io":              {Auth: encodeDockerConfigFieldAuth("robot", "token")},
+				"registry.example.com": {Auth: "b3RoZXI6b3RoZXI="},
+			},
+		},
+		{
+			name:  "test-existing-registry-kept",
+			other: `{"auths": {"quay.io": {"auth": "b3RoZXI6b3RoZXI="}}}`,
+			expected: DockerConfig{
+				"quay.io": {Auth: encodeDockerConfigFieldAuth("robot", "token")},
+			},
+		},
+		{
+			name:        "test-invalid-dockerconfigjson",
+			other:       `{"auths":`,
+			expectedErr: true,
+		},
+	}
+
+	for i, c := range cases {
+
+		t.Run(c.name, func(t *testing.T) {
+			secret, _ := GenerateDockerJsonSecret("test-secret", "quay.io", "robot", "token", "")
+
+			err := MergeDockerConfigJSON(secret, []byte(c.other))
+
+			if c.expectedErr != (err != nil) {
+				t.Errorf("Test case %d did not match\nExpected Error: %#v\nActual: %#v", i, c.expectedErr, err)
+			}
+
+			if c.expectedErr {
+				return
+			}
+
+			var dockerCfgJSON DockerConfigJSON
+			if err := json.Unmarshal(secret.Data[corev1.DockerConfigJsonKey], &dockerCfgJSON); err != nil {
+				t.Fatal(err)
+			}
+
+			if !reflect.DeepEqual(dockerCfgJSON.Auths, c.expected) {
+				t.Errorf("Test case %d did not match\nExpected: %#v\nActual: %#v", i, c.expected, dockerCfgJSON.Auths)
+			}
+		})
+	}
+}
@@ -0,0 +1,98 @@
+package credentials
+
+import (
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+const (
+	RobotCredentialsModeSecret         = "Secret"
+	RobotCredentialsModePushSecret     = "PushSecret"
+	RobotCredentialsModeExternalSecret = "ExternalSecret"
+	RobotCredentialsModeReference      = "Reference"
+	SecretStoreKind                    = "SecretStore"
+	ClusterSecretStoreKind             = "ClusterSecretStore"
+)
+
+var (
+	// External Secrets Operator types are not part of the scheme of the operator and are managed as unstructured objects
+	PushSecretGroupVersionKind     = schema.GroupVersionKind{Group: "external-secrets.io", Version: "v1alpha1", Kind: "PushSecret"}
+	ExternalSecretGroupVersionKind = schema.GroupVersionKind{Group: "external-secrets.io", Version: "v1beta1", Kind: "ExternalSecret"}
+)
+
+// GeneratePushSecret generates a PushSecret pushing the dockerconfigjson of a Secret to the remote key of an external secret store
+func GeneratePushSecret(name string, secretName string, storeName string, storeKind string, remoteKey string, refreshInterval time.Duration) *unstructured.Unstructured {
+
+	pushSecret := &unstructured.Unstructured{
+		Object: map[string]interface{}{
+			"spec": map[string]interface{}{
+				"refreshInterval": refreshInterval.String(),
+				"secretStoreRefs": []interface{}{
+					map[string]interface{}{
+						"name": storeName,
+						"kind": storeKind,
+					},
+				},
+				"selector": map[string]interface{}{
+					"secret": map[string]interface{}{
+						"name": secretName,
+					},
+				},
+				"data": []interface{}{
+					map[string]interface{}{
+						"match": map[string]interface{}{
+							"secretKey": corev1.DockerConfigJsonKey,
+							"remoteRef": map[string]interface{}{
+								"remoteKey": remoteKey,
+								"property":  corev1.DockerConfigJsonKey,
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	pushSecret.SetGroupVersionKind(PushSecretGroupVersionKind)
+	pushSecret.SetName(name)
+
+	return pushSecret
+}
+
+// GenerateExternalSecret generates an ExternalSecret materializing a dockerconfigjson Secret from the remote key of an external secret store
+func GenerateExternalSecret(name string, secretName string, storeName string, storeKind string, remoteKey string, refreshInterval time.Duration) *unstructured.Unstructured {
+
+	externalSecret := &unstructured.Unstructured{
+		Object: map[string]interface{}{
+			"spec": map[string]interface{}{
+				"refreshInterval": refreshInterval.String(),
+				"secretStoreRef": map[string]interface{}{
+					"name": storeName,
+					"kind": storeKind,
+				},
+				"target": map[string]interface{}{
+					"name":           secretName,
+					"creationPolicy": "Owner",
+					"template": map[string]interface{}{
+						"type": string(corev1.SecretTypeDockerConfigJson),
+					},
+				},
+				"data": []interface{}{
+					map[string]interface{}{
+						"secretKey": corev1.DockerConfigJsonKey,
+						"remoteRef": map[string]interface{}{
+							"key":      remoteKey,
+							"property": corev1.DockerConfigJsonKey,
+						},
+					},
+				},
+			},
+		},
+	}
+	externalSecret.SetGroupVersionKind(ExternalSecretGroupVersionKind)
+	externalSecret.SetName(name)
+
+	return externalSecret
+}
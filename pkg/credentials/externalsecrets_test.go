@@ -0,0 +1,81 @@
+package credentials
+
+import (
+	"testing"
+	"time"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func TestGenerateExternalSecrets(t *testing.T) {
+
+	cases := []struct {
+		name               string
+		object             *unstructured.Unstructured
+		expectedKind       string
+		expectedRemoteKey  []string
+		expectedSecretName []string
+		expectedStoreName  []string
+		expectedRefresh    string
+	}{
+		{
+			name:               "test-generate-pushsecret",
+			object:             GeneratePushSecret("builder-quay-cluster", "builder-quay-cluster", "vault", ClusterSecretStoreKind, "quay/project/builder", time.Hour),
+			expectedKind:       "PushSecret",
+			expectedRemoteKey:  []string{"spec", "data", "match", "remoteRef", "remoteKey"},
+			expectedSecretName: []string{"spec", "selector", "secret", "name"},
+			expectedStoreName:  []string{"spec", "secretStoreRefs", "name"},
+			expectedRefresh:    "1h0m0s",
+		},
+		{
+			name:               "test-generate-externalsecret",
+			object:             GenerateExternalSecret("builder-quay-cluster", "builder-quay-cluster", "vault", ClusterSecretStoreKind, "quay/project/builder", time.Hour),
+			expectedKind:       "ExternalSecret",
+			expectedRemoteKey:  []string{"spec", "data", "remoteRef", "key"},
+			expectedSecretName: []string{"spec", "target", "name"},
+			expectedStoreName:  []string{"spec", "secretStoreRef", "name"},
+			expectedRefresh:    "1h0m0s",
+		},
+	}
+
+	for i, c := range cases {
+
+		t.Run(c.name, func(t *testing.T) {
+
+			if c.object.GetKind() != c.expectedKind || c.object.GetAPIVersion() == "" || c.object.GetName() != "builder-quay-cluster" {
+				t.Errorf("Test case %d did not match\nExpected Kind: %s\nActual: %s %s %s", i, c.expectedKind, c.object.GetAPIVersion(), c.object.GetKind(), c.object.GetName())
+			}
+
+			for path, expected := range map[string][]string{"quay/project/builder": c.expectedRemoteKey, "builder-quay-cluster": c.expectedSecretName, "vault": c.expectedStoreName} {
+				if actual := nestedString(c.object.Object, expected); actual != path {
+					t.Errorf("Test case %d did not match\nExpected %v: %s\nActual: %s", i, expected, path, actual)
+				}
+			}
+
+			if refresh, _, _ := unstructured.NestedString(c.object.Object, "spec", "refreshInterval"); refresh != c.expectedRefresh {
+				t.Errorf("Test case %d did not match\nExpected Refresh Interval: %s\nActual: %s", i, c.expectedRefresh, refresh)
+			}
+		})
+	}
+}
+
+// nestedString returns a string field, descending into the first item of any list along the path
+func nestedString(object interface{}, fields []string) string {
+	for _, field := range fields {
+		if list, ok := object.([]interface{}); ok {
+			if len(list) == 0 {
+				return ""
+			}
+			object = list[0]
+		}
+
+		fieldMap, ok := object.(map[string]interface{})
+		if !ok {
+			return ""
+		}
+		object = fieldMap[field]
+	}
+
+	value, _ := object.(string)
+	return value
+}
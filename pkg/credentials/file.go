@@ -0,0 +1,126 @@
+package credentials
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/fsnotify/fsnotify"
+	"github.com/quay/quay-bridge-operator/pkg/logging"
+)
+
+// FileProvider reads credentials from a directory containing a file for each key, such as a CSI secrets store volume. The directory is watched and credentials are reloaded when it changes
+type FileProvider struct {
+	path    string
+	watcher *fsnotify.Watcher
+
+	mu          sync.RWMutex
+	credentials map[string][]byte
+	err         error
+}
+
+func NewFileProvider(path string) (*FileProvider, error) {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := watcher.Add(path); err != nil {
+		watcher.Close()
+		return nil, fmt.Errorf("failed to watch credentials directory %s: %w", path, err)
+	}
+
+	p := &FileProvider{
+		path:    path,
+		watcher: watcher,
+	}
+
+	p.reload()
+
+	go p.watch()
+
+	return p, nil
+}
+
+func (p *FileProvider) Credentials(ctx context.Context) (map[string][]byte, error) {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	if p.err != nil {
+		return nil, p.err
+	}
+
+	credentials := make(map[string][]byte, len(p.credentials))
+	for key, value := range p.credentials {
+		credentials[key] = value
+	}
+
+	return credentials, nil
+}
+
+// Close stops watching the credentials directory
+func (p *FileProvider) Close() error {
+	return p.watcher.Close()
+}
+
+func (p *FileProvider) watch() {
+	for {
+		select {
+		case _, ok := <-p.watcher.Events:
+			if !ok {
+				return
+			}
+
+			p.reload()
+		case err, ok := <-p.watcher.Errors:
+			if !ok {
+				return
+			}
+
+			logging.Log.Error(err, "Error watching credentials directory", "Path", p.path)
+		}
+	}
+}
+
+// reload reads every file within the credentials directory. Hidden entries, such as the timestamped directories and symlinks used to update mounted volumes atomically, are ignored
+func (p *FileProvider) reload() {
+	credentials := map[string][]byte{}
+
+	entries, err := os.ReadDir(p.path)
+	if err == nil {
+		for _, entry := range entries {
+			if strings.HasPrefix(entry.Name(), ".") {
+				continue
+			}
+
+			filePath := filepath.Join(p.path, entry.Name())
+
+			info, statErr := os.Stat(filePath)
+			if statErr != nil || info.IsDir() {
+				continue
+			}
+
+			value, readErr := os.ReadFile(filePath)
+			if readErr != nil {
+				err = readErr
+				break
+			}
+
+			credentials[entry.Name()] = []byte(strings.TrimSpace(string(value)))
+		}
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if err != nil {
+		p.err = fmt.Errorf("failed to read credentials directory %s: %w", p.path, err)
+		return
+	}
+
+	p.credentials = credentials
+	p.err = nil
+}
@@ -0,0 +1,69 @@
+package credentials
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestFileProvider(t *testing.T) {
+
+	dir := t.TempDir()
+
+	if err := os.WriteFile(filepath.Join(dir, "token"), []byte("initial-token\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	// Entries used by mounted volumes to swap their contents atomically are ignored
+	if err := os.Mkdir(filepath.Join(dir, "..2024_01_01"), 0700); err != nil {
+		t.Fatal(err)
+	}
+
+	provider, err := NewFileProvider(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer provider.Close()
+
+	expected := map[string][]byte{"token": []byte("initial-token")}
+
+	credentials, err := provider.Credentials(context.TODO())
+	if err != nil || !reflect.DeepEqual(expected, credentials) {
+		t.Errorf("Initial credentials did not match\nExpected: %#v\nActual: %#v (%v)", expected, credentials, err)
+	}
+
+	// Replace the token the way a mounted volume would
+	rotated := filepath.Join(dir, ".token.tmp")
+	if err := os.WriteFile(rotated, []byte("rotated-token"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := os.Rename(rotated, filepath.Join(dir, "token")); err != nil {
+		t.Fatal(err)
+	}
+
+	expected = map[string][]byte{"token": []byte("rotated-token")}
+
+	deadline := time.Now().Add(5 * time.Second)
+	for time.Now().Before(deadline) {
+		credentials, err = provider.Credentials(context.TODO())
+		if err == nil && reflect.DeepEqual(expected, credentials) {
+			return
+		}
+
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	t.Errorf("Rotated credentials did not match\nExpected: %#v\nActual: %#v (%v)", expected, credentials, err)
+}
+
+func TestFileProviderMissingDirectory(t *testing.T) {
+
+	_, err := NewFileProvider(filepath.Join(t.TempDir(), "missing"))
+	if err == nil {
+		t.Errorf("Expected an error watching a missing directory")
+	}
+}
@@ -0,0 +1,57 @@
+package credentials
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"sort"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	CredentialsSourceSecret = "Secret"
+	CredentialsSourceFile   = "File"
+	CredentialsSourceVault  = "Vault"
+)
+
+// Provider supplies the credentials used to communicate with Quay, keyed by name such as token, clientID or username
+type Provider interface {
+	Credentials(ctx context.Context) (map[string][]byte, error)
+}
+
+// SecretProvider reads credentials from a Kubernetes Secret
+type SecretProvider struct {
+	Client client.Client
+	Name   types.NamespacedName
+}
+
+func (p *SecretProvider) Credentials(ctx context.Context) (map[string][]byte, error) {
+	secret := &corev1.Secret{}
+
+	if err := p.Client.Get(ctx, p.Name, secret); err != nil {
+		return nil, fmt.Errorf("failed to retrieve Secret %s: %w", p.Name, err)
+	}
+
+	return secret.Data, nil
+}
+
+// Fingerprint returns a digest of credentials used to detect changes without retaining the credentials themselves
+func Fingerprint(credentials map[string][]byte) string {
+	hash := sha256.New()
+
+	keys := make([]string, 0, len(credentials))
+	for key := range credentials {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		fmt.Fprintf(hash, "%s\x00%s\x00", key, credentials[key])
+	}
+
+	return hex.EncodeToString(hash.Sum(nil))
+}
@@ -0,0 +1,139 @@
+package credentials
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"golang.org/x/oauth2"
+	"golang.org/x/oauth2/clientcredentials"
+)
+
+const (
+	AuthenticationMethodToken             = "Token"
+	AuthenticationMethodClientCredentials = "ClientCredentials"
+	AuthenticationMethodPassword          = "Password"
+	ClientIDKey                           = "clientID"
+	ClientSecretKey                       = "clientSecret"
+	UsernameKey                           = "username"
+	PasswordKey                           = "password"
+
+	// tokenRefreshWindow is how long before expiry a token is replaced
+	tokenRefreshWindow = time.Minute
+)
+
+// TokenExchange represents the exchange of credentials for a short-lived token at an OAuth token endpoint
+type TokenExchange struct {
+	Method      string
+	TokenURL    string
+	Scopes      []string
+	Credentials map[string][]byte
+}
+
+// TokenCache reuses the tokens obtained by a TokenExchange until shortly before they expire
+type TokenCache struct {
+	mu      sync.Mutex
+	entries map[string]tokenCacheEntry
+}
+
+type tokenCacheEntry struct {
+	fingerprint string
+	tokenSource oauth2.TokenSource
+}
+
+func NewTokenCache() *TokenCache {
+	return &TokenCache{
+		entries: map[string]tokenCacheEntry{},
+	}
+}
+
+// Token returns a token for the exchange cached under name. Changes to the exchange, such as rotated credentials, discard the cached token
+func (c *TokenCache) Token(httpClient *http.Client, name string, exchange TokenExchange) (string, error) {
+	fingerprint := exchange.fingerprint()
+
+	c.mu.Lock()
+	entry, found := c.entries[name]
+	if !found || entry.fingerprint != fingerprint {
+		tokenSource, err := newTokenSource(httpClient, exchange)
+		if err != nil {
+			c.mu.Unlock()
+			return "", err
+		}
+
+		entry = tokenCacheEntry{fingerprint: fingerprint, tokenSource: tokenSource}
+		c.entries[name] = entry
+	}
+	c.mu.Unlock()
+
+	token, err := entry.tokenSource.Token()
+	if err != nil {
+		return "", fmt.Errorf("failed to obtain token from %s: %w", exchange.TokenURL, err)
+	}
+
+	return token.AccessToken, nil
+}
+
+// newTokenSource creates a token source which requests a new token once the current token is within the refresh window of expiring
+func newTokenSource(httpClient *http.Client, exchange TokenExchange) (oauth2.TokenSource, error) {
+	if exchange.TokenURL == "" {
+		return nil, fmt.Errorf("a token URL is required for the %s authentication method", exchange.Method)
+	}
+
+	// Tokens are refreshed outside of any single reconciliation
+	ctx := context.WithValue(context.Background(), oauth2.HTTPClient, httpClient)
+
+	var tokenSource oauth2.TokenSource
+
+	switch exchange.Method {
+	case AuthenticationMethodClientCredentials:
+		clientID, clientSecret := string(exchange.Credentials[ClientIDKey]), string(exchange.Credentials[ClientSecretKey])
+		if clientID == "" || clientSecret == "" {
+			return nil, fmt.Errorf("credentials must contain the '%s' and '%s' keys", ClientIDKey, ClientSecretKey)
+		}
+
+		config := &clientcredentials.Config{
+			ClientID:     clientID,
+			ClientSecret: clientSecret,
+			TokenURL:     exchange.TokenURL,
+			Scopes:       exchange.Scopes,
+		}
+
+		tokenSource = tokenSourceFunc(func() (*oauth2.Token, error) {
+			return config.Token(ctx)
+		})
+	case AuthenticationMethodPassword:
+		username, password := string(exchange.Credentials[UsernameKey]), string(exchange.Credentials[PasswordKey])
+		if username == "" || password == "" {
+			return nil, fmt.Errorf("credentials must contain the '%s' and '%s' keys", UsernameKey, PasswordKey)
+		}
+
+		config := &oauth2.Config{
+			ClientID:     string(exchange.Credentials[ClientIDKey]),
+			ClientSecret: string(exchange.Credentials[ClientSecretKey]),
+			Endpoint:     oauth2.Endpoint{TokenURL: exchange.TokenURL},
+			Scopes:       exchange.Scopes,
+		}
+
+		tokenSource = tokenSourceFunc(func() (*oauth2.Token, error) {
+			return config.PasswordCredentialsToken(ctx, username, password)
+		})
+	default:
+		return nil, fmt.Errorf("unsupported authentication method '%s'", exchange.Method)
+	}
+
+	return oauth2.ReuseTokenSourceWithExpiry(nil, tokenSource, tokenRefreshWindow), nil
+}
+
+// fingerprint returns a digest of the exchange used to detect changes such as rotated credentials
+func (e TokenExchange) fingerprint() string {
+	return fmt.Sprintf("%s\x00%s\x00%v\x00%s", e.Method, e.TokenURL, e.Scopes, Fingerprint(e.Credentials))
+}
+
+// tokenSourceFunc adapts a function to an oauth2.TokenSource
+type tokenSourceFunc func() (*oauth2.Token, error)
+
+func (f tokenSourceFunc) Token() (*oauth2.Token, error) {
+	return f()
+}
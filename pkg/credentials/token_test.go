@@ -0,0 +1,143 @@
+package credentials
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestTokenCache(t *testing.T) {
+
+	cases := []struct {
+		name             string
+		exchange         TokenExchange
+		expiresIn        int
+		rotate           map[string][]byte
+		expectedForm     map[string]string
+		expectedRequests int
+		expectedErr      bool
+	}{
+		{
+			name: "test-client-credentials",
+			exchange: TokenExchange{
+				Method:      AuthenticationMethodClientCredentials,
+				Scopes:      []string{"org:admin"},
+				Credentials: map[string][]byte{ClientIDKey: []byte("client"), ClientSecretKey: []byte("secret")},
+			},
+			expiresIn:        3600,
+			expectedForm:     map[string]string{"grant_type": "client_credentials", "scope": "org:admin"},
+			expectedRequests: 1,
+		},
+		{
+			name: "test-password",
+			exchange: TokenExchange{
+				Method:      AuthenticationMethodPassword,
+				Credentials: map[string][]byte{UsernameKey: []byte("admin"), PasswordKey: []byte("password")},
+			},
+			expiresIn:        3600,
+			expectedForm:     map[string]string{"grant_type": "password", "username": "admin", "password": "password"},
+			expectedRequests: 1,
+		},
+		{
+			name: "test-refresh-before-expiry",
+			exchange: TokenExchange{
+				Method:      AuthenticationMethodClientCredentials,
+				Credentials: map[string][]byte{ClientIDKey: []byte("client"), ClientSecretKey: []byte("secret")},
+			},
+			expiresIn:        30,
+			expectedForm:     map[string]string{"grant_type": "client_credentials"},
+			expectedRequests: 2,
+		},
+		{
+			name: "test-rotated-credentials",
+			exchange: TokenExchange{
+				Method:      AuthenticationMethodClientCredentials,
+				Credentials: map[string][]byte{ClientIDKey: []byte("client"), ClientSecretKey: []byte("secret")},
+			},
+			expiresIn:        3600,
+			rotate:           map[string][]byte{ClientIDKey: []byte("client"), ClientSecretKey: []byte("rotated")},
+			expectedForm:     map[string]string{"grant_type": "client_credentials"},
+			expectedRequests: 2,
+		},
+		{
+			name: "test-missing-credentials",
+			exchange: TokenExchange{
+				Method:      AuthenticationMethodPassword,
+				Credentials: map[string][]byte{UsernameKey: []byte("admin")},
+			},
+			expectedErr: true,
+		},
+		{
+			name: "test-unsupported-method",
+			exchange: TokenExchange{
+				Method: "Kerberos",
+			},
+			expectedErr: true,
+		},
+	}
+
+	for i, c := range cases {
+
+		t.Run(c.name, func(t *testing.T) {
+
+			requests := 0
+
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				requests++
+
+				if err := r.ParseForm(); err != nil {
+					t.Errorf("Test case %d failed to parse token request: %v", i, err)
+				}
+
+				for key, value := range c.expectedForm {
+					if r.PostForm.Get(key) != value {
+						t.Errorf("Test case %d did not match\nExpected %s: %#v\nActual: %#v", i, key, value, r.PostForm.Get(key))
+					}
+				}
+
+				w.Header().Set("Content-Type", "application/json")
+				json.NewEncoder(w).Encode(map[string]interface{}{
+					"access_token": fmt.Sprintf("token-%d", requests),
+					"token_type":   "Bearer",
+					"expires_in":   c.expiresIn,
+				})
+			}))
+			defer server.Close()
+
+			c.exchange.TokenURL = server.URL
+
+			cache := NewTokenCache()
+
+			token, err := cache.Token(server.Client(), "quay", c.exchange)
+
+			if c.expectedErr != (err != nil) {
+				t.Errorf("Test case %d did not match\nExpected Error: %#v\nActual: %#v", i, c.expectedErr, err)
+			}
+
+			if c.expectedErr {
+				return
+			}
+
+			if token != "token-1" {
+				t.Errorf("Test case %d did not match\nExpected: %#v\nActual: %#v", i, "token-1", token)
+			}
+
+			if c.rotate != nil {
+				c.exchange.Credentials = c.rotate
+			}
+
+			token, err = cache.Token(server.Client(), "quay", c.exchange)
+			if err != nil {
+				t.Errorf("Test case %d failed to obtain token: %v", i, err)
+			}
+
+			expectedToken := fmt.Sprintf("token-%d", c.expectedRequests)
+
+			if requests != c.expectedRequests || token != expectedToken {
+				t.Errorf("Test case %d did not match\nExpected: %#v after %d requests\nActual: %#v after %d requests", i, expectedToken, c.expectedRequests, token, requests)
+			}
+		})
+	}
+}
@@ -0,0 +1,221 @@
+package credentials
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	DefaultVaultMount                   = "secret"
+	DefaultVaultKVVersion               = 2
+	DefaultVaultAuthPath                = "kubernetes"
+	DefaultServiceAccountTokenPath      = "/var/run/secrets/kubernetes.io/serviceaccount/token"
+	VaultTokenEnvVar                    = "VAULT_TOKEN"
+	vaultTokenHeader                    = "X-Vault-Token"
+	vaultClientTokenRenewalLeaseDivisor = 4
+)
+
+// VaultProvider reads credentials from a HashiCorp Vault KV secrets engine. The operator authenticates using the Vault Kubernetes auth method when a Role is set, or otherwise with a static Token
+type VaultProvider struct {
+	HTTPClient              *http.Client
+	Address                 string
+	Mount                   string
+	Path                    string
+	KVVersion               int
+	AuthPath                string
+	Role                    string
+	ServiceAccountTokenPath string
+	Token                   string
+
+	mu                sync.Mutex
+	clientToken       string
+	clientTokenExpiry time.Time
+}
+
+type vaultLoginRequest struct {
+	Role string `json:"role"`
+	JWT  string `json:"jwt"`
+}
+
+type vaultLoginResponse struct {
+	Auth struct {
+		ClientToken   string `json:"client_token"`
+		LeaseDuration int    `json:"lease_duration"`
+	} `json:"auth"`
+}
+
+type vaultSecretResponse struct {
+	Data json.RawMessage `json:"data"`
+}
+
+type vaultKVv2Data struct {
+	Data map[string]interface{} `json:"data"`
+}
+
+func (p *VaultProvider) Credentials(ctx context.Context) (map[string][]byte, error) {
+	token, err := p.token(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "GET", p.secretURL(), nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set(vaultTokenHeader, token)
+
+	resp, err := p.HTTPClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read Vault secret %s: %w", p.Path, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		// The login token may have been revoked, so a new one is requested on the next read
+		if resp.StatusCode == http.StatusForbidden {
+			p.resetClientToken()
+		}
+
+		return nil, fmt.Errorf("failed to read Vault secret %s: status code %d", p.Path, resp.StatusCode)
+	}
+
+	var secretResponse vaultSecretResponse
+	if err := json.NewDecoder(resp.Body).Decode(&secretResponse); err != nil {
+		return nil, fmt.Errorf("failed to decode Vault secret %s: %w", p.Path, err)
+	}
+
+	data := map[string]interface{}{}
+
+	if p.kvVersion() == 2 {
+		var kvData vaultKVv2Data
+		if err := json.Unmarshal(secretResponse.Data, &kvData); err != nil {
+			return nil, fmt.Errorf("failed to decode Vault secret %s: %w", p.Path, err)
+		}
+		data = kvData.Data
+	} else if err := json.Unmarshal(secretResponse.Data, &data); err != nil {
+		return nil, fmt.Errorf("failed to decode Vault secret %s: %w", p.Path, err)
+	}
+
+	credentials := make(map[string][]byte, len(data))
+	for key, value := range data {
+		stringValue, ok := value.(string)
+		if !ok {
+			return nil, fmt.Errorf("value of key '%s' in Vault secret %s is not a string", key, p.Path)
+		}
+		credentials[key] = []byte(stringValue)
+	}
+
+	return credentials, nil
+}
+
+// token returns the Vault token used to read secrets, logging in with the Kubernetes auth method when a Role is set
+func (p *VaultProvider) token(ctx context.Context) (string, error) {
+	if p.Role == "" {
+		if p.Token == "" {
+			return "", fmt.Errorf("a Vault role or token is required")
+		}
+
+		return p.Token, nil
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.clientToken != "" && (p.clientTokenExpiry.IsZero() || time.Now().Before(p.clientTokenExpiry)) {
+		return p.clientToken, nil
+	}
+
+	serviceAccountTokenPath := p.ServiceAccountTokenPath
+	if serviceAccountTokenPath == "" {
+		serviceAccountTokenPath = DefaultServiceAccountTokenPath
+	}
+
+	jwt, err := os.ReadFile(serviceAccountTokenPath)
+	if err != nil {
+		return "", fmt.Errorf("failed to read service account token: %w", err)
+	}
+
+	body, err := json.Marshal(vaultLoginRequest{Role: p.Role, JWT: strings.TrimSpace(string(jwt))})
+	if err != nil {
+		return "", err
+	}
+
+	authPath := p.AuthPath
+	if authPath == "" {
+		authPath = DefaultVaultAuthPath
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", fmt.Sprintf("%s/v1/auth/%s/login", strings.TrimSuffix(p.Address, "/"), strings.Trim(authPath, "/")), bytes.NewReader(body))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := p.HTTPClient.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("failed to log in to Vault: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("failed to log in to Vault: status code %d", resp.StatusCode)
+	}
+
+	var loginResponse vaultLoginResponse
+	if err := json.NewDecoder(resp.Body).Decode(&loginResponse); err != nil {
+		return "", fmt.Errorf("failed to decode Vault login response: %w", err)
+	}
+
+	if loginResponse.Auth.ClientToken == "" {
+		return "", fmt.Errorf("vault login response did not contain a client token")
+	}
+
+	// Log in again once most of the lease has elapsed. Tokens without a lease do not expire
+	p.clientToken = loginResponse.Auth.ClientToken
+	p.clientTokenExpiry = time.Time{}
+	if loginResponse.Auth.LeaseDuration > 0 {
+		lease := time.Duration(loginResponse.Auth.LeaseDuration) * time.Second
+		p.clientTokenExpiry = time.Now().Add(lease - lease/vaultClientTokenRenewalLeaseDivisor)
+	}
+
+	return p.clientToken, nil
+}
+
+func (p *VaultProvider) resetClientToken() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.clientToken = ""
+}
+
+func (p *VaultProvider) secretURL() string {
+	mount := p.Mount
+	if mount == "" {
+		mount = DefaultVaultMount
+	}
+
+	address := strings.TrimSuffix(p.Address, "/")
+	mount = strings.Trim(mount, "/")
+	path := strings.Trim(p.Path, "/")
+
+	if p.kvVersion() == 2 {
+		return fmt.Sprintf("%s/v1/%s/data/%s", address, mount, path)
+	}
+
+	return fmt.Sprintf("%s/v1/%s/%s", address, mount, path)
+}
+
+func (p *VaultProvider) kvVersion() int {
+	if p.KVVersion == 0 {
+		return DefaultVaultKVVersion
+	}
+
+	return p.KVVersion
+}
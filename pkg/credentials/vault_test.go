@@ -0,0 +1,123 @@
+package credentials
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestVaultProvider(t *testing.T) {
+
+	cases := []struct {
+		name           string
+		provider       *VaultProvider
+		secretPath     string
+		secretBody     string
+		expected       map[string][]byte
+		expectedLogins int
+		expectedErr    bool
+	}{
+		{
+			name:           "test-kv-v2-kubernetes-auth",
+			provider:       &VaultProvider{Path: "quay/credentials", Role: "quay-bridge-operator"},
+			secretPath:     "/v1/secret/data/quay/credentials",
+			secretBody:     `{"data": {"data": {"token": "vault-token"}, "metadata": {"version": 3}}}`,
+			expected:       map[string][]byte{"token": []byte("vault-token")},
+			expectedLogins: 1,
+		},
+		{
+			name:       "test-kv-v1-static-token",
+			provider:   &VaultProvider{Mount: "kv", Path: "quay", KVVersion: 1, Token: "static-token"},
+			secretPath: "/v1/kv/quay",
+			secretBody: `{"data": {"clientID": "client", "clientSecret": "secret"}}`,
+			expected:   map[string][]byte{"clientID": []byte("client"), "clientSecret": []byte("secret")},
+		},
+		{
+			name:        "test-missing-secret",
+			provider:    &VaultProvider{Path: "missing", Token: "static-token"},
+			secretPath:  "/v1/secret/data/quay",
+			expectedErr: true,
+		},
+		{
+			name:        "test-non-string-value",
+			provider:    &VaultProvider{Path: "quay", Token: "static-token"},
+			secretPath:  "/v1/secret/data/quay",
+			secretBody:  `{"data": {"data": {"token": 1234}}}`,
+			expectedErr: true,
+		},
+		{
+			name:        "test-no-authentication",
+			provider:    &VaultProvider{Path: "quay"},
+			expectedErr: true,
+		},
+	}
+
+	for i, c := range cases {
+
+		t.Run(c.name, func(t *testing.T) {
+
+			serviceAccountTokenPath := filepath.Join(t.TempDir(), "token")
+			if err := os.WriteFile(serviceAccountTokenPath, []byte("service-account-jwt"), 0600); err != nil {
+				t.Fatal(err)
+			}
+
+			logins := 0
+
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				switch {
+				case r.Method == "POST" && r.URL.Path == "/v1/auth/kubernetes/login":
+					logins++
+
+					var login vaultLoginRequest
+					if err := json.NewDecoder(r.Body).Decode(&login); err != nil || login.Role != c.provider.Role || login.JWT != "service-account-jwt" {
+						t.Errorf("Test case %d sent an unexpected login request: %#v (%v)", i, login, err)
+					}
+
+					w.Write([]byte(`{"auth": {"client_token": "login-token", "lease_duration": 3600}}`))
+				case r.Method == "GET" && r.URL.Path == c.secretPath:
+					expectedToken := c.provider.Token
+					if c.provider.Role != "" {
+						expectedToken = "login-token"
+					}
+
+					if r.Header.Get(vaultTokenHeader) != expectedToken {
+						w.WriteHeader(http.StatusForbidden)
+						return
+					}
+
+					w.Write([]byte(c.secretBody))
+				default:
+					w.WriteHeader(http.StatusNotFound)
+				}
+			}))
+			defer server.Close()
+
+			provider := c.provider
+			provider.HTTPClient = server.Client()
+			provider.Address = server.URL
+			provider.ServiceAccountTokenPath = serviceAccountTokenPath
+
+			// Read twice to verify the login token is reused
+			for attempt := 0; attempt < 2; attempt++ {
+				credentials, err := provider.Credentials(context.TODO())
+
+				if c.expectedErr != (err != nil) {
+					t.Errorf("Test case %d did not match\nExpected Error: %#v\nActual: %#v", i, c.expectedErr, err)
+				}
+
+				if !c.expectedErr && !reflect.DeepEqual(c.expected, credentials) {
+					t.Errorf("Test case %d did not match\nExpected: %#v\nActual: %#v", i, c.expected, credentials)
+				}
+			}
+
+			if logins != c.expectedLogins {
+				t.Errorf("Test case %d did not match\nExpected Logins: %d\nActual: %d", i, c.expectedLogins, logins)
+			}
+		})
+	}
+}
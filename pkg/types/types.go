@@ -1,5 +1,12 @@
 package types
 
+import (
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
 type QuayInstance struct {
 	URL       string
 	AuthToken string
@@ -12,3 +19,166 @@ const (
 	DefaultOpenShiftServiceAccount  OpenShiftServiceAccount = "default"
 	DeployerOpenShiftServiceAccount OpenShiftServiceAccount = "deployer"
 )
+
+type QuayTeam string
+
+const (
+	AdminsQuayTeam  QuayTeam = "admins"
+	EditorsQuayTeam QuayTeam = "editors"
+	ViewersQuayTeam QuayTeam = "viewers"
+)
+
+// ImageStreamTagDestination represents an ImageStreamTag a Build will be imported into
+type ImageStreamTagDestination struct {
+	Namespace string
+	Name      string
+	Tag       string
+}
+
+func (d ImageStreamTagDestination) String() string {
+	return d.Namespace + "/" + d.Name + ":" + d.Tag
+}
+
+// RepositorySettings represents the effective settings of a Quay repository
+type RepositorySettings struct {
+	Visibility  string
+	Description string
+	Kind        string
+}
+
+// TagRetentionSettings represents the effective tag retention of a Quay repository
+type TagRetentionSettings struct {
+	KeepLastTags int
+	MaxTagAge    string
+}
+
+func (s TagRetentionSettings) String() string {
+	if s.KeepLastTags > 0 {
+		return "keep-last-tags=" + strconv.Itoa(s.KeepLastTags)
+	}
+
+	if s.MaxTagAge != "" {
+		return "max-tag-age=" + s.MaxTagAge
+	}
+
+	return "none"
+}
+
+// OrganizationQuotaSettings represents the effective storage quota of a Quay organization. A LimitBytes of 0 removes the quota
+type OrganizationQuotaSettings struct {
+	LimitBytes              int64
+	WarningThresholdPercent int
+	RejectThresholdPercent  int
+}
+
+// OrganizationContactSettings represents the resolved contact metadata of an organization
+type OrganizationContactSettings struct {
+	Email               string
+	InvoiceEmailAddress string
+}
+
+// RepositoryNotification represents a notification declared for a Quay repository
+type RepositoryNotification struct {
+	Name        string                 `json:"name"`
+	Event       string                 `json:"event"`
+	Method      string                 `json:"method"`
+	Config      map[string]interface{} `json:"config,omitempty"`
+	EventConfig map[string]interface{} `json:"eventConfig,omitempty"`
+}
+
+// VulnerabilitySummary represents the number of vulnerabilities of each severity found in an image
+type VulnerabilitySummary struct {
+	Counts  map[string]int
+	Highest string
+}
+
+// InboundSyncSettings represents the effective inbound synchronization of a namespace
+type InboundSyncSettings struct {
+	Enabled            bool
+	PollInterval       time.Duration
+	TagFilter          *regexp.Regexp
+	CreateImageStreams bool
+}
+
+// RepositoryMirrorSettings represents the upstream repository mirrored into the Quay repository of an ImageStream
+type RepositoryMirrorSettings struct {
+	ExternalReference string
+	TagPatterns       []string
+	SyncInterval      time.Duration
+	CredentialsSecret string
+	VerifyTLS         bool
+}
+
+// RobotCredentialsSettings represents how the credentials of the robot account associated to a service account are delivered
+type RobotCredentialsSettings struct {
+	Mode            string
+	StoreName       string
+	StoreKind       string
+	RemoteKey       string
+	RefreshInterval time.Duration
+}
+
+// OrganizationTarget represents the Quay organization the repositories and robot accounts of a namespace are created in
+type OrganizationTarget struct {
+	// Organization is the name of the Quay organization
+	Organization string
+	// Shared is set when the organization is shared with other namespaces rather than generated for the namespace
+	Shared bool
+	// RepositoryPrefix is prepended to the names of the repositories of the namespace
+	RepositoryPrefix string
+	// RobotAccountSuffix is appended to the names of the robot accounts of the namespace
+	RobotAccountSuffix string
+	// RepositoryPathTemplate renders the path of the repository of an ImageStream. The name of the ImageStream is used when empty
+	RepositoryPathTemplate string
+}
+
+// RepositoryName returns the name of the repository for a repository path
+func (t OrganizationTarget) RepositoryName(repositoryPath string) string {
+	return t.RepositoryPrefix + repositoryPath
+}
+
+// RobotAccountName returns the short name of the robot account associated to a service account
+func (t OrganizationTarget) RobotAccountName(serviceAccount string) string {
+	return serviceAccount + t.RobotAccountSuffix
+}
+
+// ImageStreamName returns the name of the ImageStream a repository of the organization belongs to. Repositories of shared organizations without a prefix cannot be attributed to a namespace
+func (t OrganizationTarget) ImageStreamName(repositoryName string) (string, bool) {
+	if t.Shared && t.RepositoryPrefix == "" {
+		return "", false
+	}
+
+	if !strings.HasPrefix(repositoryName, t.RepositoryPrefix) {
+		return "", false
+	}
+
+	return strings.TrimPrefix(repositoryName, t.RepositoryPrefix), true
+}
+
+// OrganizationOwnership represents whether the operator may manage the organization of a namespace
+type OrganizationOwnership struct {
+	// Owned is set when the operator may manage the organization
+	Owned bool
+	// Claim is set when the ownership of the organization must be recorded in Quay
+	Claim bool
+	// Origin is whether the organization was created or adopted by the operator
+	Origin string
+	// Owner is the cluster recorded as the owner of the organization, if any
+	Owner string
+}
+
+// ClusterClaim represents whether a cluster holds the claim of its ClusterID in Quay
+type ClusterClaim struct {
+	// Held is set when the cluster may manage the organizations of the ClusterID
+	Held bool
+	// Renew is set when the claim record must be written
+	Renew bool
+	// Takeover is set when the live claim of another cluster is taken over
+	Takeover bool
+	// Expired is set when the claim of another cluster was not renewed within the lease duration
+	Expired bool
+	// HolderUID is the unique identifier of the cluster recorded as holding the claim, if any
+	HolderUID string
+	// RenewTime is when the recorded claim was last renewed
+	RenewTime time.Time
+}
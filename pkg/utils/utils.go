@@ -1,11 +1,58 @@
 package utils
 
 import (
+	"bytes"
+	"encoding/json"
 	"fmt"
+	"net/mail"
+	"regexp"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"text/template"
+	"time"
 
+	imagev1 "github.com/openshift/api/image/v1"
+	quayv1 "github.com/quay/quay-bridge-operator/api/v1"
+	qclient "github.com/quay/quay-bridge-operator/pkg/client/quay"
 	"github.com/quay/quay-bridge-operator/pkg/constants"
+	"github.com/quay/quay-bridge-operator/pkg/credentials"
 	"github.com/quay/quay-bridge-operator/pkg/logging"
+	qotypes "github.com/quay/quay-bridge-operator/pkg/types"
 	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+var (
+	imageTagRegex = regexp.MustCompile(`^[\w][\w.-]{0,127}$`)
+	// repositoryNameRegex and nestedRepositoryNameRegex match the repository names accepted by Quay, nested names requiring extended repository names to be enabled
+	repositoryNameRegex       = regexp.MustCompile(`^[a-z0-9][.a-z0-9_-]{0,254}$`)
+	nestedRepositoryNameRegex = regexp.MustCompile(`^[a-z0-9]+(?:[._-][a-z0-9]+)*(?:/[a-z0-9]+(?:[._-][a-z0-9]+)*)+$`)
+	durationRegex             = regexp.MustCompile(`^([0-9]+)([smhdw])$`)
+	// clusterRoleQuayTeams maps the default OpenShift project roles to the Quay teams their users are members of
+	clusterRoleQuayTeams = map[string]qotypes.QuayTeam{
+		"admin": qotypes.AdminsQuayTeam,
+		"edit":  qotypes.EditorsQuayTeam,
+		"view":  qotypes.ViewersQuayTeam,
+	}
+	templateFuncs = template.FuncMap{
+		"lower":      strings.ToLower,
+		"upper":      strings.ToUpper,
+		"replace":    func(old, new, s string) string { return strings.ReplaceAll(s, old, new) },
+		"trimPrefix": func(prefix, s string) string { return strings.TrimPrefix(s, prefix) },
+		"trimSuffix": func(suffix, s string) string { return strings.TrimSuffix(s, suffix) },
+	}
+	durationUnits = map[string]time.Duration{
+		"s": time.Second,
+		"m": time.Minute,
+		"h": time.Hour,
+		"d": time.Hour * 24,
+		"w": time.Hour * 24 * 7,
+	}
 )
 
 func RemoveItemsFromSlice(s []string, r []string) []string {
@@ -83,6 +130,103 @@ func ObjectReferenceNameExists(objectReferenceNames []corev1.ObjectReference, na
 	return false
 }
 
+// GetBridgeLabels returns the labels identifying the objects written by the operator for a service account
+func GetBridgeLabels(quayIntegrationName string, serviceAccount string) map[string]string {
+	return map[string]string{
+		constants.ManagedByLabel:       constants.ManagedByLabelValue,
+		constants.QuayIntegrationLabel: quayIntegrationName,
+		constants.ServiceAccountLabel:  serviceAccount,
+	}
+}
+
+// LinkServiceAccountSecrets links the desired Secrets to a service account as mountable pull secrets and removes the references to Secrets previously linked by the operator that are no longer desired. The Secrets linked by the operator are recorded in an annotation so that references added by other means are never removed
+func LinkServiceAccountSecrets(serviceAccount *corev1.ServiceAccount, desired []string) bool {
+	var updated bool
+
+	previous := []string{}
+	if value, found := serviceAccount.Annotations[constants.LinkedSecretsAnnotation]; found && value != "" {
+		previous = strings.Split(value, ",")
+	}
+
+	for _, name := range previous {
+		if slices.Contains(desired, name) {
+			continue
+		}
+
+		if found := LocalObjectReferenceNameExists(serviceAccount.ImagePullSecrets, name); found {
+			serviceAccount.ImagePullSecrets = slices.DeleteFunc(serviceAccount.ImagePullSecrets, func(reference corev1.LocalObjectReference) bool { return reference.Name == name })
+			updated = true
+		}
+
+		if found := ObjectReferenceNameExists(serviceAccount.Secrets, name); found {
+			serviceAccount.Secrets = slices.DeleteFunc(serviceAccount.Secrets, func(reference corev1.ObjectReference) bool { return reference.Name == name })
+			updated = true
+		}
+	}
+
+	for _, name := range desired {
+		if found := LocalObjectReferenceNameExists(serviceAccount.ImagePullSecrets, name); !found {
+			serviceAccount.ImagePullSecrets = append(serviceAccount.ImagePullSecrets, corev1.LocalObjectReference{Name: name})
+			updated = true
+		}
+
+		if found := ObjectReferenceNameExists(serviceAccount.Secrets, name); !found {
+			serviceAccount.Secrets = append(serviceAccount.Secrets, corev1.ObjectReference{Name: name})
+			updated = true
+		}
+	}
+
+	linked := strings.Join(desired, ",")
+	if serviceAccount.Annotations[constants.LinkedSecretsAnnotation] != linked {
+		if len(desired) == 0 {
+			delete(serviceAccount.Annotations, constants.LinkedSecretsAnnotation)
+		} else {
+			if serviceAccount.Annotations == nil {
+				serviceAccount.Annotations = map[string]string{}
+			}
+			serviceAccount.Annotations[constants.LinkedSecretsAnnotation] = linked
+		}
+		updated = true
+	}
+
+	return updated
+}
+
+// GetManagedTeamMembers returns the members of a Quay team which were added by the operator for a namespace
+func GetManagedTeamMembers(namespace *corev1.Namespace, quayTeam qotypes.QuayTeam) []string {
+	value, found := namespace.Annotations[constants.TeamMembersAnnotationPrefix+string(quayTeam)]
+	if !found || value == "" {
+		return []string{}
+	}
+
+	return strings.Split(value, ",")
+}
+
+// SetManagedTeamMembers records the members of a Quay team which are managed by the operator for a namespace
+func SetManagedTeamMembers(namespace *corev1.Namespace, quayTeam qotypes.QuayTeam, members []string) bool {
+	annotation := constants.TeamMembersAnnotationPrefix + string(quayTeam)
+
+	sortedMembers := slices.Clone(members)
+	sort.Strings(sortedMembers)
+	value := strings.Join(sortedMembers, ",")
+
+	if current, found := namespace.Annotations[annotation]; (found && current == value) || (!found && value == "") {
+		return false
+	}
+
+	if value == "" {
+		delete(namespace.Annotations, annotation)
+		return true
+	}
+
+	if namespace.Annotations == nil {
+		namespace.Annotations = map[string]string{}
+	}
+	namespace.Annotations[annotation] = value
+
+	return true
+}
+
 func IsOpenShiftAnnotatedNamespace(namespace *corev1.Namespace) bool {
 
 	_, displayNameFound := namespace.Annotations[constants.OpenShiftDisplayNameAnnotation]
@@ -90,3 +234,1167 @@ func IsOpenShiftAnnotatedNamespace(namespace *corev1.Namespace) bool {
 
 	return displayNameFound && descriptionFound
 }
+
+// ImportBackoff returns the delay before the next ImageStream import attempt, doubling the base period for every failed attempt up to max.
+func ImportBackoff(attempts int, base time.Duration, max time.Duration) time.Duration {
+
+	backoff := base
+
+	for i := 1; i < attempts; i++ {
+		backoff = backoff * 2
+		if backoff >= max {
+			return max
+		}
+	}
+
+	return backoff
+}
+
+// IsValidImageTag returns whether the value is a valid image tag
+func IsValidImageTag(tag string) bool {
+	return imageTagRegex.MatchString(tag)
+}
+
+// ParseImageTags parses a comma separated list of image tags
+func ParseImageTags(value string) ([]string, error) {
+
+	tags := []string{}
+
+	for _, tag := range strings.Split(value, ",") {
+		tag = strings.TrimSpace(tag)
+
+		if tag == "" {
+			continue
+		}
+
+		if !IsValidImageTag(tag) {
+			return nil, fmt.Errorf("invalid image tag '%s'", tag)
+		}
+
+		tags = append(tags, tag)
+	}
+
+	return tags, nil
+}
+
+// FormatImageStreamTagDestinations formats destinations as a comma separated list of namespace/name:tag values
+func FormatImageStreamTagDestinations(destinations []qotypes.ImageStreamTagDestination) string {
+
+	values := make([]string, len(destinations))
+
+	for i, destination := range destinations {
+		values[i] = destination.String()
+	}
+
+	return strings.Join(values, ",")
+}
+
+// ParseImageStreamTagDestinations parses a comma separated list of namespace/name:tag values
+func ParseImageStreamTagDestinations(value string) ([]qotypes.ImageStreamTagDestination, error) {
+
+	destinations := []qotypes.ImageStreamTagDestination{}
+
+	for _, destinationValue := range strings.Split(value, ",") {
+
+		namespaceComponents := strings.Split(strings.TrimSpace(destinationValue), "/")
+
+		if len(namespaceComponents) != 2 {
+			return nil, fmt.Errorf("unexpected number of ImageStream components in '%s'", destinationValue)
+		}
+
+		nameTagComponents := strings.Split(namespaceComponents[1], ":")
+
+		if len(nameTagComponents) != 2 {
+			return nil, fmt.Errorf("unexpected number of ImageStream name components in '%s'", destinationValue)
+		}
+
+		destinations = append(destinations, qotypes.ImageStreamTagDestination{
+			Namespace: namespaceComponents[0],
+			Name:      nameTagComponents[0],
+			Tag:       nameTagComponents[1],
+		})
+	}
+
+	return destinations, nil
+}
+
+// TrimImageReference removes any tag or digest from an image reference
+func TrimImageReference(image string) string {
+
+	if idx := strings.Index(image, "@"); idx != -1 {
+		image = image[:idx]
+	}
+
+	if idx := strings.LastIndex(image, ":"); idx != -1 && idx > strings.LastIndex(image, "/") {
+		image = image[:idx]
+	}
+
+	return image
+}
+
+// GetImagePusherNamespaces returns the namespaces whose builder service account is granted the image pusher role by the RoleBinding
+func GetImagePusherNamespaces(roleBinding *rbacv1.RoleBinding) []string {
+
+	namespaces := []string{}
+
+	if roleBinding.RoleRef.Name != constants.ImagePusherRole {
+		return namespaces
+	}
+
+	for _, subject := range roleBinding.Subjects {
+		switch subject.Kind {
+		case rbacv1.ServiceAccountKind:
+			if subject.Name == string(qotypes.BuilderOpenShiftServiceAccount) && subject.Namespace != "" {
+				namespaces = append(namespaces, subject.Namespace)
+			}
+		case rbacv1.GroupKind:
+			if strings.HasPrefix(subject.Name, "system:serviceaccounts:") {
+				namespaces = append(namespaces, strings.TrimPrefix(subject.Name, "system:serviceaccounts:"))
+			}
+		case rbacv1.UserKind:
+			userComponents := strings.Split(subject.Name, ":")
+			if len(userComponents) == 4 && userComponents[0] == "system" && userComponents[1] == "serviceaccount" && userComponents[3] == string(qotypes.BuilderOpenShiftServiceAccount) {
+				namespaces = append(namespaces, userComponents[2])
+			}
+		}
+	}
+
+	return namespaces
+}
+
+// GetAnnotationValue returns the value of the annotation from the first object containing it. Objects should be ordered from most to least specific.
+func GetAnnotationValue(key string, objects ...metav1.Object) (string, bool) {
+
+	for _, object := range objects {
+		if object == nil {
+			continue
+		}
+
+		if value, found := object.GetAnnotations()[key]; found {
+			return value, true
+		}
+	}
+
+	return "", false
+}
+
+// GetRepositorySettings returns the settings of the repository for an ImageStream, applying ImageStream and Namespace annotation overrides to the QuayIntegration defaults
+func GetRepositorySettings(quayIntegration *quayv1.QuayIntegration, namespace *corev1.Namespace, imageStream metav1.Object) (qotypes.RepositorySettings, error) {
+
+	settings := qotypes.RepositorySettings{
+		Visibility: qclient.RepositoryVisibilityPrivate,
+		Kind:       qclient.RepositoryKindImage,
+	}
+
+	descriptionTemplate := ""
+
+	if quayIntegration.Spec.RepositoryDefaults != nil {
+		if quayIntegration.Spec.RepositoryDefaults.Visibility != "" {
+			settings.Visibility = quayIntegration.Spec.RepositoryDefaults.Visibility
+		}
+
+		if quayIntegration.Spec.RepositoryDefaults.Kind != "" {
+			settings.Kind = quayIntegration.Spec.RepositoryDefaults.Kind
+		}
+
+		descriptionTemplate = quayIntegration.Spec.RepositoryDefaults.Description
+	}
+
+	if value, found := GetAnnotationValue(constants.RepositoryVisibilityAnnotation, imageStream, namespace); found {
+		settings.Visibility = value
+	}
+
+	if value, found := GetAnnotationValue(constants.RepositoryKindAnnotation, imageStream, namespace); found {
+		settings.Kind = value
+	}
+
+	if value, found := GetAnnotationValue(constants.RepositoryDescriptionAnnotation, imageStream, namespace); found {
+		descriptionTemplate = value
+	}
+
+	if settings.Visibility != qclient.RepositoryVisibilityPrivate && settings.Visibility != qclient.RepositoryVisibilityPublic {
+		return settings, fmt.Errorf("invalid repository visibility '%s'", settings.Visibility)
+	}
+
+	if settings.Kind != qclient.RepositoryKindImage && settings.Kind != qclient.RepositoryKindApplication {
+		return settings, fmt.Errorf("invalid repository kind '%s'", settings.Kind)
+	}
+
+	descriptionTmpl, err := template.New("description").Parse(descriptionTemplate)
+	if err != nil {
+		return settings, fmt.Errorf("invalid repository description template: %w", err)
+	}
+
+	var description bytes.Buffer
+	err = descriptionTmpl.Execute(&description, struct {
+		Namespace string
+		Name      string
+	}{
+		Namespace: imageStream.GetNamespace(),
+		Name:      imageStream.GetName(),
+	})
+	if err != nil {
+		return settings, fmt.Errorf("invalid repository description template: %w", err)
+	}
+
+	settings.Description = description.String()
+
+	return settings, nil
+}
+
+// ParseQuayDuration parses a duration in the format accepted by Quay, such as 30d or 2w
+func ParseQuayDuration(duration string) (time.Duration, error) {
+	matches := durationRegex.FindStringSubmatch(duration)
+	if matches == nil {
+		return 0, fmt.Errorf("invalid duration '%s'", duration)
+	}
+
+	value, err := strconv.Atoi(matches[1])
+	if err != nil {
+		return 0, fmt.Errorf("invalid duration '%s': %w", duration, err)
+	}
+
+	return time.Duration(value) * durationUnits[matches[2]], nil
+}
+
+// GetTagRetentionSettings resolves the tag retention of a repository. The ImageStream, Namespace and QuayIntegration are consulted in order and the first to define a retention policy wins
+func GetTagRetentionSettings(quayIntegration *quayv1.QuayIntegration, namespace *corev1.Namespace, imageStream metav1.Object) (qotypes.TagRetentionSettings, error) {
+
+	settings := qotypes.TagRetentionSettings{}
+
+	for _, object := range []metav1.Object{imageStream, namespace} {
+		keepLastTags, keepLastTagsFound := GetAnnotationValue(constants.TagRetentionKeepLastTagsAnnotation, object)
+		maxTagAge, maxTagAgeFound := GetAnnotationValue(constants.TagRetentionMaxTagAgeAnnotation, object)
+
+		if !keepLastTagsFound && !maxTagAgeFound {
+			continue
+		}
+
+		if keepLastTagsFound {
+			value, err := strconv.Atoi(keepLastTags)
+			if err != nil {
+				return settings, fmt.Errorf("invalid tag retention keep last tags '%s'", keepLastTags)
+			}
+			settings.KeepLastTags = value
+		}
+
+		settings.MaxTagAge = maxTagAge
+
+		return settings, validateTagRetentionSettings(settings)
+	}
+
+	if quayIntegration.Spec.TagRetention != nil {
+		settings.KeepLastTags = quayIntegration.Spec.TagRetention.KeepLastTags
+		settings.MaxTagAge = quayIntegration.Spec.TagRetention.MaxTagAge
+	}
+
+	return settings, validateTagRetentionSettings(settings)
+}
+
+func validateTagRetentionSettings(settings qotypes.TagRetentionSettings) error {
+
+	if settings.KeepLastTags != 0 && settings.MaxTagAge != "" {
+		return fmt.Errorf("only one of keep last tags or max tag age may be specified")
+	}
+
+	if settings.KeepLastTags < 0 {
+		return fmt.Errorf("invalid tag retention keep last tags '%d'", settings.KeepLastTags)
+	}
+
+	if settings.MaxTagAge != "" {
+		if _, err := ParseQuayDuration(settings.MaxTagAge); err != nil {
+			return fmt.Errorf("invalid tag retention max tag age: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// GetUntaggedExpiration resolves the number of seconds untagged images are retained in an organization. The Namespace annotation takes precedence over the QuayIntegration. A value of false is returned if neither defines an expiration
+func GetUntaggedExpiration(quayIntegration *quayv1.QuayIntegration, namespace *corev1.Namespace) (int, bool, error) {
+
+	expiration := ""
+
+	if quayIntegration.Spec.TagRetention != nil {
+		expiration = quayIntegration.Spec.TagRetention.UntaggedExpiration
+	}
+
+	if value, found := GetAnnotationValue(constants.UntaggedExpirationAnnotation, namespace); found {
+		expiration = value
+	}
+
+	if expiration == "" {
+		return 0, false, nil
+	}
+
+	duration, err := ParseQuayDuration(expiration)
+	if err != nil {
+		return 0, false, fmt.Errorf("invalid untagged expiration: %w", err)
+	}
+
+	return int(duration.Seconds()), true, nil
+}
+
+// GetOrganizationTarget resolves the Quay organization of a namespace. Namespaces are given an organization of their own unless the target-organization annotation selects a shared organization the namespace is permitted to target, in which case repositories are prefixed with the namespace name unless the Unprefixed repository naming scheme is selected and allowed
+func GetOrganizationTarget(quayIntegration *quayv1.QuayIntegration, namespace *corev1.Namespace) (qotypes.OrganizationTarget, error) {
+
+	repositoryPathTemplate := quayIntegration.Spec.RepositoryPathTemplate
+	if value, found := GetAnnotationValue(constants.RepositoryPathTemplateAnnotation, namespace); found {
+		repositoryPathTemplate = value
+	}
+
+	if _, err := template.New("repositoryPath").Funcs(templateFuncs).Parse(repositoryPathTemplate); err != nil {
+		return qotypes.OrganizationTarget{}, fmt.Errorf("invalid repository path template: %w", err)
+	}
+
+	targetOrganization, found := GetAnnotationValue(constants.TargetOrganizationAnnotation, namespace)
+	if !found || targetOrganization == "" {
+		return qotypes.OrganizationTarget{Organization: quayIntegration.GenerateQuayOrganizationNameFromNamespace(namespace.Name), RepositoryPathTemplate: repositoryPathTemplate}, nil
+	}
+
+	var sharedOrganization *quayv1.SharedOrganization
+	for i := range quayIntegration.Spec.SharedOrganizations {
+		if quayIntegration.Spec.SharedOrganizations[i].Name == targetOrganization {
+			sharedOrganization = &quayIntegration.Spec.SharedOrganizations[i]
+			break
+		}
+	}
+
+	if sharedOrganization == nil {
+		return qotypes.OrganizationTarget{}, fmt.Errorf("target organization '%s' is not a shared organization of the QuayIntegration", targetOrganization)
+	}
+
+	if sharedOrganization.NamespaceSelector == nil {
+		return qotypes.OrganizationTarget{}, fmt.Errorf("shared organization '%s' does not define a namespace selector", targetOrganization)
+	}
+
+	selector, err := metav1.LabelSelectorAsSelector(sharedOrganization.NamespaceSelector)
+	if err != nil {
+		return qotypes.OrganizationTarget{}, fmt.Errorf("invalid namespace selector of shared organization '%s': %w", targetOrganization, err)
+	}
+
+	if !selector.Matches(labels.Set(namespace.Labels)) {
+		return qotypes.OrganizationTarget{}, fmt.Errorf("namespace '%s' is not permitted to target shared organization '%s'", namespace.Name, targetOrganization)
+	}
+
+	target := qotypes.OrganizationTarget{
+		Organization:           targetOrganization,
+		Shared:                 true,
+		RepositoryPrefix:       namespace.Name + "_",
+		RobotAccountSuffix:     "_" + strings.ReplaceAll(namespace.Name, "-", "_"),
+		RepositoryPathTemplate: repositoryPathTemplate,
+	}
+
+	repositoryNaming, _ := GetAnnotationValue(constants.RepositoryNamingAnnotation, namespace)
+
+	switch repositoryNaming {
+	case "", constants.RepositoryNamingPrefixed:
+	case constants.RepositoryNamingUnprefixed:
+		if !sharedOrganization.AllowUnprefixedRepositories {
+			return qotypes.OrganizationTarget{}, fmt.Errorf("shared organization '%s' does not allow unprefixed repositories", targetOrganization)
+		}
+		target.RepositoryPrefix = ""
+	default:
+		return qotypes.OrganizationTarget{}, fmt.Errorf("invalid repository naming '%s'", repositoryNaming)
+	}
+
+	return target, nil
+}
+
+// GetRepositoryName renders the name of the Quay repository of an ImageStream within the organization of its namespace and validates it against the naming rules of Quay
+func GetRepositoryName(target qotypes.OrganizationTarget, imageStream metav1.Object) (string, error) {
+
+	repositoryPath := imageStream.GetName()
+
+	if target.RepositoryPathTemplate != "" {
+		repositoryPathTmpl, err := template.New("repositoryPath").Funcs(templateFuncs).Option("missingkey=zero").Parse(target.RepositoryPathTemplate)
+		if err != nil {
+			return "", fmt.Errorf("invalid repository path template: %w", err)
+		}
+
+		var path bytes.Buffer
+		err = repositoryPathTmpl.Execute(&path, struct {
+			Namespace   string
+			Name        string
+			Labels      map[string]string
+			Annotations map[string]string
+		}{
+			Namespace:   imageStream.GetNamespace(),
+			Name:        imageStream.GetName(),
+			Labels:      imageStream.GetLabels(),
+			Annotations: imageStream.GetAnnotations(),
+		})
+		if err != nil {
+			return "", fmt.Errorf("invalid repository path template: %w", err)
+		}
+
+		repositoryPath = strings.TrimSpace(path.String())
+	}
+
+	repositoryName := target.RepositoryName(repositoryPath)
+
+	if strings.Contains(repositoryName, "/") {
+		if len(repositoryName) < 2 || len(repositoryName) > 255 || !nestedRepositoryNameRegex.MatchString(repositoryName) {
+			return "", fmt.Errorf("invalid nested repository name '%s'", repositoryName)
+		}
+	} else if !repositoryNameRegex.MatchString(repositoryName) {
+		return "", fmt.Errorf("invalid repository name '%s'", repositoryName)
+	}
+
+	return repositoryName, nil
+}
+
+// GetOrganizationContactSettings resolves the contact metadata of the organization for a namespace. Namespace annotations take precedence over the templates of the QuayIntegration
+func GetOrganizationContactSettings(quayIntegration *quayv1.QuayIntegration, namespace *corev1.Namespace, quayOrganizationName string) (qotypes.OrganizationContactSettings, error) {
+
+	settings := qotypes.OrganizationContactSettings{}
+
+	emailTemplate := ""
+	invoiceEmailTemplate := ""
+
+	if quayIntegration.Spec.OrganizationContact != nil {
+		emailTemplate = quayIntegration.Spec.OrganizationContact.EmailTemplate
+		invoiceEmailTemplate = quayIntegration.Spec.OrganizationContact.InvoiceEmailTemplate
+	}
+
+	if value, found := GetAnnotationValue(constants.OrganizationEmailAnnotation, namespace); found {
+		emailTemplate = value
+	}
+
+	if value, found := GetAnnotationValue(constants.OrganizationInvoiceEmailAnnotation, namespace); found {
+		invoiceEmailTemplate = value
+	}
+
+	var err error
+
+	settings.Email, err = renderOrganizationEmail("email", emailTemplate, quayIntegration, namespace, quayOrganizationName)
+	if err != nil {
+		return settings, err
+	}
+
+	settings.InvoiceEmailAddress, err = renderOrganizationEmail("invoice email", invoiceEmailTemplate, quayIntegration, namespace, quayOrganizationName)
+	if err != nil {
+		return settings, err
+	}
+
+	return settings, nil
+}
+
+// renderOrganizationEmail renders an organization email template and validates the resulting address
+func renderOrganizationEmail(kind string, emailTemplate string, quayIntegration *quayv1.QuayIntegration, namespace *corev1.Namespace, quayOrganizationName string) (string, error) {
+
+	if emailTemplate == "" {
+		return "", nil
+	}
+
+	emailTmpl, err := template.New("email").Parse(emailTemplate)
+	if err != nil {
+		return "", fmt.Errorf("invalid organization %s template: %w", kind, err)
+	}
+
+	var email bytes.Buffer
+	err = emailTmpl.Execute(&email, struct {
+		Namespace    string
+		Organization string
+		ClusterID    string
+	}{
+		Namespace:    namespace.Name,
+		Organization: quayOrganizationName,
+		ClusterID:    quayIntegration.Spec.ClusterID,
+	})
+	if err != nil {
+		return "", fmt.Errorf("invalid organization %s template: %w", kind, err)
+	}
+
+	address, err := mail.ParseAddress(email.String())
+	if err != nil || address.Name != "" {
+		return "", fmt.Errorf("invalid organization %s '%s'", kind, email.String())
+	}
+
+	return address.Address, nil
+}
+
+// GetOrganizationQuotaSettings resolves the storage quota of the organization for a namespace. The Namespace annotation takes precedence over a ResourceQuota in the namespace, which takes precedence over the QuayIntegration. A value of false is returned if no quota is defined
+func GetOrganizationQuotaSettings(quayIntegration *quayv1.QuayIntegration, namespace *corev1.Namespace, resourceQuotas []corev1.ResourceQuota) (qotypes.OrganizationQuotaSettings, bool, error) {
+
+	settings := qotypes.OrganizationQuotaSettings{
+		RejectThresholdPercent: constants.DefaultQuotaRejectThresholdPercent,
+	}
+
+	var limit *resource.Quantity
+
+	if quayIntegration.Spec.OrganizationQuota != nil {
+		limit = quayIntegration.Spec.OrganizationQuota.Limit
+		settings.WarningThresholdPercent = quayIntegration.Spec.OrganizationQuota.WarningThresholdPercent
+
+		if quayIntegration.Spec.OrganizationQuota.RejectThresholdPercent != 0 {
+			settings.RejectThresholdPercent = quayIntegration.Spec.OrganizationQuota.RejectThresholdPercent
+		}
+	}
+
+	// The most restrictive ResourceQuota applies
+	var resourceQuotaLimit *resource.Quantity
+	for _, resourceQuota := range resourceQuotas {
+		if hard, found := resourceQuota.Spec.Hard[corev1.ResourceName(constants.OrganizationStorageResourceName)]; found {
+			if resourceQuotaLimit == nil || hard.Cmp(*resourceQuotaLimit) < 0 {
+				hardLimit := hard.DeepCopy()
+				resourceQuotaLimit = &hardLimit
+			}
+		}
+	}
+
+	if resourceQuotaLimit != nil {
+		limit = resourceQuotaLimit
+	}
+
+	if value, found := GetAnnotationValue(constants.OrganizationQuotaAnnotation, namespace); found {
+		annotationLimit, err := resource.ParseQuantity(value)
+		if err != nil {
+			return settings, false, fmt.Errorf("invalid organization quota '%s': %w", value, err)
+		}
+		limit = &annotationLimit
+	}
+
+	if limit == nil {
+		return settings, false, nil
+	}
+
+	if limit.Sign() < 0 {
+		return settings, false, fmt.Errorf("invalid organization quota '%s'", limit.String())
+	}
+
+	if settings.WarningThresholdPercent >= settings.RejectThresholdPercent {
+		return settings, false, fmt.Errorf("warning threshold of %d%% must be less than the reject threshold of %d%%", settings.WarningThresholdPercent, settings.RejectThresholdPercent)
+	}
+
+	settings.LimitBytes = limit.Value()
+
+	return settings, true, nil
+}
+
+// GetOrganizationQuotaCondition returns the Namespace condition describing the storage used by an organization against its quota
+func GetOrganizationQuotaCondition(quayOrganizationName string, settings qotypes.OrganizationQuotaSettings, usageBytes int64) corev1.NamespaceCondition {
+
+	condition := corev1.NamespaceCondition{
+		Type:   corev1.NamespaceConditionType(constants.OrganizationQuotaConditionType),
+		Status: corev1.ConditionFalse,
+		Reason: "WithinQuota",
+	}
+
+	if settings.LimitBytes == 0 {
+		condition.Message = fmt.Sprintf("Quay organization %s has no storage quota", quayOrganizationName)
+		return condition
+	}
+
+	percent := int(usageBytes * 100 / settings.LimitBytes)
+
+	condition.Message = fmt.Sprintf("Quay organization %s is using %s of %s (%d%%)", quayOrganizationName, resource.NewQuantity(usageBytes, resource.BinarySI).String(), resource.NewQuantity(settings.LimitBytes, resource.BinarySI).String(), percent)
+
+	if percent >= settings.RejectThresholdPercent {
+		condition.Status = corev1.ConditionTrue
+		condition.Reason = "HardLimitExceeded"
+	} else if settings.WarningThresholdPercent > 0 && percent >= settings.WarningThresholdPercent {
+		condition.Status = corev1.ConditionTrue
+		condition.Reason = "SoftLimitExceeded"
+	}
+
+	return condition
+}
+
+// GetQuayUsername maps an OpenShift user to a Quay user using the mappings and username template of the team sync configuration
+func GetQuayUsername(teamSync *quayv1.TeamSync, username string) (string, error) {
+
+	for _, usernameMapping := range teamSync.UsernameMappings {
+		if usernameMapping.OpenShiftUsername == username {
+			return usernameMapping.QuayUsername, nil
+		}
+	}
+
+	if teamSync.UsernameTemplate == "" {
+		return username, nil
+	}
+
+	usernameTmpl, err := template.New("username").Funcs(templateFuncs).Parse(teamSync.UsernameTemplate)
+	if err != nil {
+		return "", fmt.Errorf("invalid username template: %w", err)
+	}
+
+	var quayUsername bytes.Buffer
+	err = usernameTmpl.Execute(&quayUsername, struct {
+		Username string
+	}{
+		Username: username,
+	})
+	if err != nil {
+		return "", fmt.Errorf("invalid username template: %w", err)
+	}
+
+	return strings.TrimSpace(quayUsername.String()), nil
+}
+
+// GetQuayTeamMembers returns the sorted Quay users of each Quay team from the users bound to the admin, edit and view roles by RoleBindings
+func GetQuayTeamMembers(teamSync *quayv1.TeamSync, roleBindings []rbacv1.RoleBinding) (map[qotypes.QuayTeam][]string, error) {
+
+	teamMembers := map[qotypes.QuayTeam][]string{}
+	for _, quayTeam := range clusterRoleQuayTeams {
+		teamMembers[quayTeam] = []string{}
+	}
+
+	for _, roleBinding := range roleBindings {
+		if roleBinding.RoleRef.Kind != "ClusterRole" {
+			continue
+		}
+
+		quayTeam, found := clusterRoleQuayTeams[roleBinding.RoleRef.Name]
+		if !found {
+			continue
+		}
+
+		for _, subject := range roleBinding.Subjects {
+			if subject.Kind != rbacv1.UserKind || strings.HasPrefix(subject.Name, "system:") {
+				continue
+			}
+
+			quayUsername, err := GetQuayUsername(teamSync, subject.Name)
+			if err != nil {
+				return nil, err
+			}
+
+			if quayUsername == "" || slices.Contains(teamMembers[quayTeam], quayUsername) {
+				continue
+			}
+
+			teamMembers[quayTeam] = append(teamMembers[quayTeam], quayUsername)
+		}
+	}
+
+	for quayTeam := range teamMembers {
+		sort.Strings(teamMembers[quayTeam])
+	}
+
+	return teamMembers, nil
+}
+
+// GetRepositoryNotifications returns the notifications declared for the repository of an ImageStream. Notifications declared on the Namespace apply to every repository and are replaced by notifications of the same name declared on the ImageStream
+func GetRepositoryNotifications(namespace *corev1.Namespace, imageStream metav1.Object) ([]qotypes.RepositoryNotification, error) {
+
+	notifications := []qotypes.RepositoryNotification{}
+
+	for _, object := range []metav1.Object{namespace, imageStream} {
+		value, found := GetAnnotationValue(constants.RepositoryNotificationsAnnotation, object)
+		if !found {
+			continue
+		}
+
+		declaredNotifications := []qotypes.RepositoryNotification{}
+		if err := json.Unmarshal([]byte(value), &declaredNotifications); err != nil {
+			return nil, fmt.Errorf("invalid repository notifications: %w", err)
+		}
+
+		declaredNames := map[string]bool{}
+		for _, notification := range declaredNotifications {
+			if notification.Name == "" || notification.Event == "" || notification.Method == "" {
+				return nil, fmt.Errorf("repository notifications require a name, event and method")
+			}
+
+			if declaredNames[notification.Name] {
+				return nil, fmt.Errorf("duplicate repository notification '%s'", notification.Name)
+			}
+			declaredNames[notification.Name] = true
+		}
+
+		// Notifications of the same name declared on a more specific object take precedence
+		for _, notification := range notifications {
+			if !declaredNames[notification.Name] {
+				declaredNotifications = append(declaredNotifications, notification)
+			}
+		}
+
+		notifications = declaredNotifications
+	}
+
+	sort.Slice(notifications, func(i, j int) bool {
+		return notifications[i].Name < notifications[j].Name
+	})
+
+	return notifications, nil
+}
+
+// SummarizeVulnerabilities counts the vulnerabilities of each severity reported by a Quay security scan
+func SummarizeVulnerabilities(security qclient.ManifestSecurity) qotypes.VulnerabilitySummary {
+
+	summary := qotypes.VulnerabilitySummary{
+		Counts: map[string]int{},
+	}
+
+	if security.Data == nil {
+		return summary
+	}
+
+	for _, feature := range security.Data.Layer.Features {
+		for _, vulnerability := range feature.Vulnerabilities {
+			severity := vulnerability.Severity
+			if !slices.Contains(qclient.VulnerabilitySeverities, severity) {
+				severity = "Unknown"
+			}
+
+			summary.Counts[severity]++
+
+			if summary.Highest == "" || IsSeverityAtLeast(severity, summary.Highest) {
+				summary.Highest = severity
+			}
+		}
+	}
+
+	return summary
+}
+
+// FormatVulnerabilitySummary formats the vulnerability counts from most to least severe, such as Critical=1,High=3
+func FormatVulnerabilitySummary(summary qotypes.VulnerabilitySummary) string {
+
+	counts := []string{}
+
+	for i := len(qclient.VulnerabilitySeverities) - 1; i >= 0; i-- {
+		severity := qclient.VulnerabilitySeverities[i]
+		if summary.Counts[severity] > 0 {
+			counts = append(counts, fmt.Sprintf("%s=%d", severity, summary.Counts[severity]))
+		}
+	}
+
+	if len(counts) == 0 {
+		return "None"
+	}
+
+	return strings.Join(counts, ",")
+}
+
+// IsSeverityAtLeast returns whether a vulnerability severity is at least as severe as the threshold
+func IsSeverityAtLeast(severity string, threshold string) bool {
+	return slices.Index(qclient.VulnerabilitySeverities, severity) >= slices.Index(qclient.VulnerabilitySeverities, threshold) && slices.Contains(qclient.VulnerabilitySeverities, severity)
+}
+
+// GetImageDigest returns the digest of an image reference pinned by digest
+func GetImageDigest(image string) (string, bool) {
+	if i := strings.LastIndex(image, "@"); i != -1 && strings.HasPrefix(image[i+1:], "sha256:") {
+		return image[i+1:], true
+	}
+
+	return "", false
+}
+
+// GetImageTag returns the tag of an image reference, which defaults to latest
+func GetImageTag(image string) string {
+
+	if idx := strings.Index(image, "@"); idx != -1 {
+		image = image[:idx]
+	}
+
+	if idx := strings.LastIndex(image, ":"); idx != -1 && idx > strings.LastIndex(image, "/") {
+		return image[idx+1:]
+	}
+
+	return constants.DefaultImageTag
+}
+
+// GetImageStreamTagDigest returns the digest currently imported into the tag of an ImageStream which an image reference by tag refers to
+func GetImageStreamTagDigest(imageStream *imagev1.ImageStream, image string) (string, bool) {
+	repository := TrimImageReference(image)
+	tag := GetImageTag(image)
+
+	for _, tagEvents := range imageStream.Status.Tags {
+		if tagEvents.Tag != tag || len(tagEvents.Items) == 0 {
+			continue
+		}
+
+		latest := tagEvents.Items[0]
+
+		if repository == imageStream.Status.DockerImageRepository ||
+			repository == imageStream.Status.PublicDockerImageRepository ||
+			repository == TrimImageReference(latest.DockerImageReference) ||
+			(imageStream.Spec.LookupPolicy.Local && repository == imageStream.Name) {
+			return latest.Image, true
+		}
+	}
+
+	return "", false
+}
+
+// GetInboundSyncSettings resolves the inbound synchronization of a namespace, applying Namespace annotation overrides to the QuayIntegration
+func GetInboundSyncSettings(quayIntegration *quayv1.QuayIntegration, namespace *corev1.Namespace) (qotypes.InboundSyncSettings, error) {
+
+	settings := qotypes.InboundSyncSettings{
+		PollInterval: constants.DefaultInboundSyncPollInterval,
+	}
+
+	tagFilter := ""
+
+	if quayIntegration.Spec.InboundSync != nil {
+		settings.Enabled = quayIntegration.Spec.InboundSync.Enabled
+		tagFilter = quayIntegration.Spec.InboundSync.TagFilter
+		settings.CreateImageStreams = quayIntegration.Spec.InboundSync.CreateImageStreams
+
+		if quayIntegration.Spec.InboundSync.PollInterval != nil && quayIntegration.Spec.InboundSync.PollInterval.Duration > 0 {
+			settings.PollInterval = quayIntegration.Spec.InboundSync.PollInterval.Duration
+		}
+	}
+
+	if value, found := GetAnnotationValue(constants.InboundSyncAnnotation, namespace); found {
+		enabled, err := strconv.ParseBool(value)
+		if err != nil {
+			return settings, fmt.Errorf("invalid inbound sync '%s'", value)
+		}
+		settings.Enabled = enabled
+	}
+
+	if value, found := GetAnnotationValue(constants.InboundSyncTagFilterAnnotation, namespace); found {
+		tagFilter = value
+	}
+
+	if value, found := GetAnnotationValue(constants.InboundSyncCreateImageStreamsAnnotation, namespace); found {
+		createImageStreams, err := strconv.ParseBool(value)
+		if err != nil {
+			return settings, fmt.Errorf("invalid inbound sync ImageStream creation '%s'", value)
+		}
+		settings.CreateImageStreams = createImageStreams
+	}
+
+	if tagFilter != "" {
+		tagFilterRegex, err := regexp.Compile(tagFilter)
+		if err != nil {
+			return settings, fmt.Errorf("invalid inbound sync tag filter: %w", err)
+		}
+		settings.TagFilter = tagFilterRegex
+	}
+
+	return settings, nil
+}
+
+// GetRepositoryMirrorSettings returns the upstream repository declared to be mirrored into the repository of an ImageStream
+func GetRepositoryMirrorSettings(imageStream metav1.Object) (qotypes.RepositoryMirrorSettings, bool, error) {
+
+	settings := qotypes.RepositoryMirrorSettings{
+		SyncInterval: constants.DefaultMirrorSyncInterval,
+		VerifyTLS:    true,
+	}
+
+	externalReference, found := GetAnnotationValue(constants.MirrorSourceAnnotation, imageStream)
+	if !found || strings.TrimSpace(externalReference) == "" {
+		return settings, false, nil
+	}
+	settings.ExternalReference = strings.TrimSpace(externalReference)
+
+	tags := constants.DefaultMirrorTags
+	if value, found := GetAnnotationValue(constants.MirrorTagsAnnotation, imageStream); found {
+		tags = value
+	}
+
+	for _, tag := range strings.Split(tags, ",") {
+		if tag = strings.TrimSpace(tag); tag != "" {
+			settings.TagPatterns = append(settings.TagPatterns, tag)
+		}
+	}
+
+	if len(settings.TagPatterns) == 0 {
+		return settings, true, fmt.Errorf("repository mirror requires at least one tag pattern")
+	}
+
+	if value, found := GetAnnotationValue(constants.MirrorSyncIntervalAnnotation, imageStream); found {
+		syncInterval, err := ParseQuayDuration(value)
+		if err != nil {
+			return settings, true, fmt.Errorf("invalid repository mirror sync interval: %w", err)
+		}
+
+		if syncInterval < time.Minute {
+			return settings, true, fmt.Errorf("repository mirror sync interval '%s' must be at least 1m", value)
+		}
+		settings.SyncInterval = syncInterval
+	}
+
+	if value, found := GetAnnotationValue(constants.MirrorVerifyTLSAnnotation, imageStream); found {
+		verifyTLS, err := strconv.ParseBool(value)
+		if err != nil {
+			return settings, true, fmt.Errorf("invalid repository mirror verify TLS '%s'", value)
+		}
+		settings.VerifyTLS = verifyTLS
+	}
+
+	settings.CredentialsSecret, _ = GetAnnotationValue(constants.MirrorCredentialsSecretAnnotation, imageStream)
+
+	return settings, true, nil
+}
+
+// GetRobotCredentialsSettings resolves how the credentials of the robot account associated to a service account are delivered, rendering the remote key of the external secret store
+func GetRobotCredentialsSettings(quayIntegration *quayv1.QuayIntegration, namespace string, serviceAccount string, organization string, robot string) (qotypes.RobotCredentialsSettings, error) {
+
+	settings := qotypes.RobotCredentialsSettings{
+		Mode:            credentials.RobotCredentialsModeSecret,
+		StoreKind:       credentials.ClusterSecretStoreKind,
+		RefreshInterval: constants.DefaultRobotCredentialsRefreshInterval,
+	}
+
+	robotCredentials := quayIntegration.Spec.RobotCredentials
+	if robotCredentials == nil {
+		return settings, nil
+	}
+
+	if robotCredentials.Mode != "" {
+		settings.Mode = robotCredentials.Mode
+	}
+
+	switch settings.Mode {
+	case credentials.RobotCredentialsModeSecret:
+		return settings, nil
+	case credentials.RobotCredentialsModePushSecret, credentials.RobotCredentialsModeExternalSecret:
+		if robotCredentials.SecretStoreRef == nil || robotCredentials.SecretStoreRef.Name == "" {
+			return settings, fmt.Errorf("a secret store is required by robot credentials mode '%s'", settings.Mode)
+		}
+
+		settings.StoreName = robotCredentials.SecretStoreRef.Name
+
+		if robotCredentials.SecretStoreRef.Kind != "" {
+			settings.StoreKind = robotCredentials.SecretStoreRef.Kind
+		}
+	case credentials.RobotCredentialsModeReference:
+	default:
+		return settings, fmt.Errorf("invalid robot credentials mode '%s'", settings.Mode)
+	}
+
+	if robotCredentials.RefreshInterval != nil && robotCredentials.RefreshInterval.Duration > 0 {
+		settings.RefreshInterval = robotCredentials.RefreshInterval.Duration
+	}
+
+	remoteKeyTemplate := constants.DefaultRobotCredentialsRemoteKeyTemplate
+	if robotCredentials.RemoteKeyTemplate != "" {
+		remoteKeyTemplate = robotCredentials.RemoteKeyTemplate
+	}
+
+	remoteKeyTmpl, err := template.New("remoteKey").Parse(remoteKeyTemplate)
+	if err != nil {
+		return settings, fmt.Errorf("invalid robot credentials remote key template: %w", err)
+	}
+
+	var remoteKey bytes.Buffer
+	err = remoteKeyTmpl.Execute(&remoteKey, struct {
+		Namespace      string
+		ServiceAccount string
+		Organization   string
+		Robot          string
+	}{
+		Namespace:      namespace,
+		ServiceAccount: serviceAccount,
+		Organization:   organization,
+		Robot:          robot,
+	})
+	if err != nil {
+		return settings, fmt.Errorf("invalid robot credentials remote key template: %w", err)
+	}
+
+	if remoteKey.Len() == 0 {
+		return settings, fmt.Errorf("robot credentials remote key template rendered an empty key")
+	}
+
+	settings.RemoteKey = remoteKey.String()
+
+	return settings, nil
+}
+
+// IsAdoptionRequested determines whether the adopt annotation is set on the given objects, the first object taking precedence
+func IsAdoptionRequested(objects ...metav1.Object) (bool, error) {
+	value, found := GetAnnotationValue(constants.AdoptAnnotation, objects...)
+	if !found {
+		return false, nil
+	}
+
+	adopt, err := strconv.ParseBool(value)
+	if err != nil {
+		return false, fmt.Errorf("invalid adopt annotation '%s'", value)
+	}
+
+	return adopt, nil
+}
+
+// IsFinalizationForced determines whether the force-finalize annotation is set on the given objects, the first object taking precedence. Invalid values do not force finalization
+func IsFinalizationForced(objects ...metav1.Object) bool {
+	value, found := GetAnnotationValue(constants.ForceFinalizeAnnotation, objects...)
+	if !found {
+		return false
+	}
+
+	force, err := strconv.ParseBool(value)
+	return err == nil && force
+}
+
+// GetFinalizationBlockedCondition returns the Namespace condition reporting that the Quay resources of a terminating namespace cannot be deleted
+func GetFinalizationBlockedCondition(reason string, message string) corev1.NamespaceCondition {
+
+	return corev1.NamespaceCondition{
+		Type:    corev1.NamespaceConditionType(constants.FinalizationBlockedConditionType),
+		Status:  corev1.ConditionTrue,
+		Reason:  reason,
+		Message: fmt.Sprintf("%s. Set the %s annotation to remove the finalizer and leave the Quay resources in place", message, constants.ForceFinalizeAnnotation),
+	}
+}
+
+// GetOrganizationOwnership determines whether a cluster may manage an existing organization from the metadata of its ownership robot account
+func GetOrganizationOwnership(ownershipRobotAccount *qclient.RobotAccount, clusterID string, previouslyManaged bool, adopt bool) qotypes.OrganizationOwnership {
+
+	ownership := qotypes.OrganizationOwnership{}
+
+	if ownershipRobotAccount != nil {
+		ownership.Owner, _ = ownershipRobotAccount.UnstructuredMetadata[constants.OwnershipMetadataClusterIDKey].(string)
+		ownership.Origin, _ = ownershipRobotAccount.UnstructuredMetadata[constants.OwnershipMetadataOriginKey].(string)
+
+		if ownership.Owner == clusterID {
+			ownership.Owned = true
+			return ownership
+		}
+	}
+
+	switch {
+	case adopt:
+		ownership.Origin = constants.OwnershipOriginAdopted
+	case ownershipRobotAccount == nil && previouslyManaged:
+		ownership.Origin = constants.OwnershipOriginCreated
+	default:
+		return ownership
+	}
+
+	ownership.Owned = true
+	ownership.Claim = true
+
+	return ownership
+}
+
+// GetOwnershipRobotAccountRequest returns the ownership robot account recording the cluster owning an organization
+func GetOwnershipRobotAccountRequest(clusterID string, namespace string, origin string) qclient.RobotAccountRequest {
+	return qclient.RobotAccountRequest{
+		Description: fmt.Sprintf("Records that this organization is managed by the quay-bridge-operator of cluster %s for namespace %s. Do not delete", clusterID, namespace),
+		UnstructuredMetadata: map[string]string{
+			constants.OwnershipMetadataManagedByKey: constants.ManagedByLabelValue,
+			constants.OwnershipMetadataClusterIDKey: clusterID,
+			constants.OwnershipMetadataNamespaceKey: namespace,
+			constants.OwnershipMetadataOriginKey:    origin,
+		},
+	}
+}
+
+// GetOwnershipCondition returns the Namespace condition reporting whether the organization of a namespace is owned by others
+func GetOwnershipCondition(quayOrganizationName string, ownership qotypes.OrganizationOwnership, conflictingRepositories []string) corev1.NamespaceCondition {
+
+	condition := corev1.NamespaceCondition{
+		Type:    corev1.NamespaceConditionType(constants.OwnershipConflictConditionType),
+		Status:  corev1.ConditionFalse,
+		Reason:  "Owned",
+		Message: fmt.Sprintf("Quay organization %s is managed by the operator", quayOrganizationName),
+	}
+
+	if ownership.Origin == constants.OwnershipOriginShared {
+		condition.Reason = "Shared"
+		condition.Message = fmt.Sprintf("Quay organization %s is shared and only the repositories and robot accounts of the namespace are managed by the operator", quayOrganizationName)
+	}
+
+	switch {
+	case !ownership.Owned && ownership.Owner != "":
+		condition.Status = corev1.ConditionTrue
+		condition.Reason = "OrganizationConflict"
+		condition.Message = fmt.Sprintf("Quay organization %s is managed by cluster %s. Set the %s annotation to adopt it", quayOrganizationName, ownership.Owner, constants.AdoptAnnotation)
+	case !ownership.Owned:
+		condition.Status = corev1.ConditionTrue
+		condition.Reason = "OrganizationConflict"
+		condition.Message = fmt.Sprintf("Quay organization %s was not created by the operator. Set the %s annotation to adopt it", quayOrganizationName, constants.AdoptAnnotation)
+	case len(conflictingRepositories) > 0:
+		condition.Status = corev1.ConditionTrue
+		condition.Reason = "RepositoryConflict"
+		condition.Message = fmt.Sprintf("Quay repositories %s of organization %s were not created by the operator. Set the %s annotation on their ImageStreams to adopt them", strings.Join(conflictingRepositories, ", "), quayOrganizationName, constants.AdoptAnnotation)
+	}
+
+	return condition
+}
+
+// GetClusterClaim determines whether a cluster holds the claim of its ClusterID using the metadata of the claim robot account. Claims that do not exist or that were not renewed within the lease duration are acquired, while the live claim of another cluster is only taken over when its holder matches the takeover annotation
+func GetClusterClaim(claimRobotAccount *qclient.RobotAccount, clusterUID string, takeoverUID string, now time.Time) qotypes.ClusterClaim {
+
+	claim := qotypes.ClusterClaim{}
+
+	if claimRobotAccount == nil {
+		claim.Held = true
+		claim.Renew = true
+		return claim
+	}
+
+	claim.HolderUID, _ = claimRobotAccount.UnstructuredMetadata[constants.ClusterClaimMetadataClusterUIDKey].(string)
+
+	// Claims with an invalid renew time are considered expired
+	renewTime, _ := claimRobotAccount.UnstructuredMetadata[constants.ClusterClaimMetadataRenewTimeKey].(string)
+	claim.RenewTime, _ = time.Parse(time.RFC3339, renewTime)
+
+	switch {
+	case claim.HolderUID == clusterUID:
+		claim.Held = true
+		claim.Renew = now.Sub(claim.RenewTime) >= constants.ClusterClaimRenewPeriod
+	case IsClusterClaimExpired(claim.RenewTime, now):
+		claim.Held = true
+		claim.Renew = true
+		claim.Expired = true
+	case takeoverUID != "" && takeoverUID == claim.HolderUID:
+		claim.Held = true
+		claim.Renew = true
+		claim.Takeover = true
+	}
+
+	return claim
+}
+
+// IsClusterClaimExpired determines whether a claim renewed at the given time has outlived its lease
+func IsClusterClaimExpired(renewTime time.Time, now time.Time) bool {
+	return now.Sub(renewTime) > constants.ClusterClaimLeaseDuration
+}
+
+// GetClusterClaimRobotAccountRequest returns the claim robot account recording that a cluster holds the claim of its ClusterID
+func GetClusterClaimRobotAccountRequest(clusterID string, clusterUID string, renewTime time.Time) qclient.RobotAccountRequest {
+	return qclient.RobotAccountRequest{
+		Description: fmt.Sprintf("Records that ClusterID %s is claimed by the quay-bridge-operator of cluster %s. Do not delete", clusterID, clusterUID),
+		UnstructuredMetadata: map[string]string{
+			constants.OwnershipMetadataManagedByKey:     constants.ManagedByLabelValue,
+			constants.OwnershipMetadataClusterIDKey:     clusterID,
+			constants.ClusterClaimMetadataClusterUIDKey: clusterUID,
+			constants.ClusterClaimMetadataRenewTimeKey:  renewTime.UTC().Format(time.RFC3339),
+		},
+	}
+}
+
+// GetClusterClaimCondition returns the QuayIntegration condition reporting whether the cluster holds the claim of its ClusterID
+func GetClusterClaimCondition(clusterID string, claim qotypes.ClusterClaim) metav1.Condition {
+
+	condition := metav1.Condition{
+		Type:    constants.ClusterClaimConditionType,
+		Status:  metav1.ConditionTrue,
+		Reason:  "Claimed",
+		Message: fmt.Sprintf("ClusterID %s is claimed by this cluster", clusterID),
+	}
+
+	switch {
+	case !claim.Held:
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = "ClaimedByAnotherCluster"
+		condition.Message = fmt.Sprintf("ClusterID %s is claimed by cluster %s, renewed at %s. Set the %s annotation to %s to take over the claim", clusterID, claim.HolderUID, claim.RenewTime.UTC().Format(time.RFC3339), constants.ClusterClaimTakeoverAnnotation, claim.HolderUID)
+	case claim.Takeover:
+		condition.Reason = "TakenOver"
+		condition.Message = fmt.Sprintf("ClusterID %s was taken over from cluster %s", clusterID, claim.HolderUID)
+	case claim.Expired:
+		condition.Reason = "ClaimExpired"
+		condition.Message = fmt.Sprintf("ClusterID %s was claimed after the claim of cluster %s expired", clusterID, claim.HolderUID)
+	}
+
+	return condition
+}
+
+// GetClusterClaimFailedCondition returns the QuayIntegration condition reporting that the claim of the ClusterID could not be synchronized
+func GetClusterClaimFailedCondition(clusterID string, err error) metav1.Condition {
+	return metav1.Condition{
+		Type:    constants.ClusterClaimConditionType,
+		Status:  metav1.ConditionFalse,
+		Reason:  "ClaimFailed",
+		Message: fmt.Sprintf("Failed to claim ClusterID %s: %v", clusterID, err),
+	}
+}
@@ -1,7 +1,20 @@
 package utils
 
 import (
+	"reflect"
 	"testing"
+	"time"
+
+	imagev1 "github.com/openshift/api/image/v1"
+	quayv1 "github.com/quay/quay-bridge-operator/api/v1"
+	qclient "github.com/quay/quay-bridge-operator/pkg/client/quay"
+	"github.com/quay/quay-bridge-operator/pkg/constants"
+	"github.com/quay/quay-bridge-operator/pkg/credentials"
+	qotypes "github.com/quay/quay-bridge-operator/pkg/types"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 func TestRobotAccountName(t *testing.T) {
@@ -32,3 +45,1773 @@ func TestRobotAccountName(t *testing.T) {
 		})
 	}
 }
+
+func TestImportBackoff(t *testing.T) {
+
+	cases := []struct {
+		name     string
+		attempts int
+		expected time.Duration
+	}{
+		{
+			name:     "test-first-attempt",
+			attempts: 1,
+			expected: 5 * time.Second,
+		},
+		{
+			name:     "test-third-attempt",
+			attempts: 3,
+			expected: 20 * time.Second,
+		},
+		{
+			name:     "test-capped-attempt",
+			attempts: 20,
+			expected: time.Minute,
+		},
+	}
+
+	for i, c := range cases {
+
+		t.Run(c.name, func(t *testing.T) {
+
+			result := ImportBackoff(c.attempts, 5*time.Second, time.Minute)
+
+			if c.expected != result {
+				t.Errorf("Test case %d did not match\nExpected: %#v\nActual: %#v", i, c.expected, result)
+			}
+		})
+	}
+}
+
+func TestParseImageStreamTagDestinations(t *testing.T) {
+
+	cases := []struct {
+		name        string
+		value       string
+		expected    []qotypes.ImageStreamTagDestination
+		expectedErr bool
+	}{
+		{
+			name:  "test-single-destination",
+			value: "myproject/app:latest",
+			expected: []qotypes.ImageStreamTagDestination{
+				{Namespace: "myproject", Name: "app", Tag: "latest"},
+			},
+		},
+		{
+			name:  "test-multiple-destinations",
+			value: "myproject/app:latest,myproject/app:v1.0",
+			expected: []qotypes.ImageStreamTagDestination{
+				{Namespace: "myproject", Name: "app", Tag: "latest"},
+				{Namespace: "myproject", Name: "app", Tag: "v1.0"},
+			},
+		},
+		{
+			name:        "test-missing-tag",
+			value:       "myproject/app",
+			expectedErr: true,
+		},
+		{
+			name:        "test-missing-namespace",
+			value:       "app:latest",
+			expectedErr: true,
+		},
+	}
+
+	for i, c := range cases {
+
+		t.Run(c.name, func(t *testing.T) {
+
+			result, err := ParseImageStreamTagDestinations(c.value)
+
+			if c.expectedErr != (err != nil) {
+				t.Errorf("Test case %d did not match\nExpected Error: %#v\nActual: %#v", i, c.expectedErr, err)
+			}
+
+			if !c.expectedErr && !reflect.DeepEqual(c.expected, result) {
+				t.Errorf("Test case %d did not match\nExpected: %#v\nActual: %#v", i, c.expected, result)
+			}
+
+			if !c.expectedErr && FormatImageStreamTagDestinations(result) != c.value {
+				t.Errorf("Test case %d did not round trip\nExpected: %#v\nActual: %#v", i, c.value, FormatImageStreamTagDestinations(result))
+			}
+		})
+	}
+}
+
+func TestParseImageTags(t *testing.T) {
+
+	cases := []struct {
+		name        string
+		value       string
+		expected    []string
+		expectedErr bool
+	}{
+		{
+			name:     "test-tags",
+			value:    "v1.0, stable",
+			expected: []string{"v1.0", "stable"},
+		},
+		{
+			name:     "test-empty",
+			value:    "",
+			expected: []string{},
+		},
+		{
+			name:        "test-invalid-tag",
+			value:       "v1.0,-bad",
+			expectedErr: true,
+		},
+	}
+
+	for i, c := range cases {
+
+		t.Run(c.name, func(t *testing.T) {
+
+			result, err := ParseImageTags(c.value)
+
+			if c.expectedErr != (err != nil) {
+				t.Errorf("Test case %d did not match\nExpected Error: %#v\nActual: %#v", i, c.expectedErr, err)
+			}
+
+			if !c.expectedErr && !reflect.DeepEqual(c.expected, result) {
+				t.Errorf("Test case %d did not match\nExpected: %#v\nActual: %#v", i, c.expected, result)
+			}
+		})
+	}
+}
+
+func TestTrimImageReference(t *testing.T) {
+
+	cases := []struct {
+		name     string
+		image    string
+		expected string
+	}{
+		{
+			name:     "test-tag",
+			image:    "quay.example.com/org/app:latest",
+			expected: "quay.example.com/org/app",
+		},
+		{
+			name:     "test-digest",
+			image:    "quay.example.com/org/app@sha256:abc",
+			expected: "quay.example.com/org/app",
+		},
+		{
+			name:     "test-port-without-tag",
+			image:    "quay.example.com:8443/org/app",
+			expected: "quay.example.com:8443/org/app",
+		},
+	}
+
+	for i, c := range cases {
+
+		t.Run(c.name, func(t *testing.T) {
+
+			result := TrimImageReference(c.image)
+
+			if c.expected != result {
+				t.Errorf("Test case %d did not match\nExpected: %#v\nActual: %#v", i, c.expected, result)
+			}
+		})
+	}
+}
+
+func TestGetImagePusherNamespaces(t *testing.T) {
+
+	cases := []struct {
+		name        string
+		roleBinding rbacv1.RoleBinding
+		expected    []string
+	}{
+		{
+			name: "test-image-pusher-subjects",
+			roleBinding: rbacv1.RoleBinding{
+				RoleRef: rbacv1.RoleRef{Kind: "ClusterRole", Name: "system:image-pusher"},
+				Subjects: []rbacv1.Subject{
+					{Kind: "ServiceAccount", Name: "builder", Namespace: "source1"},
+					{Kind: "ServiceAccount", Name: "default", Namespace: "ignored"},
+					{Kind: "Group", Name: "system:serviceaccounts:source2"},
+					{Kind: "User", Name: "system:serviceaccount:source3:builder"},
+				},
+			},
+			expected: []string{"source1", "source2", "source3"},
+		},
+		{
+			name: "test-other-role",
+			roleBinding: rbacv1.RoleBinding{
+				RoleRef: rbacv1.RoleRef{Kind: "ClusterRole", Name: "view"},
+				Subjects: []rbacv1.Subject{
+					{Kind: "ServiceAccount", Name: "builder", Namespace: "source1"},
+				},
+			},
+			expected: []string{},
+		},
+	}
+
+	for i, c := range cases {
+
+		t.Run(c.name, func(t *testing.T) {
+
+			result := GetImagePusherNamespaces(&c.roleBinding)
+
+			if !reflect.DeepEqual(c.expected, result) {
+				t.Errorf("Test case %d did not match\nExpected: %#v\nActual: %#v", i, c.expected, result)
+			}
+		})
+	}
+}
+
+func TestGetRepositorySettings(t *testing.T) {
+
+	quayIntegration := &quayv1.QuayIntegration{
+		Spec: quayv1.QuayIntegrationSpec{
+			RepositoryDefaults: &quayv1.RepositoryDefaults{
+				Description: "ImageStream {{.Namespace}}/{{.Name}}",
+			},
+		},
+	}
+
+	cases := []struct {
+		name                   string
+		namespaceAnnotations   map[string]string
+		imageStreamAnnotations map[string]string
+		expected               qotypes.RepositorySettings
+		expectedErr            bool
+	}{
+		{
+			name: "test-integration-defaults",
+			expected: qotypes.RepositorySettings{
+				Visibility:  "private",
+				Description: "ImageStream project/app",
+				Kind:        "image",
+			},
+		},
+		{
+			name:                 "test-namespace-override",
+			namespaceAnnotations: map[string]string{constants.RepositoryVisibilityAnnotation: "public"},
+			expected: qotypes.RepositorySettings{
+				Visibility:  "public",
+				Description: "ImageStream project/app",
+				Kind:        "image",
+			},
+		},
+		{
+			name:                   "test-imagestream-override",
+			namespaceAnnotations:   map[string]string{constants.RepositoryVisibilityAnnotation: "public"},
+			imageStreamAnnotations: map[string]string{constants.RepositoryVisibilityAnnotation: "private", constants.RepositoryDescriptionAnnotation: "Application {{.Name}}"},
+			expected: qotypes.RepositorySettings{
+				Visibility:  "private",
+				Description: "Application app",
+				Kind:        "image",
+			},
+		},
+		{
+			name:                   "test-invalid-kind",
+			imageStreamAnnotations: map[string]string{constants.RepositoryKindAnnotation: "helm"},
+			expectedErr:            true,
+		},
+	}
+
+	for i, c := range cases {
+
+		t.Run(c.name, func(t *testing.T) {
+
+			namespace := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "project", Annotations: c.namespaceAnnotations}}
+			imageStream := &imagev1.ImageStream{ObjectMeta: metav1.ObjectMeta{Namespace: "project", Name: "app", Annotations: c.imageStreamAnnotations}}
+
+			result, err := GetRepositorySettings(quayIntegration, namespace, imageStream)
+
+			if c.expectedErr != (err != nil) {
+				t.Errorf("Test case %d did not match\nExpected Error: %#v\nActual: %#v", i, c.expectedErr, err)
+			}
+
+			if !c.expectedErr && !reflect.DeepEqual(c.expected, result) {
+				t.Errorf("Test case %d did not match\nExpected: %#v\nActual: %#v", i, c.expected, result)
+			}
+		})
+	}
+}
+
+func TestParseQuayDuration(t *testing.T) {
+
+	cases := []struct {
+		duration    string
+		expected    time.Duration
+		expectedErr bool
+	}{
+		{duration: "30s", expected: time.Second * 30},
+		{duration: "12h", expected: time.Hour * 12},
+		{duration: "30d", expected: time.Hour * 24 * 30},
+		{duration: "2w", expected: time.Hour * 24 * 14},
+		{duration: "2y", expectedErr: true},
+		{duration: "", expectedErr: true},
+	}
+
+	for i, c := range cases {
+		result, err := ParseQuayDuration(c.duration)
+
+		if c.expectedErr != (err != nil) {
+			t.Errorf("Test case %d did not match\nExpected Error: %#v\nActual: %#v", i, c.expectedErr, err)
+		}
+
+		if c.expected != result {
+			t.Errorf("Test case %d did not match\nExpected: %#v\nActual: %#v", i, c.expected, result)
+		}
+	}
+}
+
+func TestGetTagRetentionSettings(t *testing.T) {
+
+	quayIntegration := &quayv1.QuayIntegration{
+		Spec: quayv1.QuayIntegrationSpec{
+			TagRetention: &quayv1.TagRetentionPolicy{
+				KeepLastTags: 20,
+			},
+		},
+	}
+
+	cases := []struct {
+		name                   string
+		namespaceAnnotations   map[string]string
+		imageStreamAnnotations map[string]string
+		expected               qotypes.TagRetentionSettings
+		expectedErr            bool
+	}{
+		{
+			name:     "test-integration-defaults",
+			expected: qotypes.TagRetentionSettings{KeepLastTags: 20},
+		},
+		{
+			name:                 "test-namespace-override",
+			namespaceAnnotations: map[string]string{constants.TagRetentionMaxTagAgeAnnotation: "30d"},
+			expected:             qotypes.TagRetentionSettings{MaxTagAge: "30d"},
+		},
+		{
+			name:                   "test-imagestream-override",
+			namespaceAnnotations:   map[string]string{constants.TagRetentionMaxTagAgeAnnotation: "30d"},
+			imageStreamAnnotations: map[string]string{constants.TagRetentionKeepLastTagsAnnotation: "5"},
+			expected:               qotypes.TagRetentionSettings{KeepLastTags: 5},
+		},
+		{
+			name:                   "test-imagestream-disabled",
+			imageStreamAnnotations: map[string]string{constants.TagRetentionKeepLastTagsAnnotation: "0"},
+			expected:               qotypes.TagRetentionSettings{},
+		},
+		{
+			name:                 "test-mutually-exclusive",
+			namespaceAnnotations: map[string]string{constants.TagRetentionKeepLastTagsAnnotation: "5", constants.TagRetentionMaxTagAgeAnnotation: "30d"},
+			expectedErr:          true,
+		},
+		{
+			name:                   "test-invalid-max-tag-age",
+			imageStreamAnnotations: map[string]string{constants.TagRetentionMaxTagAgeAnnotation: "thirty days"},
+			expectedErr:            true,
+		},
+	}
+
+	for i, c := range cases {
+
+		t.Run(c.name, func(t *testing.T) {
+
+			namespace := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "project", Annotations: c.namespaceAnnotations}}
+			imageStream := &imagev1.ImageStream{ObjectMeta: metav1.ObjectMeta{Namespace: "project", Name: "app", Annotations: c.imageStreamAnnotations}}
+
+			result, err := GetTagRetentionSettings(quayIntegration, namespace, imageStream)
+
+			if c.expectedErr != (err != nil) {
+				t.Errorf("Test case %d did not match\nExpected Error: %#v\nActual: %#v", i, c.expectedErr, err)
+			}
+
+			if !c.expectedErr && !reflect.DeepEqual(c.expected, result) {
+				t.Errorf("Test case %d did not match\nExpected: %#v\nActual: %#v", i, c.expected, result)
+			}
+		})
+	}
+}
+
+func TestGetUntaggedExpiration(t *testing.T) {
+
+	cases := []struct {
+		name                 string
+		tagRetention         *quayv1.TagRetentionPolicy
+		namespaceAnnotations map[string]string
+		expected             int
+		expectedFound        bool
+		expectedErr          bool
+	}{
+		{
+			name: "test-unset",
+		},
+		{
+			name:          "test-integration-default",
+			tagRetention:  &quayv1.TagRetentionPolicy{UntaggedExpiration: "2w"},
+			expected:      1209600,
+			expectedFound: true,
+		},
+		{
+			name:                 "test-namespace-override",
+			tagRetention:         &quayv1.TagRetentionPolicy{UntaggedExpiration: "2w"},
+			namespaceAnnotations: map[string]string{constants.UntaggedExpirationAnnotation: "1d"},
+			expected:             86400,
+			expectedFound:        true,
+		},
+		{
+			name:                 "test-invalid",
+			namespaceAnnotations: map[string]string{constants.UntaggedExpirationAnnotation: "forever"},
+			expectedErr:          true,
+		},
+	}
+
+	for i, c := range cases {
+
+		t.Run(c.name, func(t *testing.T) {
+
+			quayIntegration := &quayv1.QuayIntegration{Spec: quayv1.QuayIntegrationSpec{TagRetention: c.tagRetention}}
+			namespace := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "project", Annotations: c.namespaceAnnotations}}
+
+			result, found, err := GetUntaggedExpiration(quayIntegration, namespace)
+
+			if c.expectedErr != (err != nil) {
+				t.Errorf("Test case %d did not match\nExpected Error: %#v\nActual: %#v", i, c.expectedErr, err)
+			}
+
+			if c.expected != result || c.expectedFound != found {
+				t.Errorf("Test case %d did not match\nExpected: %#v\nActual: %#v", i, c.expected, result)
+			}
+		})
+	}
+}
+
+func TestGetRepositoryName(t *testing.T) {
+
+	cases := []struct {
+		name                   string
+		target                 qotypes.OrganizationTarget
+		imageStreamName        string
+		imageStreamLabels      map[string]string
+		imageStreamAnnotations map[string]string
+		expected               string
+		expectedErr            bool
+	}{
+		{
+			name:            "test-default",
+			imageStreamName: "app",
+			expected:        "app",
+		},
+		{
+			name:            "test-shared-prefix",
+			target:          qotypes.OrganizationTarget{Shared: true, RepositoryPrefix: "project_"},
+			imageStreamName: "app",
+			expected:        "project_app",
+		},
+		{
+			name:              "test-nested-label",
+			target:            qotypes.OrganizationTarget{RepositoryPathTemplate: "{{.Labels.app}}/{{.Name}}"},
+			imageStreamName:   "api",
+			imageStreamLabels: map[string]string{"app": "payments"},
+			expected:          "payments/api",
+		},
+		{
+			name:                   "test-nested-annotation-shared-prefix",
+			target:                 qotypes.OrganizationTarget{Shared: true, RepositoryPrefix: "project_", RepositoryPathTemplate: `{{index .Annotations "example.com/group" | lower}}/{{.Name}}`},
+			imageStreamName:        "api",
+			imageStreamAnnotations: map[string]string{"example.com/group": "Payments"},
+			expected:               "project_payments/api",
+		},
+		{
+			name:            "test-missing-label",
+			target:          qotypes.OrganizationTarget{RepositoryPathTemplate: "{{.Labels.app}}/{{.Name}}"},
+			imageStreamName: "api",
+			expectedErr:     true,
+		},
+		{
+			name:              "test-invalid-characters",
+			target:            qotypes.OrganizationTarget{RepositoryPathTemplate: "{{.Labels.app}}/{{.Name}}"},
+			imageStreamName:   "api",
+			imageStreamLabels: map[string]string{"app": "Payments"},
+			expectedErr:       true,
+		},
+		{
+			name:            "test-single-character-nested",
+			target:          qotypes.OrganizationTarget{RepositoryPathTemplate: "a/{{.Name}}"},
+			imageStreamName: "api",
+			expected:        "a/api",
+		},
+		{
+			name:            "test-consecutive-separators",
+			target:          qotypes.OrganizationTarget{RepositoryPathTemplate: "team//{{.Name}}"},
+			imageStreamName: "api",
+			expectedErr:     true,
+		},
+	}
+
+	for i, c := range cases {
+
+		t.Run(c.name, func(t *testing.T) {
+
+			imageStream := &imagev1.ImageStream{ObjectMeta: metav1.ObjectMeta{Name: c.imageStreamName, Namespace: "project", Labels: c.imageStreamLabels, Annotations: c.imageStreamAnnotations}}
+
+			result, err := GetRepositoryName(c.target, imageStream)
+
+			if c.expectedErr != (err != nil) {
+				t.Errorf("Test case %d did not match\nExpected Error: %#v\nActual: %#v", i, c.expectedErr, err)
+			}
+
+			if !c.expectedErr && c.expected != result {
+				t.Errorf("Test case %d did not match\nExpected: %#v\nActual: %#v", i, c.expected, result)
+			}
+		})
+	}
+}
+
+func TestGetOrganizationContactSettings(t *testing.T) {
+
+	cases := []struct {
+		name                 string
+		organizationContact  *quayv1.OrganizationContact
+		namespaceAnnotations map[string]string
+		expected             qotypes.OrganizationContactSettings
+		expectedErr          bool
+	}{
+		{
+			name: "test-unset",
+		},
+		{
+			name:                "test-integration-templates",
+			organizationContact: &quayv1.OrganizationContact{EmailTemplate: "{{.Namespace}}@quay.example.com", InvoiceEmailTemplate: "billing+{{.Organization}}@quay.example.com"},
+			expected:            qotypes.OrganizationContactSettings{Email: "project@quay.example.com", InvoiceEmailAddress: "billing+openshift_project@quay.example.com"},
+		},
+		{
+			name:                 "test-namespace-override",
+			organizationContact:  &quayv1.OrganizationContact{EmailTemplate: "{{.Namespace}}@quay.example.com"},
+			namespaceAnnotations: map[string]string{constants.OrganizationEmailAnnotation: "team@example.com", constants.OrganizationInvoiceEmailAnnotation: "finance@example.com"},
+			expected:             qotypes.OrganizationContactSettings{Email: "team@example.com", InvoiceEmailAddress: "finance@example.com"},
+		},
+		{
+			name:                "test-invalid-template",
+			organizationContact: &quayv1.OrganizationContact{EmailTemplate: "{{.Namespace"},
+			expectedErr:         true,
+		},
+		{
+			name:                 "test-invalid-email",
+			namespaceAnnotations: map[string]string{constants.OrganizationEmailAnnotation: "not an email"},
+			expectedErr:          true,
+		},
+		{
+			name:                 "test-display-name",
+			namespaceAnnotations: map[string]string{constants.OrganizationEmailAnnotation: "Team <team@example.com>"},
+			expectedErr:          true,
+		},
+	}
+
+	for i, c := range cases {
+
+		t.Run(c.name, func(t *testing.T) {
+
+			quayIntegration := &quayv1.QuayIntegration{Spec: quayv1.QuayIntegrationSpec{ClusterID: "openshift", OrganizationContact: c.organizationContact}}
+			namespace := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "project", Annotations: c.namespaceAnnotations}}
+
+			result, err := GetOrganizationContactSettings(quayIntegration, namespace, "openshift_project")
+
+			if c.expectedErr != (err != nil) {
+				t.Errorf("Test case %d did not match\nExpected Error: %#v\nActual: %#v", i, c.expectedErr, err)
+			}
+
+			if !c.expectedErr && c.expected != result {
+				t.Errorf("Test case %d did not match\nExpected: %#v\nActual: %#v", i, c.expected, result)
+			}
+		})
+	}
+}
+
+func TestGetOrganizationTarget(t *testing.T) {
+
+	sharedOrganizations := []quayv1.SharedOrganization{
+		{Name: "platform", NamespaceSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"team": "platform"}}, AllowUnprefixedRepositories: true},
+		{Name: "restricted", NamespaceSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"team": "platform"}}},
+		{Name: "unselected"},
+	}
+
+	cases := []struct {
+		name                 string
+		namespaceLabels      map[string]string
+		namespaceAnnotations map[string]string
+		expected             qotypes.OrganizationTarget
+		expectedErr          bool
+	}{
+		{
+			name:     "test-dedicated-organization",
+			expected: qotypes.OrganizationTarget{Organization: "openshift_my-project"},
+		},
+		{
+			name:                 "test-shared-organization",
+			namespaceLabels:      map[string]string{"team": "platform"},
+			namespaceAnnotations: map[string]string{constants.TargetOrganizationAnnotation: "platform"},
+			expected:             qotypes.OrganizationTarget{Organization: "platform", Shared: true, RepositoryPrefix: "my-project_", RobotAccountSuffix: "_my_project"},
+		},
+		{
+			name:                 "test-shared-organization-unprefixed",
+			namespaceLabels:      map[string]string{"team": "platform"},
+			namespaceAnnotations: map[string]string{constants.TargetOrganizationAnnotation: "platform", constants.RepositoryNamingAnnotation: constants.RepositoryNamingUnprefixed},
+			expected:             qotypes.OrganizationTarget{Organization: "platform", Shared: true, RobotAccountSuffix: "_my_project"},
+		},
+		{
+			name:                 "test-shared-organization-unprefixed-not-allowed",
+			namespaceLabels:      map[string]string{"team": "platform"},
+			namespaceAnnotations: map[string]string{constants.TargetOrganizationAnnotation: "restricted", constants.RepositoryNamingAnnotation: constants.RepositoryNamingUnprefixed},
+			expectedErr:          true,
+		},
+		{
+			name:                 "test-invalid-repository-naming",
+			namespaceLabels:      map[string]string{"team": "platform"},
+			namespaceAnnotations: map[string]string{constants.TargetOrganizationAnnotation: "platform", constants.RepositoryNamingAnnotation: "Nested"},
+			expectedErr:          true,
+		},
+		{
+			name:                 "test-namespace-not-selected",
+			namespaceLabels:      map[string]string{"team": "web"},
+			namespaceAnnotations: map[string]string{constants.TargetOrganizationAnnotation: "platform"},
+			expectedErr:          true,
+		},
+		{
+			name:                 "test-missing-namespace-selector",
+			namespaceAnnotations: map[string]string{constants.TargetOrganizationAnnotation: "unselected"},
+			expectedErr:          true,
+		},
+		{
+			name:                 "test-repository-path-template-annotation",
+			namespaceAnnotations: map[string]string{constants.RepositoryPathTemplateAnnotation: "{{.Labels.app}}/{{.Name}}"},
+			expected:             qotypes.OrganizationTarget{Organization: "openshift_my-project", RepositoryPathTemplate: "{{.Labels.app}}/{{.Name}}"},
+		},
+		{
+			name:                 "test-invalid-repository-path-template",
+			namespaceAnnotations: map[string]string{constants.RepositoryPathTemplateAnnotation: "{{.Name"},
+			expectedErr:          true,
+		},
+		{
+			name:                 "test-unknown-organization",
+			namespaceAnnotations: map[string]string{constants.TargetOrganizationAnnotation: "openshift_other-project"},
+			expectedErr:          true,
+		},
+	}
+
+	for i, c := range cases {
+
+		t.Run(c.name, func(t *testing.T) {
+
+			quayIntegration := &quayv1.QuayIntegration{Spec: quayv1.QuayIntegrationSpec{ClusterID: "openshift", SharedOrganizations: sharedOrganizations}}
+			namespace := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "my-project", Labels: c.namespaceLabels, Annotations: c.namespaceAnnotations}}
+
+			result, err := GetOrganizationTarget(quayIntegration, namespace)
+
+			if c.expectedErr != (err != nil) {
+				t.Errorf("Test case %d did not match\nExpected Error: %#v\nActual: %#v", i, c.expectedErr, err)
+			}
+
+			if !c.expectedErr && c.expected != result {
+				t.Errorf("Test case %d did not match\nExpected: %#v\nActual: %#v", i, c.expected, result)
+			}
+		})
+	}
+}
+
+func TestGetOrganizationQuotaSettings(t *testing.T) {
+
+	integrationLimit := resource.MustParse("10Gi")
+
+	cases := []struct {
+		name                 string
+		organizationQuota    *quayv1.OrganizationQuota
+		namespaceAnnotations map[string]string
+		resourceQuotas       []corev1.ResourceQuota
+		expected             qotypes.OrganizationQuotaSettings
+		expectedFound        bool
+		expectedErr          bool
+	}{
+		{
+			name:     "test-unset",
+			expected: qotypes.OrganizationQuotaSettings{RejectThresholdPercent: 100},
+		},
+		{
+			name:              "test-integration-default",
+			organizationQuota: &quayv1.OrganizationQuota{Limit: &integrationLimit, WarningThresholdPercent: 80},
+			expected:          qotypes.OrganizationQuotaSettings{LimitBytes: 10737418240, WarningThresholdPercent: 80, RejectThresholdPercent: 100},
+			expectedFound:     true,
+		},
+		{
+			name:              "test-resource-quota",
+			organizationQuota: &quayv1.OrganizationQuota{Limit: &integrationLimit, WarningThresholdPercent: 80, RejectThresholdPercent: 95},
+			resourceQuotas: []corev1.ResourceQuota{
+				{Spec: corev1.ResourceQuotaSpec{Hard: corev1.ResourceList{corev1.ResourceName(constants.OrganizationStorageResourceName): resource.MustParse("20Gi")}}},
+				{Spec: corev1.ResourceQuotaSpec{Hard: corev1.ResourceList{corev1.ResourceName(constants.OrganizationStorageResourceName): resource.MustParse("15Gi")}}},
+				{Spec: corev1.ResourceQuotaSpec{Hard: corev1.ResourceList{corev1.ResourceRequestsStorage: resource.MustParse("1Gi")}}},
+			},
+			expected:      qotypes.OrganizationQuotaSettings{LimitBytes: 16106127360, WarningThresholdPercent: 80, RejectThresholdPercent: 95},
+			expectedFound: true,
+		},
+		{
+			name:                 "test-namespace-override",
+			organizationQuota:    &quayv1.OrganizationQuota{Limit: &integrationLimit},
+			namespaceAnnotations: map[string]string{constants.OrganizationQuotaAnnotation: "0"},
+			resourceQuotas: []corev1.ResourceQuota{
+				{Spec: corev1.ResourceQuotaSpec{Hard: corev1.ResourceList{corev1.ResourceName(constants.OrganizationStorageResourceName): resource.MustParse("20Gi")}}},
+			},
+			expected:      qotypes.OrganizationQuotaSettings{RejectThresholdPercent: 100},
+			expectedFound: true,
+		},
+		{
+			name:                 "test-invalid-annotation",
+			namespaceAnnotations: map[string]string{constants.OrganizationQuotaAnnotation: "lots"},
+			expectedErr:          true,
+		},
+		{
+			name:              "test-invalid-thresholds",
+			organizationQuota: &quayv1.OrganizationQuota{Limit: &integrationLimit, WarningThresholdPercent: 90, RejectThresholdPercent: 80},
+			expectedErr:       true,
+		},
+	}
+
+	for i, c := range cases {
+
+		t.Run(c.name, func(t *testing.T) {
+
+			quayIntegration := &quayv1.QuayIntegration{Spec: quayv1.QuayIntegrationSpec{OrganizationQuota: c.organizationQuota}}
+			namespace := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "project", Annotations: c.namespaceAnnotations}}
+
+			result, found, err := GetOrganizationQuotaSettings(quayIntegration, namespace, c.resourceQuotas)
+
+			if c.expectedErr != (err != nil) {
+				t.Errorf("Test case %d did not match\nExpected Error: %#v\nActual: %#v", i, c.expectedErr, err)
+			}
+
+			if !c.expectedErr && (!reflect.DeepEqual(c.expected, result) || c.expectedFound != found) {
+				t.Errorf("Test case %d did not match\nExpected: %#v\nActual: %#v", i, c.expected, result)
+			}
+		})
+	}
+}
+
+func TestGetOrganizationQuotaCondition(t *testing.T) {
+
+	settings := qotypes.OrganizationQuotaSettings{LimitBytes: 1000, WarningThresholdPercent: 80, RejectThresholdPercent: 100}
+
+	cases := []struct {
+		settings       qotypes.OrganizationQuotaSettings
+		usageBytes     int64
+		expectedStatus corev1.ConditionStatus
+		expectedReason string
+	}{
+		{settings: qotypes.OrganizationQuotaSettings{RejectThresholdPercent: 100}, usageBytes: 1000, expectedStatus: corev1.ConditionFalse, expectedReason: "WithinQuota"},
+		{settings: settings, usageBytes: 500, expectedStatus: corev1.ConditionFalse, expectedReason: "WithinQuota"},
+		{settings: settings, usageBytes: 850, expectedStatus: corev1.ConditionTrue, expectedReason: "SoftLimitExceeded"},
+		{settings: settings, usageBytes: 1000, expectedStatus: corev1.ConditionTrue, expectedReason: "HardLimitExceeded"},
+	}
+
+	for i, c := range cases {
+		result := GetOrganizationQuotaCondition("openshift_project", c.settings, c.usageBytes)
+
+		if c.expectedStatus != result.Status || c.expectedReason != result.Reason {
+			t.Errorf("Test case %d did not match\nExpected: %#v %#v\nActual: %#v", i, c.expectedStatus, c.expectedReason, result)
+		}
+	}
+}
+
+func TestGetQuayUsername(t *testing.T) {
+
+	cases := []struct {
+		teamSync    quayv1.TeamSync
+		username    string
+		expected    string
+		expectedErr bool
+	}{
+		{
+			teamSync: quayv1.TeamSync{},
+			username: "alice",
+			expected: "alice",
+		},
+		{
+			teamSync: quayv1.TeamSync{UsernameTemplate: `{{.Username | trimSuffix "@example.com" | replace "." "_" | lower}}`},
+			username: "Alice.Smith@example.com",
+			expected: "alice_smith",
+		},
+		{
+			teamSync: quayv1.TeamSync{
+				UsernameTemplate: `{{.Username | lower}}`,
+				UsernameMappings: []quayv1.UsernameMapping{{OpenShiftUsername: "Bob", QuayUsername: "robert"}},
+			},
+			username: "Bob",
+			expected: "robert",
+		},
+		{
+			teamSync:    quayv1.TeamSync{UsernameTemplate: `{{.Username | unknown}}`},
+			username:    "alice",
+			expectedErr: true,
+		},
+	}
+
+	for i, c := range cases {
+		result, err := GetQuayUsername(&c.teamSync, c.username)
+
+		if c.expectedErr != (err != nil) {
+			t.Errorf("Test case %d did not match\nExpected Error: %#v\nActual: %#v", i, c.expectedErr, err)
+		}
+
+		if c.expected != result {
+			t.Errorf("Test case %d did not match\nExpected: %#v\nActual: %#v", i, c.expected, result)
+		}
+	}
+}
+
+func TestGetQuayTeamMembers(t *testing.T) {
+
+	teamSync := &quayv1.TeamSync{UsernameTemplate: `{{.Username | lower}}`}
+
+	roleBindings := []rbacv1.RoleBinding{
+		{
+			RoleRef: rbacv1.RoleRef{Kind: "ClusterRole", Name: "admin"},
+			Subjects: []rbacv1.Subject{
+				{Kind: rbacv1.UserKind, Name: "Alice"},
+				{Kind: rbacv1.UserKind, Name: "system:admin"},
+				{Kind: rbacv1.ServiceAccountKind, Name: "builder", Namespace: "project"},
+			},
+		},
+		{
+			RoleRef: rbacv1.RoleRef{Kind: "ClusterRole", Name: "edit"},
+			Subjects: []rbacv1.Subject{
+				{Kind: rbacv1.UserKind, Name: "carol"},
+				{Kind: rbacv1.UserKind, Name: "bob"},
+				{Kind: rbacv1.GroupKind, Name: "developers"},
+			},
+		},
+		{
+			RoleRef:  rbacv1.RoleRef{Kind: "ClusterRole", Name: "edit"},
+			Subjects: []rbacv1.Subject{{Kind: rbacv1.UserKind, Name: "Bob"}},
+		},
+		{
+			RoleRef:  rbacv1.RoleRef{Kind: "Role", Name: "view"},
+			Subjects: []rbacv1.Subject{{Kind: rbacv1.UserKind, Name: "dave"}},
+		},
+		{
+			RoleRef:  rbacv1.RoleRef{Kind: "ClusterRole", Name: constants.ImagePusherRole},
+			Subjects: []rbacv1.Subject{{Kind: rbacv1.UserKind, Name: "erin"}},
+		},
+	}
+
+	expected := map[qotypes.QuayTeam][]string{
+		qotypes.AdminsQuayTeam:  {"alice"},
+		qotypes.EditorsQuayTeam: {"bob", "carol"},
+		qotypes.ViewersQuayTeam: {},
+	}
+
+	result, err := GetQuayTeamMembers(teamSync, roleBindings)
+
+	if err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+
+	if !reflect.DeepEqual(expected, result) {
+		t.Errorf("Test case did not match\nExpected: %#v\nActual: %#v", expected, result)
+	}
+}
+
+func TestGetRepositoryNotifications(t *testing.T) {
+
+	cases := []struct {
+		name                   string
+		namespaceAnnotations   map[string]string
+		imageStreamAnnotations map[string]string
+		expected               []qotypes.RepositoryNotification
+		expectedErr            bool
+	}{
+		{
+			name:     "test-none",
+			expected: []qotypes.RepositoryNotification{},
+		},
+		{
+			name: "test-namespace-and-imagestream",
+			namespaceAnnotations: map[string]string{
+				constants.RepositoryNotificationsAnnotation: `[{"name": "pushes", "event": "repo_push", "method": "slack", "config": {"url": "https://hooks.slack.com/services/team"}}, {"name": "vulnerabilities", "event": "vulnerability_found", "method": "email", "config": {"email": "security@example.com"}, "eventConfig": {"level": 3}}]`,
+			},
+			imageStreamAnnotations: map[string]string{
+				constants.RepositoryNotificationsAnnotation: `[{"name": "pushes", "event": "repo_push", "method": "webhook", "config": {"url": "https://example.com/push"}}]`,
+			},
+			expected: []qotypes.RepositoryNotification{
+				{Name: "pushes", Event: "repo_push", Method: "webhook", Config: map[string]interface{}{"url": "https://example.com/push"}},
+				{Name: "vulnerabilities", Event: "vulnerability_found", Method: "email", Config: map[string]interface{}{"email": "security@example.com"}, EventConfig: map[string]interface{}{"level": float64(3)}},
+			},
+		},
+		{
+			name:                   "test-invalid-json",
+			imageStreamAnnotations: map[string]string{constants.RepositoryNotificationsAnnotation: `{"name": "pushes"}`},
+			expectedErr:            true,
+		},
+		{
+			name:                   "test-missing-method",
+			imageStreamAnnotations: map[string]string{constants.RepositoryNotificationsAnnotation: `[{"name": "pushes", "event": "repo_push"}]`},
+			expectedErr:            true,
+		},
+		{
+			name:                 "test-duplicate-name",
+			namespaceAnnotations: map[string]string{constants.RepositoryNotificationsAnnotation: `[{"name": "pushes", "event": "repo_push", "method": "email"}, {"name": "pushes", "event": "repo_push", "method": "slack"}]`},
+			expectedErr:          true,
+		},
+	}
+
+	for i, c := range cases {
+
+		t.Run(c.name, func(t *testing.T) {
+
+			namespace := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "project", Annotations: c.namespaceAnnotations}}
+			imageStream := &imagev1.ImageStream{ObjectMeta: metav1.ObjectMeta{Namespace: "project", Name: "app", Annotations: c.imageStreamAnnotations}}
+
+			result, err := GetRepositoryNotifications(namespace, imageStream)
+
+			if c.expectedErr != (err != nil) {
+				t.Errorf("Test case %d did not match\nExpected Error: %#v\nActual: %#v", i, c.expectedErr, err)
+			}
+
+			if !c.expectedErr && !reflect.DeepEqual(c.expected, result) {
+				t.Errorf("Test case %d did not match\nExpected: %#v\nActual: %#v", i, c.expected, result)
+			}
+		})
+	}
+}
+
+func TestSummarizeVulnerabilities(t *testing.T) {
+
+	cases := []struct {
+		security        qclient.ManifestSecurity
+		expected        qotypes.VulnerabilitySummary
+		expectedSummary string
+	}{
+		{
+			security:        qclient.ManifestSecurity{Status: qclient.SecurityScanStatusQueued},
+			expected:        qotypes.VulnerabilitySummary{Counts: map[string]int{}},
+			expectedSummary: "None",
+		},
+		{
+			security: qclient.ManifestSecurity{
+				Status: qclient.SecurityScanStatusScanned,
+				Data: &qclient.ManifestSecurityData{
+					Layer: qclient.SecurityLayer{
+						Features: []qclient.SecurityFeature{
+							{Name: "openssl", Vulnerabilities: []qclient.Vulnerability{{Name: "CVE-1", Severity: "High"}, {Name: "CVE-2", Severity: "Low"}}},
+							{Name: "bash", Vulnerabilities: []qclient.Vulnerability{{Name: "CVE-3", Severity: "Critical"}, {Name: "CVE-4", Severity: "High"}}},
+							{Name: "zlib", Vulnerabilities: []qclient.Vulnerability{{Name: "CVE-5", Severity: ""}}},
+						},
+					},
+				},
+			},
+			expected:        qotypes.VulnerabilitySummary{Counts: map[string]int{"Critical": 1, "High": 2, "Low": 1, "Unknown": 1}, Highest: "Critical"},
+			expectedSummary: "Critical=1,High=2,Low=1,Unknown=1",
+		},
+	}
+
+	for i, c := range cases {
+		result := SummarizeVulnerabilities(c.security)
+
+		if !reflect.DeepEqual(c.expected, result) {
+			t.Errorf("Test case %d did not match\nExpected: %#v\nActual: %#v", i, c.expected, result)
+		}
+
+		if summary := FormatVulnerabilitySummary(result); c.expectedSummary != summary {
+			t.Errorf("Test case %d did not match\nExpected: %#v\nActual: %#v", i, c.expectedSummary, summary)
+		}
+	}
+}
+
+func TestIsSeverityAtLeast(t *testing.T) {
+
+	cases := []struct {
+		severity  string
+		threshold string
+		expected  bool
+	}{
+		{severity: "Critical", threshold: "High", expected: true},
+		{severity: "High", threshold: "High", expected: true},
+		{severity: "Medium", threshold: "High", expected: false},
+		{severity: "", threshold: "Low", expected: false},
+	}
+
+	for i, c := range cases {
+		if result := IsSeverityAtLeast(c.severity, c.threshold); c.expected != result {
+			t.Errorf("Test case %d did not match\nExpected: %#v\nActual: %#v", i, c.expected, result)
+		}
+	}
+}
+
+func TestGetImageDigest(t *testing.T) {
+
+	cases := []struct {
+		image         string
+		expected      string
+		expectedFound bool
+	}{
+		{image: "quay.io/openshift_project/app@sha256:abc", expected: "sha256:abc", expectedFound: true},
+		{image: "image-registry.openshift-image-registry.svc:5000/project/app@sha256:abc", expected: "sha256:abc", expectedFound: true},
+		{image: "quay.io/openshift_project/app:latest"},
+		{image: "quay.io:443/openshift_project/app"},
+	}
+
+	for i, c := range cases {
+		result, found := GetImageDigest(c.image)
+
+		if c.expected != result || c.expectedFound != found {
+			t.Errorf("Test case %d did not match\nExpected: %#v\nActual: %#v", i, c.expected, result)
+		}
+	}
+}
+
+func TestGetImageTag(t *testing.T) {
+
+	cases := []struct {
+		image    string
+		expected string
+	}{
+		{image: "quay.io/openshift_project/app:v1", expected: "v1"},
+		{image: "quay.io:443/openshift_project/app", expected: "latest"},
+		{image: "image-registry.openshift-image-registry.svc:5000/project/app:v1", expected: "v1"},
+		{image: "app", expected: "latest"},
+	}
+
+	for i, c := range cases {
+		result := GetImageTag(c.image)
+
+		if c.expected != result {
+			t.Errorf("Test case %d did not match\nExpected: %#v\nActual: %#v", i, c.expected, result)
+		}
+	}
+}
+
+func TestGetImageStreamTagDigest(t *testing.T) {
+
+	imageStream := &imagev1.ImageStream{
+		ObjectMeta: metav1.ObjectMeta{Name: "app", Namespace: "project"},
+		Spec:       imagev1.ImageStreamSpec{LookupPolicy: imagev1.ImageLookupPolicy{Local: true}},
+		Status: imagev1.ImageStreamStatus{
+			DockerImageRepository: "image-registry.openshift-image-registry.svc:5000/project/app",
+			Tags: []imagev1.NamedTagEventList{
+				{Tag: "latest", Items: []imagev1.TagEvent{
+					{DockerImageReference: "quay.io/openshift_project/app@sha256:new", Image: "sha256:new"},
+					{DockerImageReference: "quay.io/openshift_project/app@sha256:old", Image: "sha256:old"},
+				}},
+				{Tag: "v1", Items: []imagev1.TagEvent{
+					{DockerImageReference: "quay.io/openshift_project/app@sha256:v1", Image: "sha256:v1"},
+				}},
+				{Tag: "pending"},
+			},
+		},
+	}
+
+	cases := []struct {
+		image         string
+		expected      string
+		expectedFound bool
+	}{
+		{image: "image-registry.openshift-image-registry.svc:5000/project/app:latest", expected: "sha256:new", expectedFound: true},
+		{image: "image-registry.openshift-image-registry.svc:5000/project/app", expected: "sha256:new", expectedFound: true},
+		{image: "quay.io/openshift_project/app:v1", expected: "sha256:v1", expectedFound: true},
+		{image: "app:v1", expected: "sha256:v1", expectedFound: true},
+		{image: "quay.io/openshift_project/app:pending"},
+		{image: "quay.io/openshift_project/app:missing"},
+		{image: "quay.io/openshift_project/other:latest"},
+		{image: "image-registry.openshift-image-registry.svc:5000/other/app:latest"},
+	}
+
+	for i, c := range cases {
+		result, found := GetImageStreamTagDigest(imageStream, c.image)
+
+		if c.expected != result || c.expectedFound != found {
+			t.Errorf("Test case %d did not match\nExpected: %#v\nActual: %#v", i, c.expected, result)
+		}
+	}
+}
+
+func TestGetInboundSyncSettings(t *testing.T) {
+
+	cases := []struct {
+		name                 string
+		inboundSync          *quayv1.InboundSync
+		namespaceAnnotations map[string]string
+		expectedEnabled      bool
+		expectedPollInterval time.Duration
+		expectedTagFilter    string
+		expectedCreate       bool
+		expectedErr          bool
+	}{
+		{
+			name:                 "test-unset",
+			expectedPollInterval: constants.DefaultInboundSyncPollInterval,
+		},
+		{
+			name:                 "test-integration-enabled",
+			inboundSync:          &quayv1.InboundSync{Enabled: true, PollInterval: &metav1.Duration{Duration: time.Minute}, TagFilter: "^v[0-9.]+$"},
+			expectedEnabled:      true,
+			expectedPollInterval: time.Minute,
+			expectedTagFilter:    "^v[0-9.]+$",
+		},
+		{
+			name:                 "test-namespace-opt-in",
+			namespaceAnnotations: map[string]string{constants.InboundSyncAnnotation: "true", constants.InboundSyncTagFilterAnnotation: "^release-"},
+			expectedEnabled:      true,
+			expectedPollInterval: constants.DefaultInboundSyncPollInterval,
+			expectedTagFilter:    "^release-",
+		},
+		{
+			name:                 "test-namespace-opt-out",
+			inboundSync:          &quayv1.InboundSync{Enabled: true},
+			namespaceAnnotations: map[string]string{constants.InboundSyncAnnotation: "false"},
+			expectedPollInterval: constants.DefaultInboundSyncPollInterval,
+		},
+		{
+			name:                 "test-integration-create-imagestreams",
+			inboundSync:          &quayv1.InboundSync{Enabled: true, CreateImageStreams: true},
+			expectedEnabled:      true,
+			expectedPollInterval: constants.DefaultInboundSyncPollInterval,
+			expectedCreate:       true,
+		},
+		{
+			name:                 "test-namespace-create-imagestreams-opt-out",
+			inboundSync:          &quayv1.InboundSync{Enabled: true, CreateImageStreams: true},
+			namespaceAnnotations: map[string]string{constants.InboundSyncCreateImageStreamsAnnotation: "false"},
+			expectedEnabled:      true,
+			expectedPollInterval: constants.DefaultInboundSyncPollInterval,
+		},
+		{
+			name:                 "test-invalid-annotation",
+			namespaceAnnotations: map[string]string{constants.InboundSyncAnnotation: "sometimes"},
+			expectedErr:          true,
+		},
+		{
+			name:                 "test-invalid-create-imagestreams-annotation",
+			namespaceAnnotations: map[string]string{constants.InboundSyncCreateImageStreamsAnnotation: "sometimes"},
+			expectedErr:          true,
+		},
+		{
+			name:                 "test-invalid-tag-filter",
+			namespaceAnnotations: map[string]string{constants.InboundSyncTagFilterAnnotation: "v[0-9"},
+			expectedErr:          true,
+		},
+	}
+
+	for i, c := range cases {
+
+		t.Run(c.name, func(t *testing.T) {
+
+			quayIntegration := &quayv1.QuayIntegration{Spec: quayv1.QuayIntegrationSpec{InboundSync: c.inboundSync}}
+			namespace := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "project", Annotations: c.namespaceAnnotations}}
+
+			result, err := GetInboundSyncSettings(quayIntegration, namespace)
+
+			if c.expectedErr != (err != nil) {
+				t.Errorf("Test case %d did not match\nExpected Error: %#v\nActual: %#v", i, c.expectedErr, err)
+			}
+
+			if c.expectedErr {
+				return
+			}
+
+			tagFilter := ""
+			if result.TagFilter != nil {
+				tagFilter = result.TagFilter.String()
+			}
+
+			if c.expectedEnabled != result.Enabled || c.expectedPollInterval != result.PollInterval || c.expectedTagFilter != tagFilter || c.expectedCreate != result.CreateImageStreams {
+				t.Errorf("Test case %d did not match\nExpected: %#v %#v %#v %#v\nActual: %#v", i, c.expectedEnabled, c.expectedPollInterval, c.expectedTagFilter, c.expectedCreate, result)
+			}
+		})
+	}
+}
+
+func TestGetRepositoryMirrorSettings(t *testing.T) {
+
+	cases := []struct {
+		name             string
+		annotations      map[string]string
+		expectedFound    bool
+		expectedSettings qotypes.RepositoryMirrorSettings
+		expectedErr      bool
+	}{
+		{
+			name: "test-not-mirrored",
+		},
+		{
+			name:          "test-defaults",
+			annotations:   map[string]string{constants.MirrorSourceAnnotation: "registry.access.redhat.com/ubi9/ubi"},
+			expectedFound: true,
+			expectedSettings: qotypes.RepositoryMirrorSettings{
+				ExternalReference: "registry.access.redhat.com/ubi9/ubi",
+				TagPatterns:       []string{"latest"},
+				SyncInterval:      constants.DefaultMirrorSyncInterval,
+				VerifyTLS:         true,
+			},
+		},
+		{
+			name: "test-all-settings",
+			annotations: map[string]string{
+				constants.MirrorSourceAnnotation:            "registry.example.com/base/image",
+				constants.MirrorTagsAnnotation:              "latest, 9.*",
+				constants.MirrorSyncIntervalAnnotation:      "6h",
+				constants.MirrorCredentialsSecretAnnotation: "upstream-credentials",
+				constants.MirrorVerifyTLSAnnotation:         "false",
+			},
+			expectedFound: true,
+			expectedSettings: qotypes.RepositoryMirrorSettings{
+				ExternalReference: "registry.example.com/base/image",
+				TagPatterns:       []string{"latest", "9.*"},
+				SyncInterval:      time.Hour * 6,
+				CredentialsSecret: "upstream-credentials",
+			},
+		},
+		{
+			name:          "test-no-tag-patterns",
+			annotations:   map[string]string{constants.MirrorSourceAnnotation: "registry.example.com/base/image", constants.MirrorTagsAnnotation: " , "},
+			expectedFound: true,
+			expectedErr:   true,
+		},
+		{
+			name:          "test-short-sync-interval",
+			annotations:   map[string]string{constants.MirrorSourceAnnotation: "registry.example.com/base/image", constants.MirrorSyncIntervalAnnotation: "30s"},
+			expectedFound: true,
+			expectedErr:   true,
+		},
+		{
+			name:          "test-invalid-verify-tls",
+			annotations:   map[string]string{constants.MirrorSourceAnnotation: "registry.example.com/base/image", constants.MirrorVerifyTLSAnnotation: "maybe"},
+			expectedFound: true,
+			expectedErr:   true,
+		},
+	}
+
+	for i, c := range cases {
+
+		t.Run(c.name, func(t *testing.T) {
+
+			imageStream := &metav1.ObjectMeta{Name: "image", Annotations: c.annotations}
+
+			result, found, err := GetRepositoryMirrorSettings(imageStream)
+
+			if c.expectedErr != (err != nil) || c.expectedFound != found {
+				t.Errorf("Test case %d did not match\nExpected Error: %#v Found: %#v\nActual Error: %#v Found: %#v", i, c.expectedErr, c.expectedFound, err, found)
+			}
+
+			if c.expectedErr || !found {
+				return
+			}
+
+			if !reflect.DeepEqual(c.expectedSettings, result) {
+				t.Errorf("Test case %d did not match\nExpected: %#v\nActual: %#v", i, c.expectedSettings, result)
+			}
+		})
+	}
+}
+
+func TestGetRobotCredentialsSettings(t *testing.T) {
+
+	cases := []struct {
+		name             string
+		robotCredentials *quayv1.RobotCredentials
+		expected         qotypes.RobotCredentialsSettings
+		expectedErr      bool
+	}{
+		{
+			name: "test-unset",
+			expected: qotypes.RobotCredentialsSettings{
+				Mode:            credentials.RobotCredentialsModeSecret,
+				StoreKind:       credentials.ClusterSecretStoreKind,
+				RefreshInterval: constants.DefaultRobotCredentialsRefreshInterval,
+			},
+		},
+		{
+			name:             "test-push-secret-default-remote-key",
+			robotCredentials: &quayv1.RobotCredentials{Mode: credentials.RobotCredentialsModePushSecret, SecretStoreRef: &quayv1.SecretStoreRef{Name: "vault"}},
+			expected: qotypes.RobotCredentialsSettings{
+				Mode:            credentials.RobotCredentialsModePushSecret,
+				StoreName:       "vault",
+				StoreKind:       credentials.ClusterSecretStoreKind,
+				RemoteKey:       "quay-bridge-operator/project/builder",
+				RefreshInterval: constants.DefaultRobotCredentialsRefreshInterval,
+			},
+		},
+		{
+			name: "test-external-secret-custom-remote-key",
+			robotCredentials: &quayv1.RobotCredentials{
+				Mode:              credentials.RobotCredentialsModeExternalSecret,
+				SecretStoreRef:    &quayv1.SecretStoreRef{Name: "vault", Kind: credentials.SecretStoreKind},
+				RemoteKeyTemplate: "quay/{{.Organization}}/{{.Robot}}",
+				RefreshInterval:   &metav1.Duration{Duration: time.Minute * 10},
+			},
+			expected: qotypes.RobotCredentialsSettings{
+				Mode:            credentials.RobotCredentialsModeExternalSecret,
+				StoreName:       "vault",
+				StoreKind:       credentials.SecretStoreKind,
+				RemoteKey:       "quay/cluster_project/cluster_project+builder",
+				RefreshInterval: time.Minute * 10,
+			},
+		},
+		{
+			name:             "test-reference",
+			robotCredentials: &quayv1.RobotCredentials{Mode: credentials.RobotCredentialsModeReference},
+			expected: qotypes.RobotCredentialsSettings{
+				Mode:            credentials.RobotCredentialsModeReference,
+				StoreKind:       credentials.ClusterSecretStoreKind,
+				RemoteKey:       "quay-bridge-operator/project/builder",
+				RefreshInterval: constants.DefaultRobotCredentialsRefreshInterval,
+			},
+		},
+		{
+			name:             "test-missing-secret-store",
+			robotCredentials: &quayv1.RobotCredentials{Mode: credentials.RobotCredentialsModeExternalSecret},
+			expectedErr:      true,
+		},
+		{
+			name:             "test-invalid-mode",
+			robotCredentials: &quayv1.RobotCredentials{Mode: "Vault"},
+			expectedErr:      true,
+		},
+		{
+			name:             "test-invalid-remote-key-template",
+			robotCredentials: &quayv1.RobotCredentials{Mode: credentials.RobotCredentialsModeReference, RemoteKeyTemplate: "{{.Namespace"},
+			expectedErr:      true,
+		},
+	}
+
+	for i, c := range cases {
+
+		t.Run(c.name, func(t *testing.T) {
+
+			quayIntegration := &quayv1.QuayIntegration{Spec: quayv1.QuayIntegrationSpec{RobotCredentials: c.robotCredentials}}
+
+			result, err := GetRobotCredentialsSettings(quayIntegration, "project", "builder", "cluster_project", "cluster_project+builder")
+
+			if c.expectedErr != (err != nil) {
+				t.Errorf("Test case %d did not match\nExpected Error: %#v\nActual: %#v", i, c.expectedErr, err)
+			}
+
+			if !c.expectedErr && !reflect.DeepEqual(c.expected, result) {
+				t.Errorf("Test case %d did not match\nExpected: %#v\nActual: %#v", i, c.expected, result)
+			}
+		})
+	}
+}
+
+func TestLinkServiceAccountSecrets(t *testing.T) {
+
+	cases := []struct {
+		name                     string
+		serviceAccount           *corev1.ServiceAccount
+		desired                  []string
+		expectedImagePullSecrets []corev1.LocalObjectReference
+		expectedSecrets          []corev1.ObjectReference
+		expectedAnnotations      map[string]string
+		expectedUpdated          bool
+	}{
+		{
+			name:                     "test-link-new-secret",
+			serviceAccount:           &corev1.ServiceAccount{},
+			desired:                  []string{"builder-quay-cluster"},
+			expectedImagePullSecrets: []corev1.LocalObjectReference{{Name: "builder-quay-cluster"}},
+			expectedSecrets:          []corev1.ObjectReference{{Name: "builder-quay-cluster"}},
+			expectedAnnotations:      map[string]string{constants.LinkedSecretsAnnotation: "builder-quay-cluster"},
+			expectedUpdated:          true,
+		},
+		{
+			name: "test-already-linked",
+			serviceAccount: &corev1.ServiceAccount{
+				ObjectMeta:       metav1.ObjectMeta{Annotations: map[string]string{constants.LinkedSecretsAnnotation: "builder-quay-cluster"}},
+				ImagePullSecrets: []corev1.LocalObjectReference{{Name: "builder-dockercfg-abcde"}, {Name: "builder-quay-cluster"}},
+				Secrets:          []corev1.ObjectReference{{Name: "builder-quay-cluster"}},
+			},
+			desired:                  []string{"builder-quay-cluster"},
+			expectedImagePullSecrets: []corev1.LocalObjectReference{{Name: "builder-dockercfg-abcde"}, {Name: "builder-quay-cluster"}},
+			expectedSecrets:          []corev1.ObjectReference{{Name: "builder-quay-cluster"}},
+			expectedAnnotations:      map[string]string{constants.LinkedSecretsAnnotation: "builder-quay-cluster"},
+		},
+		{
+			name: "test-replace-stale-secret",
+			serviceAccount: &corev1.ServiceAccount{
+				ObjectMeta:       metav1.ObjectMeta{Annotations: map[string]string{constants.LinkedSecretsAnnotation: "builder-quay-old"}},
+				ImagePullSecrets: []corev1.LocalObjectReference{{Name: "builder-dockercfg-abcde"}, {Name: "builder-quay-old"}},
+				Secrets:          []corev1.ObjectReference{{Name: "builder-quay-old"}},
+			},
+			desired:                  []string{"builder-quay-cluster"},
+			expectedImagePullSecrets: []corev1.LocalObjectReference{{Name: "builder-dockercfg-abcde"}, {Name: "builder-quay-cluster"}},
+			expectedSecrets:          []corev1.ObjectReference{{Name: "builder-quay-cluster"}},
+			expectedAnnotations:      map[string]string{constants.LinkedSecretsAnnotation: "builder-quay-cluster"},
+			expectedUpdated:          true,
+		},
+		{
+			name: "test-unlink-all",
+			serviceAccount: &corev1.ServiceAccount{
+				ObjectMeta:       metav1.ObjectMeta{Annotations: map[string]string{constants.LinkedSecretsAnnotation: "builder-quay-cluster"}},
+				ImagePullSecrets: []corev1.LocalObjectReference{{Name: "builder-dockercfg-abcde"}, {Name: "builder-quay-cluster"}},
+				Secrets:          []corev1.ObjectReference{{Name: "builder-quay-cluster"}},
+			},
+			expectedImagePullSecrets: []corev1.LocalObjectReference{{Name: "builder-dockercfg-abcde"}},
+			expectedSecrets:          []corev1.ObjectReference{},
+			expectedAnnotations:      map[string]string{},
+			expectedUpdated:          true,
+		},
+	}
+
+	for i, c := range cases {
+
+		t.Run(c.name, func(t *testing.T) {
+
+			updated := LinkServiceAccountSecrets(c.serviceAccount, c.desired)
+
+			if updated != c.expectedUpdated {
+				t.Errorf("Test case %d did not match\nExpected Updated: %#v\nActual: %#v", i, c.expectedUpdated, updated)
+			}
+
+			if !reflect.DeepEqual(c.expectedImagePullSecrets, c.serviceAccount.ImagePullSecrets) {
+				t.Errorf("Test case %d did not match\nExpected: %#v\nActual: %#v", i, c.expectedImagePullSecrets, c.serviceAccount.ImagePullSecrets)
+			}
+
+			if !reflect.DeepEqual(c.expectedSecrets, c.serviceAccount.Secrets) {
+				t.Errorf("Test case %d did not match\nExpected: %#v\nActual: %#v", i, c.expectedSecrets, c.serviceAccount.Secrets)
+			}
+
+			if !reflect.DeepEqual(c.expectedAnnotations, c.serviceAccount.Annotations) {
+				t.Errorf("Test case %d did not match\nExpected: %#v\nActual: %#v", i, c.expectedAnnotations, c.serviceAccount.Annotations)
+			}
+		})
+	}
+}
+
+func TestSetManagedTeamMembers(t *testing.T) {
+
+	annotation := constants.TeamMembersAnnotationPrefix + string(qotypes.EditorsQuayTeam)
+
+	cases := []struct {
+		name                string
+		namespace           *corev1.Namespace
+		members             []string
+		expectedAnnotations map[string]string
+		expectedUpdated     bool
+	}{
+		{
+			name:                "test-record-members",
+			namespace:           &corev1.Namespace{},
+			members:             []string{"jdoe", "alice"},
+			expectedAnnotations: map[string]string{annotation: "alice,jdoe"},
+			expectedUpdated:     true,
+		},
+		{
+			name:                "test-unchanged-members",
+			namespace:           &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Annotations: map[string]string{annotation: "alice,jdoe"}}},
+			members:             []string{"jdoe", "alice"},
+			expectedAnnotations: map[string]string{annotation: "alice,jdoe"},
+		},
+		{
+			name:                "test-remove-members",
+			namespace:           &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Annotations: map[string]string{annotation: "alice,jdoe"}}},
+			members:             []string{},
+			expectedAnnotations: map[string]string{},
+			expectedUpdated:     true,
+		},
+		{
+			name:      "test-no-members",
+			namespace: &corev1.Namespace{},
+			members:   []string{},
+		},
+	}
+
+	for i, c := range cases {
+
+		t.Run(c.name, func(t *testing.T) {
+
+			updated := SetManagedTeamMembers(c.namespace, qotypes.EditorsQuayTeam, c.members)
+
+			if updated != c.expectedUpdated {
+				t.Errorf("Test case %d did not match\nExpected Updated: %#v\nActual: %#v", i, c.expectedUpdated, updated)
+			}
+
+			if !reflect.DeepEqual(c.expectedAnnotations, c.namespace.Annotations) {
+				t.Errorf("Test case %d did not match\nExpected: %#v\nActual: %#v", i, c.expectedAnnotations, c.namespace.Annotations)
+			}
+
+			if c.expectedUpdated && len(c.members) > 0 && !reflect.DeepEqual(GetManagedTeamMembers(c.namespace, qotypes.EditorsQuayTeam), []string{"alice", "jdoe"}) {
+				t.Errorf("Test case %d did not match\nExpected: %#v\nActual: %#v", i, []string{"alice", "jdoe"}, GetManagedTeamMembers(c.namespace, qotypes.EditorsQuayTeam))
+			}
+		})
+	}
+}
+
+func TestGetOrganizationOwnership(t *testing.T) {
+
+	ownedBy := func(clusterID string, origin string) *qclient.RobotAccount {
+		return &qclient.RobotAccount{
+			Name: "myorg+quaybridge",
+			UnstructuredMetadata: map[string]interface{}{
+				constants.OwnershipMetadataClusterIDKey: clusterID,
+				constants.OwnershipMetadataOriginKey:    origin,
+			},
+		}
+	}
+
+	cases := []struct {
+		name                  string
+		ownershipRobotAccount *qclient.RobotAccount
+		previouslyManaged     bool
+		adopt                 bool
+		expected              qotypes.OrganizationOwnership
+	}{
+		{
+			name:                  "test-owned",
+			ownershipRobotAccount: ownedBy("cluster", constants.OwnershipOriginCreated),
+			expected:              qotypes.OrganizationOwnership{Owned: true, Origin: constants.OwnershipOriginCreated, Owner: "cluster"},
+		},
+		{
+			name:                  "test-owned-by-other-cluster",
+			ownershipRobotAccount: ownedBy("other", constants.OwnershipOriginCreated),
+			previouslyManaged:     true,
+			expected:              qotypes.OrganizationOwnership{Origin: constants.OwnershipOriginCreated, Owner: "other"},
+		},
+		{
+			name:                  "test-adopt-from-other-cluster",
+			ownershipRobotAccount: ownedBy("other", constants.OwnershipOriginCreated),
+			adopt:                 true,
+			expected:              qotypes.OrganizationOwnership{Owned: true, Claim: true, Origin: constants.OwnershipOriginAdopted, Owner: "other"},
+		},
+		{
+			name:              "test-claim-previously-managed",
+			previouslyManaged: true,
+			expected:          qotypes.OrganizationOwnership{Owned: true, Claim: true, Origin: constants.OwnershipOriginCreated},
+		},
+		{
+			name:     "test-adopt-unowned",
+			adopt:    true,
+			expected: qotypes.OrganizationOwnership{Owned: true, Claim: true, Origin: constants.OwnershipOriginAdopted},
+		},
+		{
+			name:     "test-foreign",
+			expected: qotypes.OrganizationOwnership{},
+		},
+	}
+
+	for i, c := range cases {
+
+		t.Run(c.name, func(t *testing.T) {
+
+			ownership := GetOrganizationOwnership(c.ownershipRobotAccount, "cluster", c.previouslyManaged, c.adopt)
+
+			if !reflect.DeepEqual(c.expected, ownership) {
+				t.Errorf("Test case %d did not match\nExpected: %#v\nActual: %#v", i, c.expected, ownership)
+			}
+		})
+	}
+}
+
+func TestIsAdoptionRequested(t *testing.T) {
+
+	cases := []struct {
+		name          string
+		imageStream   *imagev1.ImageStream
+		namespace     *corev1.Namespace
+		expected      bool
+		expectedError bool
+	}{
+		{
+			name:        "test-not-requested",
+			imageStream: &imagev1.ImageStream{},
+			namespace:   &corev1.Namespace{},
+		},
+		{
+			name:        "test-namespace-requested",
+			imageStream: &imagev1.ImageStream{},
+			namespace:   &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Annotations: map[string]string{constants.AdoptAnnotation: "true"}}},
+			expected:    true,
+		},
+		{
+			name:        "test-imagestream-override",
+			imageStream: &imagev1.ImageStream{ObjectMeta: metav1.ObjectMeta{Annotations: map[string]string{constants.AdoptAnnotation: "false"}}},
+			namespace:   &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Annotations: map[string]string{constants.AdoptAnnotation: "true"}}},
+		},
+		{
+			name:          "test-invalid",
+			imageStream:   &imagev1.ImageStream{},
+			namespace:     &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Annotations: map[string]string{constants.AdoptAnnotation: "yes please"}}},
+			expectedError: true,
+		},
+	}
+
+	for i, c := range cases {
+
+		t.Run(c.name, func(t *testing.T) {
+
+			adopt, err := IsAdoptionRequested(c.imageStream, c.namespace)
+
+			if (err != nil) != c.expectedError {
+				t.Errorf("Test case %d did not match\nExpected Error: %#v\nActual: %#v", i, c.expectedError, err)
+			}
+
+			if adopt != c.expected {
+				t.Errorf("Test case %d did not match\nExpected: %#v\nActual: %#v", i, c.expected, adopt)
+			}
+		})
+	}
+}
+
+func TestIsFinalizationForced(t *testing.T) {
+
+	cases := []struct {
+		name            string
+		namespace       *corev1.Namespace
+		quayIntegration *quayv1.QuayIntegration
+		expected        bool
+	}{
+		{
+			name:            "test-not-forced",
+			namespace:       &corev1.Namespace{},
+			quayIntegration: &quayv1.QuayIntegration{},
+		},
+		{
+			name:            "test-quayintegration-forced",
+			namespace:       &corev1.Namespace{},
+			quayIntegration: &quayv1.QuayIntegration{ObjectMeta: metav1.ObjectMeta{Annotations: map[string]string{constants.ForceFinalizeAnnotation: "true"}}},
+			expected:        true,
+		},
+		{
+			name:            "test-namespace-override",
+			namespace:       &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Annotations: map[string]string{constants.ForceFinalizeAnnotation: "false"}}},
+			quayIntegration: &quayv1.QuayIntegration{ObjectMeta: metav1.ObjectMeta{Annotations: map[string]string{constants.ForceFinalizeAnnotation: "true"}}},
+		},
+		{
+			name:            "test-invalid",
+			namespace:       &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Annotations: map[string]string{constants.ForceFinalizeAnnotation: "now"}}},
+			quayIntegration: &quayv1.QuayIntegration{},
+		},
+	}
+
+	for i, c := range cases {
+
+		t.Run(c.name, func(t *testing.T) {
+
+			forced := IsFinalizationForced(c.namespace, c.quayIntegration)
+
+			if forced != c.expected {
+				t.Errorf("Test case %d did not match\nExpected: %#v\nActual: %#v", i, c.expected, forced)
+			}
+		})
+	}
+}
+
+func TestIsClusterClaimExpired(t *testing.T) {
+
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	cases := []struct {
+		name      string
+		renewTime time.Time
+		expected  bool
+	}{
+		{
+			name:      "test-renewed",
+			renewTime: now.Add(-constants.ClusterClaimRenewPeriod),
+		},
+		{
+			name:      "test-lease-end",
+			renewTime: now.Add(-constants.ClusterClaimLeaseDuration),
+		},
+		{
+			name:      "test-expired",
+			renewTime: now.Add(-constants.ClusterClaimLeaseDuration - time.Second),
+			expected:  true,
+		},
+		{
+			name:     "test-never-renewed",
+			expected: true,
+		},
+	}
+
+	for i, c := range cases {
+
+		t.Run(c.name, func(t *testing.T) {
+
+			expired := IsClusterClaimExpired(c.renewTime, now)
+
+			if expired != c.expected {
+				t.Errorf("Test case %d did not match\nExpected: %#v\nActual: %#v", i, c.expected, expired)
+			}
+		})
+	}
+}
+
+func TestGetClusterClaim(t *testing.T) {
+
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	claimedBy := func(clusterUID string, renewTime string) *qclient.RobotAccount {
+		return &qclient.RobotAccount{
+			Name: "cluster.quaybridge+clusterclaim",
+			UnstructuredMetadata: map[string]interface{}{
+				constants.ClusterClaimMetadataClusterUIDKey: clusterUID,
+				constants.ClusterClaimMetadataRenewTimeKey:  renewTime,
+			},
+		}
+	}
+
+	cases := []struct {
+		name              string
+		claimRobotAccount *qclient.RobotAccount
+		takeoverUID       string
+		expected          qotypes.ClusterClaim
+	}{
+		{
+			name:     "test-unclaimed",
+			expected: qotypes.ClusterClaim{Held: true, Renew: true},
+		},
+		{
+			name:              "test-held",
+			claimRobotAccount: claimedBy("uid", "2024-01-01T11:58:00Z"),
+			expected:          qotypes.ClusterClaim{Held: true, HolderUID: "uid", RenewTime: time.Date(2024, 1, 1, 11, 58, 0, 0, time.UTC)},
+		},
+		{
+			name:              "test-held-renew",
+			claimRobotAccount: claimedBy("uid", "2024-01-01T11:50:00Z"),
+			expected:          qotypes.ClusterClaim{Held: true, Renew: true, HolderUID: "uid", RenewTime: time.Date(2024, 1, 1, 11, 50, 0, 0, time.UTC)},
+		},
+		{
+			name:              "test-claimed-by-live-cluster",
+			claimRobotAccount: claimedBy("other", "2024-01-01T11:58:00Z"),
+			expected:          qotypes.ClusterClaim{HolderUID: "other", RenewTime: time.Date(2024, 1, 1, 11, 58, 0, 0, time.UTC)},
+		},
+		{
+			name:              "test-takeover-mismatch",
+			claimRobotAccount: claimedBy("other", "2024-01-01T11:58:00Z"),
+			takeoverUID:       "another",
+			expected:          qotypes.ClusterClaim{HolderUID: "other", RenewTime: time.Date(2024, 1, 1, 11, 58, 0, 0, time.UTC)},
+		},
+		{
+			name:              "test-takeover",
+			claimRobotAccount: claimedBy("other", "2024-01-01T11:58:00Z"),
+			takeoverUID:       "other",
+			expected:          qotypes.ClusterClaim{Held: true, Renew: true, Takeover: true, HolderUID: "other", RenewTime: time.Date(2024, 1, 1, 11, 58, 0, 0, time.UTC)},
+		},
+		{
+			name:              "test-expired",
+			claimRobotAccount: claimedBy("other", "2024-01-01T11:00:00Z"),
+			expected:          qotypes.ClusterClaim{Held: true, Renew: true, Expired: true, HolderUID: "other", RenewTime: time.Date(2024, 1, 1, 11, 0, 0, 0, time.UTC)},
+		},
+		{
+			name:              "test-invalid-renew-time",
+			claimRobotAccount: claimedBy("other", "yesterday"),
+			expected:          qotypes.ClusterClaim{Held: true, Renew: true, Expired: true, HolderUID: "other"},
+		},
+	}
+
+	for i, c := range cases {
+
+		t.Run(c.name, func(t *testing.T) {
+
+			claim := GetClusterClaim(c.claimRobotAccount, "uid", c.takeoverUID, now)
+
+			if !reflect.DeepEqual(c.expected, claim) {
+				t.Errorf("Test case %d did not match\nExpected: %#v\nActual: %#v", i, c.expected, claim)
+			}
+		})
+	}
+}
@@ -0,0 +1,130 @@
+package webhook
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/go-logr/logr"
+	buildv1 "github.com/openshift/api/build/v1"
+	imagev1 "github.com/openshift/api/image/v1"
+	"github.com/quay/quay-bridge-operator/pkg/constants"
+	"github.com/quay/quay-bridge-operator/pkg/logging"
+	"github.com/quay/quay-bridge-operator/pkg/utils"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+type PodSecurityValidator struct {
+	Client  client.Client
+	decoder *admission.Decoder
+	Log     logr.Logger
+}
+
+// +kubebuilder:webhook:path=/validate-pods,mutating=false,failurePolicy=ignore,verbs=create,groups="",resources=pods,versions=v1,name=securityscan.quay.redhat.com,sideEffects=None,admissionReviewVersions={v1}
+
+func (v *PodSecurityValidator) Handle(ctx context.Context, req admission.Request) admission.Response {
+
+	pod := &corev1.Pod{}
+
+	err := v.decoder.Decode(req, pod)
+	if err != nil {
+		return admission.Errored(http.StatusBadRequest, err)
+	}
+
+	quayIntegration, found, err := findQuayIntegration(ctx, v.Client, req.Namespace)
+	if err != nil {
+		return admission.Errored(http.StatusInternalServerError, err)
+	}
+
+	if !found || quayIntegration.Spec.SecurityScan == nil || quayIntegration.Spec.SecurityScan.DenySeverity == "" {
+		return admission.Allowed("")
+	}
+
+	denySeverity := quayIntegration.Spec.SecurityScan.DenySeverity
+
+	containers := append(append([]corev1.Container{}, pod.Spec.InitContainers...), pod.Spec.Containers...)
+
+	for _, container := range containers {
+		highestSeverity, err := v.getHighestVulnerabilitySeverity(ctx, req.Namespace, container.Image)
+		if err != nil {
+			return admission.Errored(http.StatusInternalServerError, err)
+		}
+
+		if highestSeverity != "" && utils.IsSeverityAtLeast(highestSeverity, denySeverity) {
+			logging.Log.Info("Denying Pod using vulnerable image", "Namespace", req.Namespace, "Container", container.Name, "Image", container.Image, "Severity", highestSeverity)
+			return admission.Denied(fmt.Sprintf("Image '%s' of container '%s' has vulnerabilities of severity %s, which is at or above the permitted severity of %s", container.Image, container.Name, highestSeverity, denySeverity))
+		}
+	}
+
+	return admission.Allowed("")
+}
+
+// getHighestVulnerabilitySeverity returns the highest vulnerability severity recorded for an image pushed by a Build. Images which are not pinned by digest and cannot be resolved through an ImageStream, or were not pushed by a Build, have no recorded severity
+func (v *PodSecurityValidator) getHighestVulnerabilitySeverity(ctx context.Context, namespace string, image string) (string, error) {
+	digest, found := utils.GetImageDigest(image)
+	if !found {
+		var err error
+		digest, found, err = v.resolveImageDigest(ctx, namespace, image)
+		if err != nil || !found {
+			return "", err
+		}
+	}
+
+	builds := &buildv1.BuildList{}
+	err := v.Client.List(ctx, builds, client.MatchingFields{constants.BuildImportDigestIndex: digest})
+	if err != nil {
+		return "", err
+	}
+
+	for _, build := range builds.Items {
+		if severity := build.GetAnnotations()[constants.HighestVulnerabilitySeverityAnnotation]; severity != "" {
+			return severity, nil
+		}
+	}
+
+	return "", nil
+}
+
+// resolveImageDigest resolves an image reference by tag to the digest imported into the ImageStream tag it refers to
+func (v *PodSecurityValidator) resolveImageDigest(ctx context.Context, namespace string, image string) (string, bool, error) {
+	namespaces := []string{namespace}
+
+	// Images of the integrated registry are referenced as <registry>/<namespace>/<imagestream>
+	if components := strings.Split(utils.TrimImageReference(image), "/"); len(components) >= 3 && components[len(components)-2] != namespace {
+		namespaces = append(namespaces, components[len(components)-2])
+	}
+
+	for _, imageStreamNamespace := range namespaces {
+		imageStreams := &imagev1.ImageStreamList{}
+		err := v.Client.List(ctx, imageStreams, client.InNamespace(imageStreamNamespace))
+		if err != nil {
+			return "", false, err
+		}
+
+		for i := range imageStreams.Items {
+			if digest, found := utils.GetImageStreamTagDigest(&imageStreams.Items[i], image); found {
+				return digest, true, nil
+			}
+		}
+	}
+
+	return "", false, nil
+}
+
+// InjectDecoder injects the decoder.
+func (v *PodSecurityValidator) InjectDecoder(d *admission.Decoder) error {
+	v.decoder = d
+	return nil
+}
+
+// IndexBuildImportDigest indexes Builds by the digest of the image they pushed
+func IndexBuildImportDigest(object client.Object) []string {
+	if digest := object.GetAnnotations()[constants.BuildImportDigestAnnotation]; digest != "" {
+		return []string{digest}
+	}
+
+	return nil
+}
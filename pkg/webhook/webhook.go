@@ -9,6 +9,7 @@ import (
 
 	"github.com/go-logr/logr"
 	buildv1 "github.com/openshift/api/build/v1"
+	imagev1 "github.com/openshift/api/image/v1"
 	quayv1 "github.com/quay/quay-bridge-operator/api/v1"
 	"github.com/quay/quay-bridge-operator/pkg/constants"
 	"github.com/quay/quay-bridge-operator/pkg/logging"
@@ -17,6 +18,8 @@ import (
 	jsonpatch "gomodules.xyz/jsonpatch/v2"
 	admissionv1 "k8s.io/api/admission/v1"
 	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -78,8 +81,22 @@ func (q *QuayIntegrationMutator) Handle(ctx context.Context, req admission.Reque
 					},
 				}
 			}
+		} else if crossNamespaceErr := q.checkCrossNamespaceOutput(ctx, &req, build, &quayIntegration); crossNamespaceErr != nil {
+			admissionResponse = &admissionv1.AdmissionResponse{
+				Allowed: false,
+				Result: &metav1.Status{
+					Message: crossNamespaceErr.Error(),
+				},
+			}
+		} else if quayOrganizationName, quayRepositoryName, repositoryErr := q.getQuayRepository(ctx, &req, build, &quayIntegration); repositoryErr != nil {
+			admissionResponse = &admissionv1.AdmissionResponse{
+				Allowed: false,
+				Result: &metav1.Status{
+					Message: repositoryErr.Error(),
+				},
+			}
 		} else {
-			admissionResponse = getAdmissionResponseForBuild(build, &quayIntegration)
+			admissionResponse = getAdmissionResponseForBuild(build, &quayIntegration, quayOrganizationName, quayRepositoryName)
 		}
 
 	}
@@ -104,12 +121,88 @@ func (q *QuayIntegrationMutator) checkSecretForBuilderServiceAccount(ctx context
 	return hasSecret, nil
 }
 
+// checkCrossNamespaceOutput verifies a Build pushing to an ImageStream in another namespace has been granted the image pusher role in that namespace
+func (q *QuayIntegrationMutator) checkCrossNamespaceOutput(ctx context.Context, ar *admission.Request, build *buildv1.Build, quayIntegration *quayv1.QuayIntegration) error {
+	if build.Spec.Output.To == nil || build.Spec.Output.To.Kind != "ImageStreamTag" || build.Spec.Output.To.Namespace == "" || build.Spec.Output.To.Namespace == ar.Namespace {
+		return nil
+	}
+
+	targetNamespace := build.Spec.Output.To.Namespace
+
+	if !quayIntegration.IsAllowedNamespace(targetNamespace) {
+		return fmt.Errorf("Build output namespace '%s' is not managed by the Quay integration", targetNamespace)
+	}
+
+	roleBindings := &rbacv1.RoleBindingList{}
+	err := q.Client.List(ctx, roleBindings, &client.ListOptions{Namespace: targetNamespace})
+	if err != nil {
+		return err
+	}
+
+	for i := range roleBindings.Items {
+		for _, pusherNamespace := range utils.GetImagePusherNamespaces(&roleBindings.Items[i]) {
+			if pusherNamespace == ar.Namespace {
+				return nil
+			}
+		}
+	}
+
+	logging.Log.Info("Builder service account has not been granted image pusher role", "Namespace", ar.Namespace, "Target Namespace", targetNamespace)
+	return fmt.Errorf("The builder service account in namespace '%s' must be granted the '%s' role in namespace '%s' to push to its ImageStreams", ar.Namespace, constants.ImagePusherRole, targetNamespace)
+}
+
+// getQuayRepository resolves the Quay organization and repository of the ImageStream a Build pushes to
+func (q *QuayIntegrationMutator) getQuayRepository(ctx context.Context, ar *admission.Request, build *buildv1.Build, quayIntegration *quayv1.QuayIntegration) (string, string, error) {
+	if build.Spec.Output.To == nil || build.Spec.Output.To.Kind != "ImageStreamTag" {
+		return "", "", nil
+	}
+
+	destinationNamespace := ar.Namespace
+	if build.Spec.Output.To.Namespace != "" {
+		destinationNamespace = build.Spec.Output.To.Namespace
+	}
+
+	namespace := &corev1.Namespace{}
+	if err := q.Client.Get(ctx, types.NamespacedName{Name: destinationNamespace}, namespace); err != nil {
+		return "", "", err
+	}
+
+	target, err := utils.GetOrganizationTarget(quayIntegration, namespace)
+	if err != nil {
+		return "", "", fmt.Errorf("Invalid Quay organization for namespace '%s': %w", destinationNamespace, err)
+	}
+
+	// The repository of an ImageStream that does not exist yet is derived from its name alone
+	imageStreamName := strings.Split(build.Spec.Output.To.Name, ":")[0]
+
+	imageStream := &imagev1.ImageStream{}
+	if err := q.Client.Get(ctx, types.NamespacedName{Namespace: destinationNamespace, Name: imageStreamName}, imageStream); err != nil {
+		if !apierrors.IsNotFound(err) {
+			return "", "", err
+		}
+
+		imageStream = &imagev1.ImageStream{ObjectMeta: metav1.ObjectMeta{Name: imageStreamName, Namespace: destinationNamespace}}
+	}
+
+	repositoryName, err := utils.GetRepositoryName(target, imageStream)
+	if err != nil {
+		return "", "", fmt.Errorf("Invalid Quay repository for ImageStream '%s/%s': %w", destinationNamespace, imageStreamName, err)
+	}
+
+	return target.Organization, repositoryName, nil
+}
+
 func (q *QuayIntegrationMutator) getQuayIntegration(ctx context.Context, ar *admission.Request) (quayv1.QuayIntegration, bool, error) {
+	return findQuayIntegration(ctx, q.Client, ar.Namespace)
+}
+
+// findQuayIntegration returns the QuayIntegration if the namespace is managed by it
+func findQuayIntegration(ctx context.Context, c client.Client, namespace string) (quayv1.QuayIntegration, bool, error) {
 
 	// Find the Current Registered QuayIntegration objects
 	quayIntegrations := quayv1.QuayIntegrationList{}
 
-	err := q.Client.List(ctx, &quayIntegrations, &client.ListOptions{})
+	err := c.List(ctx, &quayIntegrations, &client.ListOptions{})
 
 	if err != nil {
 		return quayv1.QuayIntegration{}, false, err
@@ -123,7 +216,7 @@ func (q *QuayIntegrationMutator) getQuayIntegration(ctx context.Context, ar *adm
 	quayIntegration := *&quayIntegrations.Items[0]
 
 	// Check is this is a valid namespace (TODO: Use a predicate to filter out?)
-	validNamespace := quayIntegration.IsAllowedNamespace(ar.Namespace)
+	validNamespace := quayIntegration.IsAllowedNamespace(namespace)
 
 	if !validNamespace {
 		return quayv1.QuayIntegration{}, false, nil
@@ -132,13 +225,13 @@ func (q *QuayIntegrationMutator) getQuayIntegration(ctx context.Context, ar *adm
 	return quayIntegration, true, nil
 }
 
-func getAdmissionResponseForBuild(build *buildv1.Build, quayIntegration *quayv1.QuayIntegration) *admissionv1.AdmissionResponse {
+func getAdmissionResponseForBuild(build *buildv1.Build, quayIntegration *quayv1.QuayIntegration, quayOrganizationName string, quayRepositoryName string) *admissionv1.AdmissionResponse {
 
 	var patch []jsonpatch.JsonPatchOperation
 
 	quayRegistryHostname, err := quayIntegration.GetRegistryHostname()
 
-	if (build.Spec.Strategy.DockerStrategy == nil && build.Spec.Strategy.SourceStrategy == nil) || build.Spec.CommonSpec.Output.To.Kind != "ImageStreamTag" {
+	if (build.Spec.Strategy.DockerStrategy == nil && build.Spec.Strategy.SourceStrategy == nil) || build.Spec.CommonSpec.Output.To == nil || build.Spec.CommonSpec.Output.To.Kind != "ImageStreamTag" {
 		return &admissionv1.AdmissionResponse{
 			Allowed: true,
 		}
@@ -150,10 +243,34 @@ func getAdmissionResponseForBuild(build *buildv1.Build, quayIntegration *quayv1.
 		imageStreamDestinationNamespace = build.Spec.CommonSpec.Output.To.Namespace
 	}
 
-	// Get ImageStream Name and Tag
-	imageStremParts := strings.Split(build.Spec.Output.To.Name, ":")
+	// Get ImageStream Name and Tag, which defaults to latest as in OpenShift
+	imageStreamName, imageStreamTag, _ := strings.Cut(build.Spec.Output.To.Name, ":")
+	if imageStreamTag == "" {
+		imageStreamTag = constants.DefaultImageTag
+	}
 
-	dockerImage := fmt.Sprintf("%s/%s/%s:%s", quayRegistryHostname, quayIntegration.GenerateQuayOrganizationNameFromNamespace(imageStreamDestinationNamespace), imageStremParts[0], imageStremParts[1])
+	destinations := []qotypes.ImageStreamTagDestination{
+		{Namespace: imageStreamDestinationNamespace, Name: imageStreamName, Tag: imageStreamTag},
+	}
+
+	// Add any additional tags requested on the Build
+	additionalTags, err := utils.ParseImageTags(build.GetAnnotations()[constants.BuildAdditionalTagsAnnotation])
+	if err != nil {
+		return &admissionv1.AdmissionResponse{
+			Allowed: false,
+			Result: &metav1.Status{
+				Message: fmt.Sprintf("Invalid value for annotation '%s': %s", constants.BuildAdditionalTagsAnnotation, err.Error()),
+			},
+		}
+	}
+
+	for _, additionalTag := range additionalTags {
+		if additionalTag != imageStreamTag {
+			destinations = append(destinations, qotypes.ImageStreamTagDestination{Namespace: imageStreamDestinationNamespace, Name: imageStreamName, Tag: additionalTag})
+		}
+	}
+
+	dockerImage := fmt.Sprintf("%s/%s/%s:%s", quayRegistryHostname, quayOrganizationName, quayRepositoryName, imageStreamTag)
 
 	// Update the Kind
 	patch = append(patch, jsonpatch.JsonPatchOperation{
@@ -177,6 +294,15 @@ func getAdmissionResponseForBuild(build *buildv1.Build, quayIntegration *quayv1.
 		})
 	}
 
+	// Annotations can only be added to a Build that already has some
+	if build.GetAnnotations() == nil {
+		patch = append(patch, jsonpatch.JsonPatchOperation{
+			Operation: "add",
+			Path:      "/metadata/annotations",
+			Value:     map[string]string{},
+		})
+	}
+
 	// Add annotations to Build to for Build Controller to use
 	patch = append(patch, jsonpatch.JsonPatchOperation{
 		Operation: "add",
@@ -187,7 +313,7 @@ func getAdmissionResponseForBuild(build *buildv1.Build, quayIntegration *quayv1.
 	patch = append(patch, jsonpatch.JsonPatchOperation{
 		Operation: "add",
 		Path:      "/metadata/annotations/" + escapeJSONPointer(constants.BuildDestinationImageStreamAnnotation),
-		Value:     fmt.Sprintf("%s/%s:%s", imageStreamDestinationNamespace, imageStremParts[0], imageStremParts[1]),
+		Value:     utils.FormatImageStreamTagDestinations(destinations),
 	})
 
 	patchBytes, err := json.Marshal(patch)
@@ -0,0 +1,154 @@
+package webhook
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	jsonpatch "github.com/evanphx/json-patch/v5"
+	buildv1 "github.com/openshift/api/build/v1"
+	quayv1 "github.com/quay/quay-bridge-operator/api/v1"
+	"github.com/quay/quay-bridge-operator/pkg/constants"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestGetAdmissionResponseForBuild(t *testing.T) {
+
+	quayIntegration := &quayv1.QuayIntegration{
+		Spec: quayv1.QuayIntegrationSpec{
+			ClusterID:    "openshift",
+			QuayHostname: "https://quay.example.com",
+		},
+	}
+
+	newBuild := func(annotations map[string]string, to *corev1.ObjectReference) *buildv1.Build {
+		return &buildv1.Build{
+			ObjectMeta: metav1.ObjectMeta{Name: "app-1", Namespace: "project", Annotations: annotations},
+			Spec: buildv1.BuildSpec{
+				CommonSpec: buildv1.CommonSpec{
+					Strategy: buildv1.BuildStrategy{Type: buildv1.DockerBuildStrategyType, DockerStrategy: &buildv1.DockerBuildStrategy{}},
+					Output:   buildv1.BuildOutput{To: to},
+				},
+			},
+		}
+	}
+
+	cases := []struct {
+		name         string
+		build        *buildv1.Build
+		organization string
+		repository   string
+		allowed      bool
+		expected     *buildv1.Build
+	}{
+		{
+			name:         "test-docker-image-output-unchanged",
+			build:        newBuild(nil, &corev1.ObjectReference{Kind: "DockerImage", Name: "quay.io/org/app:latest"}),
+			organization: "openshift_project",
+			repository:   "app",
+			allowed:      true,
+			expected:     newBuild(nil, &corev1.ObjectReference{Kind: "DockerImage", Name: "quay.io/org/app:latest"}),
+		},
+		{
+			name:         "test-imagestreamtag-output-without-annotations",
+			build:        newBuild(nil, &corev1.ObjectReference{Kind: "ImageStreamTag", Name: "app:latest"}),
+			organization: "openshift_project",
+			repository:   "app",
+			allowed:      true,
+			expected: newBuild(map[string]string{
+				constants.BuildOperatorManagedAnnotation:        "true",
+				constants.BuildDestinationImageStreamAnnotation: "project/app:latest",
+			}, &corev1.ObjectReference{Kind: "DockerImage", Name: "quay.example.com/openshift_project/app:latest"}),
+		},
+		{
+			name:         "test-imagestreamtag-output-other-namespace",
+			build:        newBuild(map[string]string{"openshift.io/build-config.name": "app"}, &corev1.ObjectReference{Kind: "ImageStreamTag", Namespace: "shared", Name: "app:latest"}),
+			organization: "openshift_shared",
+			repository:   "app",
+			allowed:      true,
+			expected: newBuild(map[string]string{
+				"openshift.io/build-config.name":                "app",
+				constants.BuildOperatorManagedAnnotation:        "true",
+				constants.BuildDestinationImageStreamAnnotation: "shared/app:latest",
+			}, &corev1.ObjectReference{Kind: "DockerImage", Name: "quay.example.com/openshift_shared/app:latest"}),
+		},
+		{
+			name:         "test-imagestreamtag-output-shared-organization",
+			build:        newBuild(nil, &corev1.ObjectReference{Kind: "ImageStreamTag", Name: "app:latest"}),
+			organization: "platform",
+			repository:   "project_app",
+			allowed:      true,
+			expected: newBuild(map[string]string{
+				constants.BuildOperatorManagedAnnotation:        "true",
+				constants.BuildDestinationImageStreamAnnotation: "project/app:latest",
+			}, &corev1.ObjectReference{Kind: "DockerImage", Name: "quay.example.com/platform/project_app:latest"}),
+		},
+		{
+			name:         "test-imagestreamtag-output-nested-repository",
+			build:        newBuild(nil, &corev1.ObjectReference{Kind: "ImageStreamTag", Name: "app:latest"}),
+			organization: "openshift_project",
+			repository:   "payments/app",
+			allowed:      true,
+			expected: newBuild(map[string]string{
+				constants.BuildOperatorManagedAnnotation:        "true",
+				constants.BuildDestinationImageStreamAnnotation: "project/app:latest",
+			}, &corev1.ObjectReference{Kind: "DockerImage", Name: "quay.example.com/openshift_project/payments/app:latest"}),
+		},
+		{
+			name:         "test-imagestreamtag-output-without-tag",
+			build:        newBuild(nil, &corev1.ObjectReference{Kind: "ImageStreamTag", Name: "app"}),
+			organization: "openshift_project",
+			repository:   "app",
+			allowed:      true,
+			expected: newBuild(map[string]string{
+				constants.BuildOperatorManagedAnnotation:        "true",
+				constants.BuildDestinationImageStreamAnnotation: "project/app:latest",
+			}, &corev1.ObjectReference{Kind: "DockerImage", Name: "quay.example.com/openshift_project/app:latest"}),
+		},
+		{
+			name:         "test-invalid-additional-tags",
+			build:        newBuild(map[string]string{constants.BuildAdditionalTagsAnnotation: "in valid"}, &corev1.ObjectReference{Kind: "ImageStreamTag", Name: "app:latest"}),
+			organization: "openshift_project",
+			repository:   "app",
+			allowed:      false,
+		},
+	}
+
+	for i, c := range cases {
+
+		t.Run(c.name, func(t *testing.T) {
+
+			response := getAdmissionResponseForBuild(c.build, quayIntegration, c.organization, c.repository)
+
+			if response.Allowed != c.allowed {
+				t.Errorf("Test case %d did not match\nExpected Allowed: %t\nActual: %t (%v)", i, c.allowed, response.Allowed, response.Result)
+			}
+
+			if !c.allowed {
+				return
+			}
+
+			buildBytes, _ := json.Marshal(c.build)
+
+			if len(response.Patch) > 0 {
+				patch, err := jsonpatch.DecodePatch(response.Patch)
+				if err != nil {
+					t.Fatalf("Test case %d returned an invalid patch: %v", i, err)
+				}
+
+				buildBytes, err = patch.Apply(buildBytes)
+				if err != nil {
+					t.Fatalf("Test case %d returned a patch that cannot be applied: %v", i, err)
+				}
+			}
+
+			actual := &buildv1.Build{}
+			_ = json.Unmarshal(buildBytes, actual)
+
+			if !reflect.DeepEqual(c.expected, actual) {
+				t.Errorf("Test case %d did not match\nExpected: %#v\nActual: %#v", i, c.expected, actual)
+			}
+		})
+	}
+}